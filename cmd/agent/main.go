@@ -2,21 +2,27 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"net"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kennethnrk/edgernetes-ai/internal/agent"
 	grpcagent "github.com/kennethnrk/edgernetes-ai/internal/agent/api/grpc"
+	httpagent "github.com/kennethnrk/edgernetes-ai/internal/agent/api/http"
 	agentmonitor "github.com/kennethnrk/edgernetes-ai/internal/agent/monitor"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"google.golang.org/grpc/codes"
 )
 
 func main() {
 
 	controlPlaneAddress := flag.String("addr", "localhost:50051", "The address of the control plane")
 	nodeName := flag.String("n", "", "The name of the node (defaults to hostname-random)")
+	nodeLabels := flag.String("labels", "", "Comma-separated node labels, e.g. zone=lab,camera=true")
 	flag.Parse()
 
 	log.Println("Agent started")
@@ -34,6 +40,44 @@ func main() {
 		agentInfo.Port = port
 	}
 
+	// User labels are merged over the built-in edgernetes.ai/* labels.
+	labels, err := agent.ParseLabels(*nodeLabels)
+	if err != nil {
+		log.Fatalf("Invalid -labels: %v", err)
+	}
+	for k, v := range labels {
+		agentInfo.Labels[k] = v
+	}
+
+	// Model files are streamed from the control plane and cached locally before loading.
+	modelDir := os.Getenv("AGENT_MODEL_DIR")
+	if modelDir == "" {
+		modelDir = "./data/agent-models"
+	}
+	agentInfo.ControlPlaneAddr = *controlPlaneAddress
+	agentInfo.ModelDir = modelDir
+
+	// Retries, outlier ejection and circuit breaking for forwarded inference.
+	policy, err := forwardPolicyFromEnv(agentInfo.ForwardPolicy)
+	if err != nil {
+		log.Fatalf("Invalid forwarding configuration: %v", err)
+	}
+	agentInfo.ForwardPolicy = policy
+
+	// Size and tenant shares of each replica's job queue.
+	queue, err := queueConfigFromEnv(runway.DefaultQueueConfig())
+	if err != nil {
+		log.Fatalf("Invalid queue configuration: %v", err)
+	}
+	runway.SetQueueConfig(queue)
+
+	// Without the runtime the agent still registers, but deployed replicas fail to load.
+	if err := runway.InitRuntime(); err != nil {
+		log.Printf("Warning: %v", err)
+	} else {
+		defer runway.CloseRuntime()
+	}
+
 	// Register with control-plane (control-plane will use agentInfo.IP:agentInfo.Port for heartbeats)
 	if err := grpcagent.RegisterWithControlPlane(*controlPlaneAddress, agentInfo); err != nil {
 		log.Fatalf("Failed to register with control-plane: %v", err)
@@ -44,12 +88,123 @@ func main() {
 	// Start a goroutine to monitor heartbeat staleness and re-register if needed
 	go agentmonitor.MonitorHeartbeatStaleness(agentInfo, *controlPlaneAddress, grpcagent.RegisterWithControlPlane, grpcagent.DeregisterWithControlPlane)
 
+	// KServe v2 HTTP/JSON inference for clients that cannot speak gRPC.
+	httpAddr := os.Getenv("AGENT_HTTP_ADDR")
+	if httpAddr == "" {
+		httpAddr = ":8080"
+	}
+	go func() {
+		if err := httpagent.StartHTTPServer(agentInfo, httpAddr); err != nil {
+			log.Printf("Agent HTTP server stopped: %v", err)
+		}
+	}()
+
 	log.Printf("Starting agent gRPC server on %s", serverAddr)
 	if err := grpcagent.StartGRPCServer(agentInfo, serverAddr); err != nil {
 		log.Fatalf("Failed to start agent gRPC server: %v", err)
 	}
 }
 
+// forwardPolicyFromEnv overrides the fields of policy set by the
+// AGENT_FORWARD_*, AGENT_OUTLIER_* and AGENT_BREAKER_* environment variables.
+func forwardPolicyFromEnv(policy agent.ForwardPolicy) (agent.ForwardPolicy, error) {
+	ints := map[string]*int{
+		"AGENT_FORWARD_MAX_RETRIES":          &policy.MaxRetries,
+		"AGENT_FORWARD_MAX_HOPS":             &policy.MaxHops,
+		"AGENT_OUTLIER_CONSECUTIVE_FAILURES": &policy.EjectAfter,
+		"AGENT_BREAKER_WINDOW":               &policy.BreakerWindow,
+	}
+	for name, field := range ints {
+		if v := os.Getenv(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return policy, fmt.Errorf("%s must be a non-negative integer, got %q", name, v)
+			}
+			*field = n
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"AGENT_OUTLIER_EJECTION_SECONDS":     &policy.EjectionTime,
+		"AGENT_OUTLIER_MAX_EJECTION_SECONDS": &policy.MaxEjectionTime,
+		"AGENT_BREAKER_OPEN_SECONDS":         &policy.BreakerOpenTime,
+	}
+	for name, field := range durations {
+		if v := os.Getenv(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				return policy, fmt.Errorf("%s must be a positive number of seconds, got %q", name, v)
+			}
+			*field = time.Duration(n) * time.Second
+		}
+	}
+
+	if v := os.Getenv("AGENT_FORWARD_STREAMS"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return policy, fmt.Errorf("AGENT_FORWARD_STREAMS must be true or false, got %q", v)
+		}
+		policy.Streams = b
+	}
+
+	if v := os.Getenv("AGENT_BREAKER_FAILURE_RATIO"); v != "" {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil || r <= 0 || r > 1 {
+			return policy, fmt.Errorf("AGENT_BREAKER_FAILURE_RATIO must be in (0, 1], got %q", v)
+		}
+		policy.BreakerFailureRatio = r
+	}
+
+	// Comma-separated gRPC code names, e.g. UNAVAILABLE,RESOURCE_EXHAUSTED.
+	if v := os.Getenv("AGENT_FORWARD_RETRYABLE_CODES"); v != "" {
+		policy.RetryableCodes = nil
+		for _, name := range strings.Split(v, ",") {
+			var c codes.Code
+			if err := c.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(strings.TrimSpace(name))))); err != nil {
+				return policy, fmt.Errorf("AGENT_FORWARD_RETRYABLE_CODES: %w", err)
+			}
+			policy.RetryableCodes = append(policy.RetryableCodes, c)
+		}
+	}
+	return policy, nil
+}
+
+// queueConfigFromEnv overrides the fields of cfg set by AGENT_QUEUE_CAPACITY,
+// AGENT_QUEUE_MAX_PER_TENANT and AGENT_TENANT_WEIGHTS.
+func queueConfigFromEnv(cfg runway.QueueConfig) (runway.QueueConfig, error) {
+	if v := os.Getenv("AGENT_QUEUE_CAPACITY"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return cfg, fmt.Errorf("AGENT_QUEUE_CAPACITY must be a positive integer, got %q", v)
+		}
+		cfg.Capacity = n
+	}
+	if v := os.Getenv("AGENT_QUEUE_MAX_PER_TENANT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("AGENT_QUEUE_MAX_PER_TENANT must be a non-negative integer, got %q", v)
+		}
+		cfg.MaxPerTenant = n
+	}
+
+	// Comma-separated tenant=weight pairs, e.g. control=4,analytics=0.5.
+	if v := os.Getenv("AGENT_TENANT_WEIGHTS"); v != "" {
+		pairs, err := agent.ParseLabels(v)
+		if err != nil {
+			return cfg, fmt.Errorf("AGENT_TENANT_WEIGHTS: %w", err)
+		}
+		cfg.TenantWeights = make(map[string]float64, len(pairs))
+		for tenant, w := range pairs {
+			weight, err := strconv.ParseFloat(w, 64)
+			if err != nil || weight <= 0 {
+				return cfg, fmt.Errorf("AGENT_TENANT_WEIGHTS: weight of %q must be a positive number, got %q", tenant, w)
+			}
+			cfg.TenantWeights[tenant] = weight
+		}
+	}
+	return cfg, nil
+}
+
 // portFromAddr parses a host:port address and returns the port number (e.g. ":50052" -> 50052).
 func portFromAddr(addr string) int {
 	// Handle ":port" form
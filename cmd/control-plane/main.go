@@ -9,7 +9,13 @@ import (
 	"time"
 
 	grpcregistry "github.com/kennethnrk/edgernetes-ai/internal/control-plane/api/grpc/registry"
+	autoscalercontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/autoscaler"
+	draincontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/drain"
 	heartbeatcontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/heartbeat"
+	revivalcliniccontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/revivalClinic"
+	rolloutcontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/rollout"
+	frameworkscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/framework"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
 	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
 	"google.golang.org/grpc"
 )
@@ -59,6 +65,123 @@ func main() {
 	// Start heartbeat handler in a separate goroutine
 	go heartbeatcontroller.StartHeartbeatHandler(store, interval)
 
+	schedulerIntervalSeconds := 10
+
+	if envInterval := os.Getenv("SCHEDULER_INTERVAL_SECONDS"); envInterval != "" {
+		if parsed, err := strconv.Atoi(envInterval); err == nil && parsed > 0 {
+			schedulerIntervalSeconds = parsed
+		} else {
+			log.Printf("Invalid SCHEDULER_INTERVAL_SECONDS value '%s', using default 10 seconds", envInterval)
+		}
+	}
+
+	// Scheduling plugins, e.g. SCHEDULER_FILTERS="memory,storage" SCHEDULER_SCORERS="spread:2,tops:1"
+	schedulerFilters := frameworkscheduler.DefaultFilters
+	if env, ok := os.LookupEnv("SCHEDULER_FILTERS"); ok {
+		schedulerFilters = env
+	}
+	schedulerScorers := frameworkscheduler.DefaultScorers
+	if env, ok := os.LookupEnv("SCHEDULER_SCORERS"); ok {
+		schedulerScorers = env
+	}
+	framework, err := frameworkscheduler.Parse(schedulerFilters, schedulerScorers)
+	if err != nil {
+		log.Fatalf("invalid scheduler plugin configuration: %v", err)
+	}
+	log.Printf("Scheduler plugins: filters=%v scorers=%v", framework.Filters(), framework.Scorers())
+
+	// Start the placement scheduler that deploys registered models onto nodes
+	scheduler := placementscheduler.NewWithFramework(store, framework)
+	go scheduler.Start(time.Duration(schedulerIntervalSeconds) * time.Second)
+
+	revivalIntervalSeconds := 10
+
+	if envInterval := os.Getenv("REVIVAL_INTERVAL_SECONDS"); envInterval != "" {
+		if parsed, err := strconv.Atoi(envInterval); err == nil && parsed > 0 {
+			revivalIntervalSeconds = parsed
+		} else {
+			log.Printf("Invalid REVIVAL_INTERVAL_SECONDS value '%s', using default 10 seconds", envInterval)
+		}
+	}
+
+	revivalConfig := revivalcliniccontroller.DefaultConfig()
+	if envGrace := os.Getenv("REVIVAL_GRACE_PERIOD_SECONDS"); envGrace != "" {
+		if parsed, err := strconv.Atoi(envGrace); err == nil && parsed >= 0 {
+			revivalConfig.GracePeriod = time.Duration(parsed) * time.Second
+		} else {
+			log.Printf("Invalid REVIVAL_GRACE_PERIOD_SECONDS value '%s', using default %v", envGrace, revivalConfig.GracePeriod)
+		}
+	}
+	if envRestarts := os.Getenv("REVIVAL_MAX_RESTARTS"); envRestarts != "" {
+		if parsed, err := strconv.Atoi(envRestarts); err == nil && parsed >= 0 {
+			revivalConfig.MaxRestarts = parsed
+		} else {
+			log.Printf("Invalid REVIVAL_MAX_RESTARTS value '%s', using default %d", envRestarts, revivalConfig.MaxRestarts)
+		}
+	}
+
+	// Start the revival clinic that moves replicas off dead nodes and restarts failed ones
+	go revivalcliniccontroller.StartRevivalClinic(store, scheduler, revivalConfig, time.Duration(revivalIntervalSeconds)*time.Second)
+
+	drainIntervalSeconds := 5
+
+	if envInterval := os.Getenv("DRAIN_INTERVAL_SECONDS"); envInterval != "" {
+		if parsed, err := strconv.Atoi(envInterval); err == nil && parsed > 0 {
+			drainIntervalSeconds = parsed
+		} else {
+			log.Printf("Invalid DRAIN_INTERVAL_SECONDS value '%s', using default 5 seconds", envInterval)
+		}
+	}
+
+	// Start the drain controller that migrates replicas off nodes marked for draining
+	go draincontroller.StartDrainController(store, scheduler, time.Duration(drainIntervalSeconds)*time.Second)
+
+	rolloutIntervalSeconds := 5
+
+	if envInterval := os.Getenv("ROLLOUT_INTERVAL_SECONDS"); envInterval != "" {
+		if parsed, err := strconv.Atoi(envInterval); err == nil && parsed > 0 {
+			rolloutIntervalSeconds = parsed
+		} else {
+			log.Printf("Invalid ROLLOUT_INTERVAL_SECONDS value '%s', using default 5 seconds", envInterval)
+		}
+	}
+
+	// Start the rollout controller that replaces replicas after a model's artifact changes
+	go rolloutcontroller.StartRolloutController(store, scheduler, time.Duration(rolloutIntervalSeconds)*time.Second)
+
+	autoscalerIntervalSeconds := 15
+
+	if envInterval := os.Getenv("AUTOSCALER_INTERVAL_SECONDS"); envInterval != "" {
+		if parsed, err := strconv.Atoi(envInterval); err == nil && parsed > 0 {
+			autoscalerIntervalSeconds = parsed
+		} else {
+			log.Printf("Invalid AUTOSCALER_INTERVAL_SECONDS value '%s', using default 15 seconds", envInterval)
+		}
+	}
+
+	autoscalerConfig := autoscalercontroller.DefaultConfig()
+	for _, setting := range []struct {
+		env   string
+		value *time.Duration
+	}{
+		{"AUTOSCALER_SCALE_UP_STABILIZATION_SECONDS", &autoscalerConfig.ScaleUpStabilization},
+		{"AUTOSCALER_SCALE_DOWN_STABILIZATION_SECONDS", &autoscalerConfig.ScaleDownStabilization},
+		{"AUTOSCALER_SCALE_UP_COOLDOWN_SECONDS", &autoscalerConfig.ScaleUpCooldown},
+		{"AUTOSCALER_SCALE_DOWN_COOLDOWN_SECONDS", &autoscalerConfig.ScaleDownCooldown},
+	} {
+		if env := os.Getenv(setting.env); env != "" {
+			if parsed, err := strconv.Atoi(env); err == nil && parsed >= 0 {
+				*setting.value = time.Duration(parsed) * time.Second
+			} else {
+				log.Printf("Invalid %s value '%s', using default %v", setting.env, env, *setting.value)
+			}
+		}
+	}
+
+	// Start the autoscaler that adjusts replica counts of autoscaled models to their load
+	autoscaler := autoscalercontroller.New(store, scheduler, autoscalerConfig)
+	go autoscaler.Start(time.Duration(autoscalerIntervalSeconds) * time.Second)
+
 	log.Printf("control-plane gRPC server listening on %s", addr)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("gRPC server stopped: %v", err)
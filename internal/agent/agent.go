@@ -1,7 +1,9 @@
 package agent
 
 import (
+	"context"
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -10,6 +12,7 @@ import (
 	"os"
 	"runtime"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,6 +41,8 @@ type ModelReplicaDetails struct {
 	ErrorMessage  string                       `json:"error_message"`
 	LogFile       string                       `json:"log_file"`
 	InstanceCount int                          `json:"instance_count"`
+	LocalPath     string                       `json:"local_path"` // Cached model file on this node; set once fetched
+	InputFormat   json.RawMessage              `json:"input_format,omitempty"`
 }
 
 type Agent struct {
@@ -48,23 +53,85 @@ type Agent struct {
 	Metadata             store.NodeMetadata         `json:"metadata"`
 	ResourceCapabilities store.ResourceCapabilities `json:"resource_capabilities"`
 	AssignedModels       []ModelReplicaDetails      `json:"assigned_models"`
+	Labels               map[string]string          `json:"labels"`
+	modelsMu             sync.RWMutex               // guards AssignedModels
+
+	// ControlPlaneAddr is used to stream local model files from the control plane.
+	ControlPlaneAddr string `json:"-"`
+	// ModelDir is the directory where fetched model files are cached.
+	ModelDir string `json:"-"`
+
+	// ForwardPolicy sets retries, outlier ejection and circuit breaking for
+	// requests forwarded to peers.
+	ForwardPolicy ForwardPolicy `json:"-"`
 
 	endpointCache map[string][]*heartbeatpb.EndpointDetail
+	balancers     map[string]modelBalancer // Per model; kept across endpoint updates
 	endpointMu    sync.RWMutex
-	lb            balancer.LoadBalancer
+	peers         *peerTracker
+	streams       *streamForwarder
+	forwardOnce   sync.Once
+
+	pipelines  map[string]Pipeline // By ID, as last sent by the control plane
+	pipelineMu sync.RWMutex
 
 	mu            sync.RWMutex
 	LastHeartbeat time.Time `json:"last_heartbeat"`
 }
 
+// modelBalancer is the load balancer picking the peers of one model.
+type modelBalancer struct {
+	strategy constants.LoadBalancer
+	lb       balancer.LoadBalancer
+}
+
+// UpdateEndpoints replaces the known endpoints of every model. A model's load
+// balancer is kept, with the load it observed, unless its strategy changed.
 func (a *Agent) UpdateEndpoints(endpoints []*heartbeatpb.ServiceEndpoints) {
 	newCache := make(map[string][]*heartbeatpb.EndpointDetail)
+	a.endpointMu.Lock()
+	defer a.endpointMu.Unlock()
+
+	balancers := make(map[string]modelBalancer, len(endpoints))
 	for _, se := range endpoints {
 		newCache[se.ModelId] = se.Endpoints
+
+		strategy := constants.LoadBalancer(se.LoadBalancer)
+		if mb, ok := a.balancers[se.ModelId]; ok && mb.strategy == strategy {
+			balancers[se.ModelId] = mb
+			continue
+		}
+		lb, err := balancer.New(strategy)
+		if err != nil {
+			log.Printf("Model %s: %v, using round robin", se.ModelId, err)
+			lb = balancer.NewWeightedRoundRobin()
+		}
+		balancers[se.ModelId] = modelBalancer{strategy: strategy, lb: lb}
 	}
-	a.endpointMu.Lock()
 	a.endpointCache = newCache
-	a.endpointMu.Unlock()
+	a.balancers = balancers
+}
+
+// balancerFor returns the load balancer of modelID.
+func (a *Agent) balancerFor(modelID string) balancer.LoadBalancer {
+	a.endpointMu.RLock()
+	mb, ok := a.balancers[modelID]
+	a.endpointMu.RUnlock()
+	if ok {
+		return mb.lb
+	}
+
+	a.endpointMu.Lock()
+	defer a.endpointMu.Unlock()
+	if mb, ok := a.balancers[modelID]; ok {
+		return mb.lb
+	}
+	if a.balancers == nil {
+		a.balancers = make(map[string]modelBalancer)
+	}
+	lb := balancer.NewWeightedRoundRobin()
+	a.balancers[modelID] = modelBalancer{lb: lb}
+	return lb
 }
 
 func (a *Agent) GetEndpoints(modelID string) []*heartbeatpb.EndpointDetail {
@@ -74,6 +141,8 @@ func (a *Agent) GetEndpoints(modelID string) []*heartbeatpb.EndpointDetail {
 }
 
 func (a *Agent) AssignModel(model ModelReplicaDetails) error {
+	a.modelsMu.Lock()
+	defer a.modelsMu.Unlock()
 	if slices.ContainsFunc(a.AssignedModels, func(m ModelReplicaDetails) bool { return m.ID == model.ID }) {
 		return errors.New("model already assigned")
 	}
@@ -81,6 +150,127 @@ func (a *Agent) AssignModel(model ModelReplicaDetails) error {
 	return nil
 }
 
+// ReplaceFailedModel replaces an assigned replica with the same ID if it has
+// failed, so the control plane can restart it. It returns false otherwise.
+func (a *Agent) ReplaceFailedModel(model ModelReplicaDetails) bool {
+	a.modelsMu.Lock()
+	defer a.modelsMu.Unlock()
+	for i := range a.AssignedModels {
+		if a.AssignedModels[i].ID == model.ID && a.AssignedModels[i].Status == constants.ModelReplicaStatusFailed {
+			a.AssignedModels[i] = model
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveModel removes the assigned replica with the given ID and returns it.
+// It returns false if no such replica is assigned to the agent.
+func (a *Agent) RemoveModel(replicaID string) (ModelReplicaDetails, bool) {
+	a.modelsMu.Lock()
+	defer a.modelsMu.Unlock()
+	for i, m := range a.AssignedModels {
+		if m.ID == replicaID {
+			a.AssignedModels = slices.Delete(a.AssignedModels, i, i+1)
+			return m, true
+		}
+	}
+	return ModelReplicaDetails{}, false
+}
+
+// ModelFileInUse reports whether an assigned replica loads the cached model
+// file at path. A replica of modelID that is still being fetched counts too,
+// since its download may be going to the same file.
+func (a *Agent) ModelFileInUse(path, modelID string) bool {
+	a.modelsMu.RLock()
+	defer a.modelsMu.RUnlock()
+	return slices.ContainsFunc(a.AssignedModels, func(m ModelReplicaDetails) bool {
+		return m.LocalPath == path || (m.LocalPath == "" && m.ModelID == modelID)
+	})
+}
+
+// UpdateModelReplica applies fn to the assigned replica with the given ID.
+// It returns false if no such replica is assigned to the agent.
+func (a *Agent) UpdateModelReplica(replicaID string, fn func(*ModelReplicaDetails)) bool {
+	a.modelsMu.Lock()
+	defer a.modelsMu.Unlock()
+	for i := range a.AssignedModels {
+		if a.AssignedModels[i].ID == replicaID {
+			fn(&a.AssignedModels[i])
+			return true
+		}
+	}
+	return false
+}
+
+// SetModelReplicaStatus records the status of an assigned replica together with
+// the error that caused it, if any.
+func (a *Agent) SetModelReplicaStatus(replicaID string, status constants.ModelReplicaStatus, errorCode int, errorMessage string) bool {
+	return a.UpdateModelReplica(replicaID, func(m *ModelReplicaDetails) {
+		m.Status = status
+		m.ErrorCode = errorCode
+		m.ErrorMessage = errorMessage
+	})
+}
+
+// GetModelReplica returns a copy of the assigned replica with the given ID.
+func (a *Agent) GetModelReplica(replicaID string) (ModelReplicaDetails, bool) {
+	a.modelsMu.RLock()
+	defer a.modelsMu.RUnlock()
+	for _, m := range a.AssignedModels {
+		if m.ID == replicaID {
+			return m, true
+		}
+	}
+	return ModelReplicaDetails{}, false
+}
+
+// ListModelReplicas returns a snapshot of all replicas assigned to the agent.
+func (a *Agent) ListModelReplicas() []ModelReplicaDetails {
+	a.modelsMu.RLock()
+	defer a.modelsMu.RUnlock()
+	return slices.Clone(a.AssignedModels)
+}
+
+// RunningReplica returns a running replica on this node of the model with the
+// given ID or, failing that, name.
+func (a *Agent) RunningReplica(model string) (ModelReplicaDetails, bool) {
+	if replica, ok := a.runningReplicaOf(model); ok {
+		return replica, true
+	}
+	a.modelsMu.RLock()
+	defer a.modelsMu.RUnlock()
+	for _, m := range a.AssignedModels {
+		if m.Name == model && m.Status == constants.ModelReplicaStatusRunning {
+			return m, true
+		}
+	}
+	return ModelReplicaDetails{}, false
+}
+
+// CanServe reports whether a request for modelID can be served here: a
+// replica runs on this node or a healthy endpoint is known on another.
+func (a *Agent) CanServe(modelID string) bool {
+	if _, ok := a.runningReplicaOf(modelID); ok {
+		return true
+	}
+	return slices.ContainsFunc(a.GetEndpoints(modelID), func(ep *heartbeatpb.EndpointDetail) bool {
+		return ep.Healthy && ep.NodeId != a.ID
+	})
+}
+
+// runningReplicaOf returns a running local replica of modelID.
+func (a *Agent) runningReplicaOf(modelID string) (ModelReplicaDetails, bool) {
+	a.modelsMu.RLock()
+	defer a.modelsMu.RUnlock()
+	for _, m := range a.AssignedModels {
+		if m.ModelID == modelID && m.Status == constants.ModelReplicaStatusRunning {
+			return m, true
+		}
+	}
+	return ModelReplicaDetails{}, false
+}
+
 func (a *Agent) UpdateLastHeartbeat() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -193,47 +383,271 @@ func GetAgentInfo(nodeName *string) *Agent {
 			},
 			ComputeDevices: computeDevices,
 		},
+		Labels: map[string]string{
+			constants.LabelOS:       runtime.GOOS,
+			constants.LabelArch:     runtime.GOARCH,
+			constants.LabelHostname: hostname,
+		},
 		endpointCache: make(map[string][]*heartbeatpb.EndpointDetail),
-		lb:            balancer.NewWeightedRoundRobin(),
+		ForwardPolicy: DefaultForwardPolicy(),
 		LastHeartbeat: time.Now(),
 	}
 	return agent
 }
 
+// ParseLabels parses a comma-separated list of key=value pairs, e.g. "zone=lab,camera=true".
+func ParseLabels(s string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label %q (expected key=value)", pair)
+		}
+		labels[key] = strings.TrimSpace(value)
+	}
+	return labels, nil
+}
+
+// InferRequest is an inference request handled by the agent. Fields are used
+// when Inputs is empty; they are converted with the input format of the replica
+// that serves the request, so they are forwarded to peers as they are.
+type InferRequest struct {
+	ModelID        string
+	Inputs         []runway.Tensor
+	Fields         map[string]any
+	ScalingEnabled bool
+	RoutingKey     string                  // Picks the peer of models using consistent hashing
+	Priority       constants.PriorityClass // Queue class on the replica that serves the request
+	Tenant         string                  // Client the replica's queue is shared fairly between
+	HopCount       int                     // Times agents have forwarded the request
+	MaxHops        int                     // Times it may be forwarded; 0 uses ForwardPolicy.MaxHops
+	Visited        []string                // Nodes that forwarded it, which it is not sent back to
+	PipelineID     string                  // Runs the pipeline's steps instead of ModelID
+}
+
+// forwarded reports whether the request came from another agent.
+func (r InferRequest) forwarded() bool {
+	return r.HopCount > 0
+}
+
+// InferResult is the outcome of an inference request handled by the agent.
+type InferResult struct {
+	Outputs  []runway.Tensor
+	ServedBy string       // Node whose replica ran the model
+	HopCount int          // Times the request was forwarded to reach it
+	Steps    []StepResult // Where each step of a pipeline request ran
+}
+
 // HandleInfer routes the inference request locally or forwards it based on the endpoint cache.
-func (a *Agent) HandleInfer(modelID string, inputData []float32, isForwarded bool, scalingEnabled bool) (float32, error) {
-	// First check if the current agent has the model
-	if slices.ContainsFunc(a.AssignedModels, func(m ModelReplicaDetails) bool { return m.ModelID == modelID }) {
-		var replicaID string
-		for _, m := range a.AssignedModels {
-			if m.ModelID == modelID {
-				replicaID = m.ID
-				break
+// Inputs or fields that do not match the model fail with an error wrapping
+// runway.ErrInvalidInput; rejected fields are also reported as an
+// *inputformat.ValidationError. Once ctx is done the request is given up, with
+// an error wrapping ctx.Err(); its deadline is passed on to forwarded peers.
+//
+// A request for a model this node does not run, or whose replica here is
+// stopped before serving it, is forwarded while its hop count is below its hop
+// budget, to a peer that has not forwarded it before.
+// A node with a stale endpoint cache can thus still pass a request on without
+// sending it around in a loop.
+//
+// A request with a PipelineID runs the pipeline's steps on this node, each
+// handled like a request for its model. Pipelines this node has not been told
+// about fail with an error wrapping ErrPipelineNotFound.
+func (a *Agent) HandleInfer(ctx context.Context, req InferRequest) (InferResult, error) {
+	if req.PipelineID != "" {
+		return a.runPipeline(ctx, req)
+	}
+
+	// First check if the current agent has a running replica of the model
+	if replica, ok := a.runningReplicaOf(req.ModelID); ok {
+		inputs := req.Inputs
+		if len(inputs) == 0 && req.Fields != nil {
+			var err error
+			if inputs, err = fieldsInput(replica, req.Fields); err != nil {
+				return InferResult{}, err
 			}
 		}
-
-		result, err := runway.ModelInference(replicaID, inputData, scalingEnabled)
-		if err != nil {
-			return 0, fmt.Errorf("local inference failed: %v", err)
+		outputs, err := runway.ModelInference(ctx, replica.ID, inputs, runway.InferOptions{
+			ScalingEnabled: req.ScalingEnabled,
+			Priority:       req.Priority,
+			Tenant:         req.Tenant,
+		})
+		var full *runway.QueueFullError
+		switch {
+		case errors.As(err, &full):
+			return a.spillOver(ctx, req, full)
+		case errors.Is(err, runway.ErrReplicaStopped):
+			// The replica was undeployed while the request waited for it; the
+			// request is handled like one for a model this node does not run.
+			log.Printf("Replica %s stopped before serving model %s, looking for a peer", replica.ID, req.ModelID)
+		case err != nil:
+			return InferResult{}, fmt.Errorf("local inference failed: %w", err)
+		default:
+			return InferResult{Outputs: outputs, ServedBy: a.ID, HopCount: req.HopCount}, nil
 		}
-		return result, nil
 	}
 
-	// Loop detection: do not forward an already forwarded request
-	if isForwarded {
-		return 0, fmt.Errorf("model %s not available on this node and request was already forwarded", modelID)
+	// Loop detection: do not forward a request past its hop budget
+	req.MaxHops = a.maxHops(req)
+	if req.HopCount >= req.MaxHops {
+		return InferResult{}, fmt.Errorf("%w: model %s not available on this node and request was already forwarded %d time(s)", ErrModelUnavailable, req.ModelID, req.HopCount)
 	}
 
 	// Not local, check cache and forward to a peer
-	endpoints := a.GetEndpoints(modelID)
+	endpoints := a.unvisitedEndpoints(req)
 	if len(endpoints) == 0 {
-		return 0, fmt.Errorf("no healthy peers known for model %s", modelID)
+		return InferResult{}, fmt.Errorf("%w: no healthy peers known for model %s", ErrModelUnavailable, req.ModelID)
 	}
 
-	target, err := a.lb.Pick(endpoints)
-	if err != nil {
-		return 0, fmt.Errorf("failed to select peer: %v", err)
+	return a.forwardWithRetries(ctx, endpoints, req)
+}
+
+// maxHops returns the hop budget of req: its own if set, else the forward
+// policy's, and at least 1.
+func (a *Agent) maxHops(req InferRequest) int {
+	if req.MaxHops > 0 {
+		return req.MaxHops
+	}
+	return max(a.ForwardPolicy.MaxHops, 1)
+}
+
+// unvisitedEndpoints returns the endpoints of the request's model on nodes
+// other than this one that have not forwarded the request yet.
+func (a *Agent) unvisitedEndpoints(req InferRequest) []*heartbeatpb.EndpointDetail {
+	var out []*heartbeatpb.EndpointDetail
+	for _, ep := range a.GetEndpoints(req.ModelID) {
+		if ep.NodeId != a.ID && !slices.Contains(req.Visited, ep.NodeId) {
+			out = append(out, ep)
+		}
+	}
+	return out
+}
+
+// spillOver forwards a request the local replica's queue had no room for to
+// the peers serving the model. A request that was already forwarded is not
+// passed on again; its sender tries the other peers itself. If no peer takes
+// the request either, an *OverloadedError with the soonest retry hint is
+// returned so that the caller can back off.
+func (a *Agent) spillOver(ctx context.Context, req InferRequest, full *runway.QueueFullError) (InferResult, error) {
+	overloaded := &OverloadedError{ModelID: req.ModelID, RetryAfter: full.RetryAfter}
+	if req.forwarded() {
+		return InferResult{}, overloaded
+	}
+
+	req.MaxHops = a.maxHops(req)
+	peers := a.unvisitedEndpoints(req)
+	if len(peers) == 0 {
+		return InferResult{}, overloaded
 	}
 
-	return a.forwardInfer(target, modelID, inputData, scalingEnabled)
+	log.Printf("Queue of replica %s is full, spilling model %s over to %d peer(s)", full.ReplicaID, req.ModelID, len(peers))
+	result, err := a.forwardWithRetries(ctx, peers, req)
+	var peerOverloaded *OverloadedError
+	switch {
+	case err == nil:
+		return result, nil
+	case errors.As(err, &peerOverloaded):
+		overloaded.noteRetryAfter(peerOverloaded.RetryAfter)
+		return InferResult{}, overloaded
+	case errors.Is(err, ErrModelUnavailable):
+		return InferResult{}, overloaded
+	}
+	return InferResult{}, err
+}
+
+// forwardWithRetries forwards req to a peer picked by the model's load balancer and,
+// if the call fails with a retryable code, to other peers up to
+// ForwardPolicy.MaxRetries times. Ejected peers and peers with an open circuit
+// breaker are skipped; if that leaves none, every known peer is tried anyway
+// rather than failing outright. Nothing is retried once ctx is done, and a call
+// given up by the caller is not held against the peer. If any peer answered
+// that it was saturated, the request fails with an *OverloadedError rather
+// than ErrModelUnavailable.
+func (a *Agent) forwardWithRetries(ctx context.Context, endpoints []*heartbeatpb.EndpointDetail, req InferRequest) (InferResult, error) {
+	lb := a.balancerFor(req.ModelID)
+	peers := a.forwarding()
+	policy := a.ForwardPolicy
+	tried := make(map[string]bool)
+
+	var lastErr error
+	var overloaded *OverloadedError
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return InferResult{}, fmt.Errorf("forwarding model %s: %w", req.ModelID, err)
+		}
+		target, err := balancer.PickFor(lb, peers.candidates(endpoints, policy, tried), req.RoutingKey)
+		if err != nil && attempt == 0 {
+			target, err = balancer.PickFor(lb, endpoints, req.RoutingKey)
+		}
+		if err != nil {
+			break
+		}
+		tried[peerKey(target)] = true
+
+		probe, ok := peers.begin(target, policy, attempt > 0)
+		if !ok {
+			continue
+		}
+		finish := balancer.Start(lb, target)
+		result, err := a.forwardInfer(ctx, target, req)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			finish(nil)
+			peers.cancel(target, probe)
+			return InferResult{}, fmt.Errorf("forwarding model %s to node %s: %w", req.ModelID, target.NodeId, ctxErr)
+		}
+		finish(err)
+		peers.done(target, policy, probe, err)
+		if err == nil {
+			return result, nil
+		}
+		saturated := isOverloaded(err)
+		if saturated {
+			if overloaded == nil {
+				overloaded = &OverloadedError{ModelID: req.ModelID}
+			}
+			overloaded.noteRetryAfter(retryAfterOf(err))
+		}
+		if !policy.retryable(err) {
+			if saturated {
+				return InferResult{}, overloaded
+			}
+			return InferResult{}, err
+		}
+		lastErr = err
+		log.Printf("Forward of model %s to node %s failed, trying another peer: %v", req.ModelID, target.NodeId, err)
+	}
+
+	if overloaded != nil {
+		return InferResult{}, overloaded
+	}
+	if lastErr == nil {
+		return InferResult{}, fmt.Errorf("%w: no healthy peers available for model %s", ErrModelUnavailable, req.ModelID)
+	}
+	return InferResult{}, fmt.Errorf("%w: %w", ErrModelUnavailable, lastErr)
+}
+
+// forwarding returns the peer health tracker, creating it on first use.
+func (a *Agent) forwarding() *peerTracker {
+	a.forwardOnce.Do(func() {
+		a.peers = newPeerTracker()
+		a.streams = newStreamForwarder()
+	})
+	return a.peers
+}
+
+// peerStreams returns the streams to peers, creating them on first use.
+func (a *Agent) peerStreams() *streamForwarder {
+	a.forwarding()
+	return a.streams
+}
+
+// PeerStats returns the health of forwarding to every peer this agent has
+// forwarded to, for reporting in heartbeats.
+func (a *Agent) PeerStats() []PeerStats {
+	return a.forwarding().snapshot()
 }
@@ -2,36 +2,74 @@ package grpcagent
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/fetcher"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
 	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/inputformat"
 	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/preprocess"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// fetchTimeout bounds how long a replica may spend downloading its model file.
+const fetchTimeout = 30 * time.Minute
+
 // deployServer implements the DeployAPIServer interface.
 type deployServer struct {
 	deploypb.UnimplementedDeployAPIServer
-	agent *agent.Agent
+	agent   *agent.Agent
+	fetcher *fetcher.Fetcher
+
+	mu          sync.Mutex
+	deployments map[string]*deployment // in-flight deployments by replica ID
 }
 
-// NewDeployServer creates a new deploy server.
+// deployment is one background run of a replica's deployment. A failed replica
+// deployed again gets a new run, so entries are compared by pointer.
+type deployment struct {
+	cancel context.CancelFunc
+}
+
+// NewDeployServer creates a new deploy server. Model files are streamed from
+// a.ControlPlaneAddr and cached under a.ModelDir.
 func NewDeployServer(a *agent.Agent) deploypb.DeployAPIServer {
 	return &deployServer{
-		agent: a,
+		agent:       a,
+		fetcher:     fetcher.New(a.ControlPlaneAddr, a.ModelDir),
+		deployments: make(map[string]*deployment),
 	}
 }
 
 // DeployModel handles model deployment requests from the control-plane.
+// The replica is recorded as pending and the request returns immediately; the
+// model is then fetched, verified and loaded in the background, and the replica
+// moves to running or failed. Heartbeats report the resulting state.
 func (s *deployServer) DeployModel(ctx context.Context, req *deploypb.DeployModelRequest) (*deploypb.DeployModelResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
 	}
+	if req.ModelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "model_id cannot be empty")
+	}
+	if _, err := inputformat.Parse([]byte(req.InputFormat)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
-	// Generate a unique replica ID
-	replicaID := uuid.New().String()
+	// Use the replica ID assigned by the control-plane scheduler, or generate one
+	// for manual deployments.
+	replicaID := req.ReplicaId
+	if replicaID == "" {
+		replicaID = uuid.New().String()
+	}
 
 	// Construct ModelReplicaDetails from request
 	replicaDetails := agent.ModelReplicaDetails{
@@ -43,14 +81,20 @@ func (s *deployServer) DeployModel(ctx context.Context, req *deploypb.DeployMode
 		ModelType:     constants.ModelType(req.ModelType),
 		ModelSize:     req.ModelSize,
 		Status:        constants.ModelReplicaStatusPending,
-		ErrorCode:     0,
+		ErrorCode:     constants.ReplicaErrorNone,
 		ErrorMessage:  "",
 		LogFile:       "",
 		InstanceCount: int(req.InstanceCount),
+		InputFormat:   json.RawMessage(req.InputFormat),
 	}
 
-	// Assign model to agent
+	// Assign model to agent. A failed replica may be deployed again under the
+	// same ID, which is how the control plane restarts it.
 	err := s.agent.AssignModel(replicaDetails)
+	if err != nil && s.agent.ReplaceFailedModel(replicaDetails) {
+		_ = runway.StopModelWorkers(replicaID) // normally not running; ignore "not running"
+		err = nil
+	}
 	if err != nil {
 		return &deploypb.DeployModelResponse{
 			Success: false,
@@ -58,8 +102,117 @@ func (s *deployServer) DeployModel(ctx context.Context, req *deploypb.DeployMode
 		}, nil
 	}
 
+	// Register the run before returning, so an UndeployModel that follows can
+	// cancel it. A previous run of a replaced replica is cancelled.
+	runCtx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	run := &deployment{cancel: cancel}
+	s.mu.Lock()
+	if prev, ok := s.deployments[replicaID]; ok {
+		prev.cancel()
+	}
+	s.deployments[replicaID] = run
+	s.mu.Unlock()
+
+	go s.runDeployment(runCtx, run, replicaID, req)
+
 	return &deploypb.DeployModelResponse{
+		Success:   true,
+		Message:   "Model deployment accepted",
+		ReplicaId: replicaID,
+	}, nil
+}
+
+// runDeployment fetches the model file, starts the inference workers and
+// records the outcome on the replica.
+func (s *deployServer) runDeployment(ctx context.Context, run *deployment, replicaID string, req *deploypb.DeployModelRequest) {
+	defer func() {
+		s.mu.Lock()
+		if s.deployments[replicaID] == run {
+			delete(s.deployments, replicaID)
+		}
+		s.mu.Unlock()
+		run.cancel()
+	}()
+
+	localPath, err := s.fetcher.Fetch(ctx, req)
+	if ctx.Err() == context.Canceled {
+		log.Printf("[deploy] deployment of replica %s cancelled", replicaID)
+		return
+	}
+	if err != nil {
+		code := constants.ReplicaErrorDownloadFailed
+		if errors.Is(err, fetcher.ErrChecksumMismatch) {
+			code = constants.ReplicaErrorChecksumMismatch
+		}
+		s.fail(replicaID, code, err)
+		return
+	}
+	s.agent.UpdateModelReplica(replicaID, func(m *agent.ModelReplicaDetails) {
+		m.LocalPath = localPath
+	})
+
+	prep, err := preprocess.Parse(req.Preprocessing)
+	if err != nil {
+		s.fail(replicaID, constants.ReplicaErrorLoadFailed, err)
+		return
+	}
+	batch := runway.BatchConfig{
+		MaxBatchSize: int(req.MaxBatchSize),
+		MaxWait:      time.Duration(req.MaxBatchWaitMs) * time.Millisecond,
+	}
+	if err := runway.StartModelWorkers(replicaID, localPath, int(req.InstanceCount), batch, prep); err != nil {
+		s.fail(replicaID, constants.ReplicaErrorLoadFailed, err)
+		return
+	}
+
+	if !s.agent.SetModelReplicaStatus(replicaID, constants.ModelReplicaStatusRunning, constants.ReplicaErrorNone, "") {
+		// The replica was undeployed while its model was loading.
+		_ = runway.StopModelWorkers(replicaID)
+		return
+	}
+	log.Printf("[deploy] replica %s of model %s is running from %s", replicaID, req.ModelId, localPath)
+}
+
+// UndeployModel removes a replica from the agent. Its workers are stopped, the
+// ONNX session is released and the cached model file is deleted unless another
+// replica still uses it; replicas of other revisions of the model do not. A
+// deployment still fetching the model is cancelled. Returns codes.NotFound if
+// the replica is not assigned.
+func (s *deployServer) UndeployModel(ctx context.Context, req *deploypb.UndeployModelRequest) (*deploypb.UndeployModelResponse, error) {
+	if req == nil || req.ReplicaId == "" {
+		return nil, status.Error(codes.InvalidArgument, "replica_id cannot be empty")
+	}
+
+	replica, found := s.agent.RemoveModel(req.ReplicaId)
+	if !found {
+		return nil, status.Error(codes.NotFound, "replica not assigned to this agent")
+	}
+
+	s.mu.Lock()
+	if run, ok := s.deployments[req.ReplicaId]; ok {
+		run.cancel()
+	}
+	s.mu.Unlock()
+
+	// Workers only exist for running replicas; "not running" is expected otherwise.
+	if err := runway.StopModelWorkers(req.ReplicaId); err != nil && replica.Status == constants.ModelReplicaStatusRunning {
+		log.Printf("[deploy] stopping workers of replica %s: %v", req.ReplicaId, err)
+	}
+
+	if replica.LocalPath != "" && !s.agent.ModelFileInUse(replica.LocalPath, replica.ModelID) {
+		if err := s.fetcher.Remove(replica.LocalPath); err != nil {
+			log.Printf("[deploy] removing cached model of replica %s: %v", req.ReplicaId, err)
+		}
+	}
+
+	log.Printf("[deploy] replica %s of model %s undeployed", req.ReplicaId, replica.ModelID)
+	return &deploypb.UndeployModelResponse{
 		Success: true,
-		Message: "Model deployed successfully",
+		Message: "Model replica undeployed",
 	}, nil
 }
+
+func (s *deployServer) fail(replicaID string, code int, err error) {
+	log.Printf("[deploy] replica %s failed: %v", replicaID, err)
+	s.agent.SetModelReplicaStatus(replicaID, constants.ModelReplicaStatusFailed, code, err.Error())
+}
@@ -6,6 +6,7 @@ import (
 	"net"
 
 	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/connpool"
 	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
 	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
 	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
@@ -23,7 +24,8 @@ func StartGRPCServer(a *agent.Agent, addr string) error {
 	}
 
 	// Create gRPC server
-	s := grpc.NewServer()
+	// Accept keepalive pings from pooled peer and control plane connections
+	s := grpc.NewServer(connpool.ServerOptions()...)
 
 	// Create and register heartbeat server
 	heartbeatSrv := NewHeartbeatServer(a)
@@ -2,10 +2,17 @@ package grpcinfer
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
 
 	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
 	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -23,17 +30,79 @@ func NewInferServer(a *agent.Agent) inferpb.InferAPIServer {
 }
 
 // Infer handles the incoming inference gRPC request.
+// Requests whose tensors do not match the model's inputs, or whose fields do not
+// match its input format, fail with codes.InvalidArgument; rejected fields are
+// attached as BadRequest details. Requests that neither this node nor any peer
+// could serve fail with codes.Unavailable, so callers can try another agent.
+// Requests for a pipeline this node does not know fail with codes.NotFound.
+// Requests that every replica was too busy to queue fail with
+// codes.ResourceExhausted and a RetryInfo detail saying when to retry.
+// Requests whose deadline passed or that were canceled fail with
+// codes.DeadlineExceeded or codes.Canceled. Other inference failures are
+// reported with success=false.
+//
+// Requests without a tenant are queued as the tenant of the caller's host.
+// Successful responses name the node that served the request, and for
+// pipelines the node that served each step.
 func (s *inferServer) Infer(ctx context.Context, req *inferpb.InferRequest) (*inferpb.InferResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
 	}
 
-	if req.ModelId == "" {
-		return nil, status.Error(codes.InvalidArgument, "model_id cannot be empty")
+	if req.ModelId == "" && req.PipelineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "model_id or pipeline_id is required")
+	}
+	priority := constants.PriorityClass(req.Priority)
+	if priority != "" && !slices.Contains(constants.PriorityClasses, priority) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown priority %q (expected one of %v)", req.Priority, constants.PriorityClasses))
 	}
 
-	prediction, err := s.agent.HandleInfer(req.ModelId, req.InputData, req.IsForwarded, req.ScalingEnabled)
+	inferReq := agent.InferRequest{
+		ModelID:        req.ModelId,
+		Inputs:         agent.FlatInput(req.InputData),
+		ScalingEnabled: req.ScalingEnabled,
+		RoutingKey:     req.RoutingKey,
+		Priority:       priority,
+		Tenant:         req.Tenant,
+		HopCount:       int(req.HopCount),
+		MaxHops:        int(req.MaxHops),
+		Visited:        req.Visited,
+		PipelineID:     req.PipelineId,
+	}
+	if req.IsForwarded && inferReq.HopCount == 0 {
+		// Forwarded by an agent that predates hop counts, which allowed one hop.
+		inferReq.HopCount, inferReq.MaxHops = 1, 1
+	}
+	if inferReq.Tenant == "" && inferReq.HopCount == 0 {
+		inferReq.Tenant = callerHost(ctx)
+	}
+	switch {
+	case len(req.Inputs) > 0:
+		inputs, err := agent.TensorsFromProto(req.Inputs)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		inferReq.Inputs = inputs
+	case req.Fields != nil:
+		inferReq.Inputs, inferReq.Fields = nil, req.Fields.AsMap()
+	}
 
+	result, err := s.agent.HandleInfer(ctx, inferReq)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return nil, status.FromContextError(err).Err()
+	}
+	if errors.Is(err, runway.ErrInvalidInput) {
+		return nil, agent.InvalidInputStatus(err).Err()
+	}
+	if errors.Is(err, runway.ErrQueueFull) {
+		return nil, agent.OverloadedStatus(err).Err()
+	}
+	if errors.Is(err, agent.ErrModelUnavailable) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if errors.Is(err, agent.ErrPipelineNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
 	if err != nil {
 		return &inferpb.InferResponse{
 			Success:      false,
@@ -43,6 +112,41 @@ func (s *inferServer) Infer(ctx context.Context, req *inferpb.InferRequest) (*in
 
 	return &inferpb.InferResponse{
 		Success:    true,
-		Prediction: prediction,
+		Prediction: agent.Prediction(result.Outputs),
+		Outputs:    agent.TensorsToProto(result.Outputs),
+		ServedBy:   result.ServedBy,
+		HopCount:   int32(result.HopCount),
+		Steps:      stepResultsToProto(result.Steps),
 	}, nil
 }
+
+func stepResultsToProto(steps []agent.StepResult) []*inferpb.StepResult {
+	if len(steps) == 0 {
+		return nil
+	}
+	out := make([]*inferpb.StepResult, len(steps))
+	for i, s := range steps {
+		out[i] = &inferpb.StepResult{
+			Name:      s.Name,
+			ModelId:   s.ModelID,
+			ServedBy:  s.ServedBy,
+			HopCount:  int32(s.HopCount),
+			LatencyMs: float64(s.Latency.Microseconds()) / 1000,
+		}
+	}
+	return out
+}
+
+// callerHost returns the host the call came from, which is the tenant of
+// requests that do not name one.
+func callerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
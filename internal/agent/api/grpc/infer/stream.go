@@ -0,0 +1,104 @@
+package grpcinfer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamInfer handles the requests of a stream concurrently, each as Infer
+// would, and sends every response as soon as it is ready, tagged with the ID
+// of its request.
+//
+// At most streamWindow requests of a stream are in flight. Until one of them
+// finishes the next request is not read, so a client sending faster than the
+// replica works through its queue is held back by gRPC flow control instead of
+// having requests rejected as ErrQueueFull.
+func (s *inferServer) StreamInfer(stream inferpb.InferAPI_StreamInferServer) error {
+	ctx := stream.Context()
+	window := make(chan struct{}, streamWindow())
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	var sendMu sync.Mutex
+
+	for {
+		select {
+		case window <- struct{}{}:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-window }()
+
+			resp := s.streamResponse(ctx, req)
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			if err := stream.Send(resp); err != nil {
+				log.Printf("Failed to send stream response %s: %v", req.GetRequestId(), err)
+			}
+		}()
+	}
+}
+
+// streamResponse runs one request of a stream. Errors Infer would return are
+// reported in the response's code and error_message.
+func (s *inferServer) streamResponse(ctx context.Context, req *inferpb.InferRequest) *inferpb.InferResponse {
+	if req.GetRequestId() == "" {
+		return errorResponse(req, status.New(codes.InvalidArgument, "request_id cannot be empty on a stream"))
+	}
+	if ms := req.GetTimeoutMs(); ms > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+		defer cancel()
+	}
+
+	resp, err := s.Infer(ctx, req)
+	if err != nil {
+		return errorResponse(req, status.Convert(err))
+	}
+	resp.RequestId = req.GetRequestId()
+	return resp
+}
+
+func errorResponse(req *inferpb.InferRequest, st *status.Status) *inferpb.InferResponse {
+	resp := &inferpb.InferResponse{
+		RequestId:    req.GetRequestId(),
+		Code:         int32(st.Code()),
+		ErrorMessage: st.Message(),
+	}
+	if d, ok := agent.RetryAfterFromStatus(st); ok {
+		resp.RetryAfterMs = d.Milliseconds()
+	}
+	return resp
+}
+
+// streamWindow is how many requests of one stream may be in flight: the share
+// of a replica's queue one tenant may hold, or the whole queue if tenants are
+// not limited.
+func streamWindow() int {
+	cfg := runway.CurrentQueueConfig()
+	if cfg.MaxPerTenant > 0 && cfg.MaxPerTenant < cfg.Capacity {
+		return cfg.MaxPerTenant
+	}
+	return max(cfg.Capacity, 1)
+}
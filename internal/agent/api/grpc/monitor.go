@@ -5,6 +5,7 @@ import (
 
 	"github.com/kennethnrk/edgernetes-ai/internal/agent"
 	agentmonitor "github.com/kennethnrk/edgernetes-ai/internal/agent/monitor"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
 	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -35,6 +36,8 @@ func (s *heartbeatServer) RequestHeartbeat(ctx context.Context, req *heartbeatpb
 	if len(req.ServiceEndpoints) > 0 {
 		s.agent.UpdateEndpoints(req.ServiceEndpoints)
 	}
+	// Every heartbeat carries all pipelines, so deleted ones are dropped.
+	s.agent.UpdatePipelines(req.Pipelines)
 
 	// Call checkHealth to get model replicas and health status
 	modelReplicas, success, err := agentmonitor.CheckHealth(s.agent)
@@ -46,15 +49,45 @@ func (s *heartbeatServer) RequestHeartbeat(ctx context.Context, req *heartbeatpb
 	pbModelReplicas := make([]*heartbeatpb.ModelReplicaDetails, len(modelReplicas))
 	for i := range modelReplicas {
 		pbModelReplicas[i] = ModelReplicaToProto(&modelReplicas[i])
+		// Report the load of running replicas for autoscaling.
+		if stats, ok := runway.Stats(modelReplicas[i].ID); ok {
+			pbModelReplicas[i].QueueDepth = int32(stats.QueueDepth)
+			pbModelReplicas[i].InFlight = int32(stats.InFlight)
+			pbModelReplicas[i].P95LatencyMs = stats.P95LatencyMs
+			pbModelReplicas[i].RecentJobs = int32(stats.RecentJobs)
+			pbModelReplicas[i].QueueClasses = ClassStatsToProto(stats.Classes)
+		}
 	}
 
 	return &heartbeatpb.RequestHeartbeatResponse{
 		NodeID:        s.agent.ID,
 		ModelReplicas: pbModelReplicas,
 		Success:       success,
+		Peers:         PeerStatsToProto(s.agent.PeerStats()),
 	}, nil
 }
 
+// PeerStatsToProto converts the agent's peer health to heartbeatpb.PeerStats.
+func PeerStatsToProto(peers []agent.PeerStats) []*heartbeatpb.PeerStats {
+	out := make([]*heartbeatpb.PeerStats, len(peers))
+	for i, p := range peers {
+		out[i] = &heartbeatpb.PeerStats{
+			NodeId:              p.NodeID,
+			Address:             p.Address,
+			Breaker:             string(p.Breaker),
+			ConsecutiveFailures: int32(p.ConsecutiveFailures),
+			Ejections:           int32(p.Ejections),
+			Requests:            p.Requests,
+			Failures:            p.Failures,
+			Retries:             p.Retries,
+		}
+		if !p.EjectedUntil.IsZero() {
+			out[i].EjectedUntil = p.EjectedUntil.Unix()
+		}
+	}
+	return out
+}
+
 // ModelReplicaToProto converts agent.ModelReplicaDetails to heartbeatpb.ModelReplicaDetails.
 func ModelReplicaToProto(m *agent.ModelReplicaDetails) *heartbeatpb.ModelReplicaDetails {
 	return &heartbeatpb.ModelReplicaDetails{
@@ -71,3 +104,19 @@ func ModelReplicaToProto(m *agent.ModelReplicaDetails) *heartbeatpb.ModelReplica
 		InstanceCount: int32(m.InstanceCount),
 	}
 }
+
+// ClassStatsToProto converts the queue metrics of a replica's priority classes
+// to heartbeatpb.QueueClassStats.
+func ClassStatsToProto(classes []runway.ClassStats) []*heartbeatpb.QueueClassStats {
+	out := make([]*heartbeatpb.QueueClassStats, len(classes))
+	for i, c := range classes {
+		out[i] = &heartbeatpb.QueueClassStats{
+			Class:      string(c.Class),
+			QueueDepth: int32(c.QueueDepth),
+			Enqueued:   c.Enqueued,
+			Rejected:   c.Rejected,
+			P95WaitMs:  c.P95WaitMs,
+		}
+	}
+	return out
+}
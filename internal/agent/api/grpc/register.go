@@ -71,6 +71,7 @@ func agentToNodeInfoProto(a *agent.Agent) *nodepb.NodeInfo {
 		Name:   a.Name,
 		Ip:     a.IP,
 		Port:   int32(a.Port),
+		Labels: a.Labels,
 	}
 
 	// Convert Metadata
@@ -0,0 +1,267 @@
+// Package httpagent serves the agent's inference API over HTTP/JSON, following
+// the Open Inference Protocol (KServe v2), for clients that cannot speak gRPC.
+//
+// Requests are handled by agent.HandleInfer like gRPC requests, so a model
+// that does not run on this node is forwarded to a peer that serves it.
+package httpagent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+)
+
+const (
+	// serverName is reported by the server metadata route.
+	serverName = "edgernetes-ai"
+	// platform is reported in the metadata of every model.
+	platform = "onnxruntime_onnx"
+	// maxRequestBytes bounds the body of an inference request.
+	maxRequestBytes = 32 << 20
+)
+
+// StartHTTPServer serves the KServe v2 routes on addr. It blocks until the
+// server fails.
+func StartHTTPServer(a *agent.Agent, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           NewHandler(a),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("KServe v2 HTTP server listening on %s", addr)
+	if err := srv.ListenAndServe(); err != nil {
+		return fmt.Errorf("HTTP server stopped: %w", err)
+	}
+	return nil
+}
+
+// NewHandler returns the KServe v2 routes of a:
+//
+//	GET  /v2                                  server metadata
+//	GET  /v2/health/live                      the server is running
+//	GET  /v2/health/ready                     the server accepts inference requests
+//	GET  /v2/models/{name}[/versions/{v}]        model metadata
+//	GET  /v2/models/{name}[/versions/{v}]/ready  a replica of the model can be reached
+//	POST /v2/models/{name}[/versions/{v}]/infer  run inference
+//
+// {name} is a model ID, or the name of a model running on this node. The
+// version is accepted but not used to pick a replica. The infer route also
+// runs pipelines, named by ID or name.
+func NewHandler(a *agent.Agent) http.Handler {
+	s := &server{agent: a}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2", s.serverMetadata)
+	mux.HandleFunc("GET /v2/health/live", s.live)
+	mux.HandleFunc("GET /v2/health/ready", s.live)
+	for _, prefix := range []string{"/v2/models/{name}", "/v2/models/{name}/versions/{version}"} {
+		mux.HandleFunc("GET "+prefix, s.modelMetadata)
+		mux.HandleFunc("GET "+prefix+"/ready", s.modelReady)
+		mux.HandleFunc("POST "+prefix+"/infer", s.infer)
+	}
+	return mux
+}
+
+type server struct {
+	agent *agent.Agent
+}
+
+func (s *server) serverMetadata(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, serverMetadata{Name: serverName, Extensions: []string{}})
+}
+
+// live answers both health routes: a running agent can always take requests,
+// which it serves or forwards.
+func (s *server) live(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// resolve returns the model ID a route's {name} refers to and the replica
+// running it on this node, if any.
+func (s *server) resolve(r *http.Request) (string, agent.ModelReplicaDetails, bool) {
+	name := r.PathValue("name")
+	if replica, ok := s.agent.RunningReplica(name); ok {
+		return replica.ModelID, replica, true
+	}
+	return name, agent.ModelReplicaDetails{}, false
+}
+
+// modelMetadata describes a model. The tensors are only known for models
+// running on this node; models served by peers are listed without them.
+func (s *server) modelMetadata(w http.ResponseWriter, r *http.Request) {
+	modelID, replica, local := s.resolve(r)
+	if !local && !s.agent.CanServe(modelID) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("model %s is not served by this node or its peers", r.PathValue("name")))
+		return
+	}
+
+	md := modelMetadata{Name: r.PathValue("name"), Platform: platform, Inputs: []tensorMetadata{}, Outputs: []tensorMetadata{}}
+	if local {
+		if replica.Version != "" {
+			md.Versions = []string{replica.Version}
+		}
+		if sig, ok := runway.ModelSignature(replica.ID); ok {
+			md.Inputs, md.Outputs = metadataOf(sig.Inputs), metadataOf(sig.Outputs)
+		}
+	}
+	writeJSON(w, http.StatusOK, md)
+}
+
+func (s *server) modelReady(w http.ResponseWriter, r *http.Request) {
+	modelID, _, local := s.resolve(r)
+	if local || s.agent.CanServe(modelID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.WriteHeader(http.StatusServiceUnavailable)
+}
+
+func (s *server) infer(w http.ResponseWriter, r *http.Request) {
+	modelID, _, _ := s.resolve(r)
+
+	var body inferenceRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	req, err := inferRequestOf(modelID, body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if pipelineID, ok := s.agent.LookupPipeline(r.PathValue("name")); ok {
+		req.ModelID, req.PipelineID = "", pipelineID
+	}
+	if req.Tenant == "" {
+		req.Tenant = remoteHost(r)
+	}
+
+	result, err := s.agent.HandleInfer(r.Context(), req)
+	if err != nil {
+		writeInferError(w, err)
+		return
+	}
+
+	outputs, err := responseOutputs(result.Outputs, body.Outputs)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, inferenceResponse{
+		ModelName:    r.PathValue("name"),
+		ModelVersion: r.PathValue("version"),
+		ID:           body.ID,
+		Parameters:   map[string]any{"served_by": result.ServedBy, "hop_count": result.HopCount},
+		Outputs:      outputs,
+	})
+}
+
+// inferRequestOf converts a v2 request body. The priority, tenant,
+// routing_key and scaling_enabled parameters map onto the fields of the
+// gRPC InferRequest.
+func inferRequestOf(modelID string, body inferenceRequest) (agent.InferRequest, error) {
+	req := agent.InferRequest{ModelID: modelID}
+	if len(body.Inputs) == 0 {
+		return req, errors.New("request has no inputs")
+	}
+	for _, in := range body.Inputs {
+		t, err := in.tensor()
+		if err != nil {
+			return req, err
+		}
+		req.Inputs = append(req.Inputs, t)
+	}
+
+	var err error
+	params := body.Parameters
+	var priority string
+	if priority, err = stringParam(params, "priority"); err != nil {
+		return req, err
+	}
+	req.Priority = constants.PriorityClass(priority)
+	if req.Priority != "" && !slices.Contains(constants.PriorityClasses, req.Priority) {
+		return req, fmt.Errorf("unknown priority %q (expected one of %v)", priority, constants.PriorityClasses)
+	}
+	if req.Tenant, err = stringParam(params, "tenant"); err != nil {
+		return req, err
+	}
+	if req.RoutingKey, err = stringParam(params, "routing_key"); err != nil {
+		return req, err
+	}
+	if v, ok := params["scaling_enabled"]; ok {
+		if req.ScalingEnabled, ok = v.(bool); !ok {
+			return req, fmt.Errorf("parameter scaling_enabled must be a boolean, got %v", v)
+		}
+	}
+	return req, nil
+}
+
+func stringParam(params map[string]any, name string) (string, error) {
+	v, ok := params[name]
+	if !ok {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("parameter %s must be a string, got %v", name, v)
+	}
+	return s, nil
+}
+
+// writeInferError answers a failed inference with the HTTP status matching
+// the gRPC code the infer server would use. Saturated models get 429 with a
+// Retry-After header in whole seconds.
+func writeInferError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
+		writeError(w, http.StatusGatewayTimeout, err.Error())
+	case errors.Is(err, runway.ErrInvalidInput):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, runway.ErrQueueFull):
+		secs := int(math.Ceil(agent.RetryAfter(err).Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(max(secs, 1)))
+		writeError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, agent.ErrModelUnavailable):
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, agent.ErrPipelineNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// remoteHost returns the host a request came from, which is the tenant of
+// requests that do not name one.
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write HTTP response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, errorResponse{Error: msg})
+}
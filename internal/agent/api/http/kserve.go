@@ -0,0 +1,222 @@
+package httpagent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+)
+
+// The JSON bodies of the Open Inference Protocol.
+
+type serverMetadata struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version"`
+	Extensions []string `json:"extensions"`
+}
+
+type modelMetadata struct {
+	Name     string           `json:"name"`
+	Versions []string         `json:"versions,omitempty"`
+	Platform string           `json:"platform"`
+	Inputs   []tensorMetadata `json:"inputs"`
+	Outputs  []tensorMetadata `json:"outputs"`
+}
+
+type tensorMetadata struct {
+	Name     string  `json:"name"`
+	Datatype string  `json:"datatype"`
+	Shape    []int64 `json:"shape"`
+}
+
+type inferenceRequest struct {
+	ID         string          `json:"id,omitempty"`
+	Parameters map[string]any  `json:"parameters,omitempty"`
+	Inputs     []requestInput  `json:"inputs"`
+	Outputs    []requestOutput `json:"outputs,omitempty"`
+}
+
+type requestInput struct {
+	Name     string          `json:"name"`
+	Shape    []int64         `json:"shape"`
+	Datatype string          `json:"datatype"`
+	Data     json.RawMessage `json:"data"`
+}
+
+type requestOutput struct {
+	Name string `json:"name"`
+}
+
+type inferenceResponse struct {
+	ModelName    string           `json:"model_name"`
+	ModelVersion string           `json:"model_version,omitempty"`
+	ID           string           `json:"id,omitempty"`
+	Parameters   map[string]any   `json:"parameters,omitempty"`
+	Outputs      []responseOutput `json:"outputs"`
+}
+
+type responseOutput struct {
+	Name     string  `json:"name"`
+	Shape    []int64 `json:"shape"`
+	Datatype string  `json:"datatype"`
+	Data     any     `json:"data"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// v2DataTypes maps runway data types to the datatype names of the protocol.
+// The protocol's other types, such as FP16 or INT8, are not supported.
+var v2DataTypes = map[runway.DataType]string{
+	runway.DataTypeFloat32: "FP32",
+	runway.DataTypeFloat64: "FP64",
+	runway.DataTypeInt32:   "INT32",
+	runway.DataTypeInt64:   "INT64",
+	runway.DataTypeUint8:   "UINT8",
+	runway.DataTypeBool:    "BOOL",
+	runway.DataTypeString:  "BYTES",
+}
+
+func metadataOf(specs []runway.TensorSpec) []tensorMetadata {
+	out := make([]tensorMetadata, 0, len(specs))
+	for _, s := range specs {
+		out = append(out, tensorMetadata{Name: s.Name, Datatype: v2DataTypes[s.DType], Shape: s.Shape})
+	}
+	return out
+}
+
+// tensor converts a request input. Data may be flat or nested in row-major
+// order; either way it must hold as many elements as the shape. Errors wrap
+// runway.ErrInvalidInput.
+func (in requestInput) tensor() (runway.Tensor, error) {
+	t := runway.Tensor{Name: in.Name, Shape: in.Shape}
+	for dtype, name := range v2DataTypes {
+		if name == in.Datatype {
+			t.DType = dtype
+		}
+	}
+	if t.DType == "" {
+		return t, fmt.Errorf("%w: input %q has unsupported datatype %q", runway.ErrInvalidInput, in.Name, in.Datatype)
+	}
+
+	var nested any
+	dec := json.NewDecoder(bytes.NewReader(in.Data))
+	dec.UseNumber()
+	if err := dec.Decode(&nested); err != nil {
+		return t, fmt.Errorf("%w: input %q: %v", runway.ErrInvalidInput, in.Name, err)
+	}
+	elems := flatten(nested, nil)
+
+	want := int64(1)
+	for _, d := range in.Shape {
+		if d < 0 {
+			return t, fmt.Errorf("%w: input %q has negative dimension in shape %v", runway.ErrInvalidInput, in.Name, in.Shape)
+		}
+		want *= d
+	}
+	if int64(len(elems)) != want {
+		return t, fmt.Errorf("%w: input %q has %d elements, shape %v needs %d", runway.ErrInvalidInput, in.Name, len(elems), in.Shape, want)
+	}
+
+	var err error
+	switch t.DType {
+	case runway.DataTypeFloat32:
+		t.Data, err = convert(elems, func(v any) (float32, bool) { f, ok := number(v); return float32(f), ok })
+	case runway.DataTypeFloat64:
+		t.Data, err = convert(elems, number)
+	case runway.DataTypeInt32:
+		t.Data, err = convert(elems, func(v any) (int32, bool) { return integer[int32](v, math.MinInt32, math.MaxInt32) })
+	case runway.DataTypeInt64:
+		t.Data, err = convert(elems, func(v any) (int64, bool) { return integer[int64](v, math.MinInt64, math.MaxInt64) })
+	case runway.DataTypeUint8:
+		t.Data, err = convert(elems, func(v any) (uint8, bool) { return integer[uint8](v, 0, math.MaxUint8) })
+	case runway.DataTypeBool:
+		t.Data, err = convert(elems, func(v any) (bool, bool) { b, ok := v.(bool); return b, ok })
+	case runway.DataTypeString:
+		t.Data, err = convert(elems, func(v any) (string, bool) { s, ok := v.(string); return s, ok })
+	}
+	if err != nil {
+		return t, fmt.Errorf("%w: input %q: %v", runway.ErrInvalidInput, in.Name, err)
+	}
+	return t, nil
+}
+
+// flatten appends the scalars of nested JSON arrays to out in row-major order.
+func flatten(v any, out []any) []any {
+	arr, ok := v.([]any)
+	if !ok {
+		return append(out, v)
+	}
+	for _, e := range arr {
+		out = flatten(e, out)
+	}
+	return out
+}
+
+// convert converts JSON scalars with conv, failing on the first element it
+// rejects.
+func convert[T any](elems []any, conv func(any) (T, bool)) ([]T, error) {
+	out := make([]T, len(elems))
+	for i, e := range elems {
+		v, ok := conv(e)
+		if !ok {
+			return nil, fmt.Errorf("element %d (%v) does not match the datatype", i, e)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// number converts a JSON number.
+func number(v any) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+// integer converts a whole JSON number in [lo, hi].
+func integer[T int32 | int64 | uint8](v any, lo, hi int64) (T, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	if err != nil || i < lo || i > hi {
+		return 0, false
+	}
+	return T(i), true
+}
+
+// responseOutputs converts the outputs of a model, keeping only the requested
+// ones if the request names any.
+func responseOutputs(tensors []runway.Tensor, requested []requestOutput) ([]responseOutput, error) {
+	out := make([]responseOutput, 0, len(tensors))
+	for _, t := range tensors {
+		if len(requested) > 0 && !slices.ContainsFunc(requested, func(r requestOutput) bool { return r.Name == t.Name }) {
+			continue
+		}
+		data := t.Data
+		if u8, ok := data.([]uint8); ok {
+			// Encoded as numbers rather than base64.
+			ints := make([]int, len(u8))
+			for i, b := range u8 {
+				ints[i] = int(b)
+			}
+			data = ints
+		}
+		out = append(out, responseOutput{Name: t.Name, Shape: t.Shape, Datatype: v2DataTypes[t.DType], Data: data})
+	}
+	for _, r := range requested {
+		if !slices.ContainsFunc(tensors, func(t runway.Tensor) bool { return t.Name == r.Name }) {
+			return nil, fmt.Errorf("model has no output %q", r.Name)
+		}
+	}
+	return out, nil
+}
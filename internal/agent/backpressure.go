@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// OverloadedError reports a request that no replica of the model had room
+// for: the local queue was full and the peers tried were saturated too, or
+// unreachable. It wraps runway.ErrQueueFull.
+type OverloadedError struct {
+	ModelID    string
+	RetryAfter time.Duration // Smallest retry hint of the replicas tried
+}
+
+func (e *OverloadedError) Error() string {
+	return fmt.Sprintf("model %s: every replica is overloaded, retry after %s", e.ModelID, e.RetryAfter)
+}
+
+func (e *OverloadedError) Unwrap() error { return runway.ErrQueueFull }
+
+// noteRetryAfter lowers the retry hint to d if that is sooner.
+func (e *OverloadedError) noteRetryAfter(d time.Duration) {
+	if d > 0 && (e.RetryAfter <= 0 || d < e.RetryAfter) {
+		e.RetryAfter = d
+	}
+}
+
+// isOverloaded reports whether a forwarded call failed because the peer had
+// no room for the request.
+func isOverloaded(err error) bool {
+	code, ok := grpcCode(err)
+	return ok && code == codes.ResourceExhausted
+}
+
+// retryAfterOf returns the retry hint of a peer's ResourceExhausted answer,
+// or runway.DefaultRetryAfter if the peer sent none.
+func retryAfterOf(err error) time.Duration {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		if d, ok := RetryAfterFromStatus(se.GRPCStatus()); ok {
+			return d
+		}
+	}
+	return runway.DefaultRetryAfter
+}
+
+// RetryAfter returns the retry hint of an error wrapping runway.ErrQueueFull,
+// or runway.DefaultRetryAfter if it carries none.
+func RetryAfter(err error) time.Duration {
+	var over *OverloadedError
+	var full *runway.QueueFullError
+	switch {
+	case errors.As(err, &over) && over.RetryAfter > 0:
+		return over.RetryAfter
+	case errors.As(err, &full) && full.RetryAfter > 0:
+		return full.RetryAfter
+	}
+	return runway.DefaultRetryAfter
+}
+
+// OverloadedStatus converts an error wrapping runway.ErrQueueFull into a
+// ResourceExhausted status. The retry hint, if err carries one, is attached
+// as RetryInfo so that clients and peers know when to come back.
+func OverloadedStatus(err error) *status.Status {
+	st := status.New(codes.ResourceExhausted, err.Error())
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(RetryAfter(err))}); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// RetryAfterFromStatus returns the retry hint carried by a status built with
+// OverloadedStatus. ok is false if it has none.
+func RetryAfterFromStatus(st *status.Status) (retryAfter time.Duration, ok bool) {
+	for _, d := range st.Details() {
+		if ri, isRetry := d.(*errdetails.RetryInfo); isRetry && ri.GetRetryDelay() != nil {
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
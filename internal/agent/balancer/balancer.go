@@ -2,10 +2,12 @@ package balancer
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
 	"sync"
 	"time"
 
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
 	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
 )
 
@@ -18,6 +20,53 @@ type LoadBalancer interface {
 	Pick(endpoints []*heartbeatpb.EndpointDetail) (*heartbeatpb.EndpointDetail, error)
 }
 
+// Observer is implemented by load balancers that pick by the load or latency
+// of endpoints. Start must be called when a request is sent to the picked
+// endpoint, and the returned function once it completes.
+type Observer interface {
+	Start(ep *heartbeatpb.EndpointDetail) (done func(err error))
+}
+
+// KeyedLoadBalancer is implemented by load balancers that pick by a request key,
+// so that requests with the same key go to the same endpoint.
+type KeyedLoadBalancer interface {
+	PickKey(endpoints []*heartbeatpb.EndpointDetail, key string) (*heartbeatpb.EndpointDetail, error)
+}
+
+// New returns a load balancer for strategy. An empty strategy is round robin.
+func New(strategy constants.LoadBalancer) (LoadBalancer, error) {
+	switch strategy {
+	case "", constants.LoadBalancerRoundRobin:
+		return NewWeightedRoundRobin(), nil
+	case constants.LoadBalancerLeastRequest:
+		return NewLeastRequest(), nil
+	case constants.LoadBalancerPowerOfTwo:
+		return NewPowerOfTwo(), nil
+	case constants.LoadBalancerLatencyEWMA:
+		return NewLatencyEWMA(), nil
+	case constants.LoadBalancerConsistentHash:
+		return NewConsistentHash(), nil
+	}
+	return nil, fmt.Errorf("unknown load balancer %q", strategy)
+}
+
+// PickFor picks an endpoint with lb, by key if lb supports it and key is set.
+func PickFor(lb LoadBalancer, endpoints []*heartbeatpb.EndpointDetail, key string) (*heartbeatpb.EndpointDetail, error) {
+	if k, ok := lb.(KeyedLoadBalancer); ok && key != "" {
+		return k.PickKey(endpoints, key)
+	}
+	return lb.Pick(endpoints)
+}
+
+// Start tells lb that a request is sent to ep, if lb observes requests. The
+// returned function must be called once the request completes.
+func Start(lb LoadBalancer, ep *heartbeatpb.EndpointDetail) (done func(err error)) {
+	if o, ok := lb.(Observer); ok {
+		return o.Start(ep)
+	}
+	return func(error) {}
+}
+
 // WeightedRoundRobin is a load balancer that selects endpoints based on their weight.
 type WeightedRoundRobin struct {
 	mu            sync.Mutex
@@ -68,6 +117,11 @@ func (w *WeightedRoundRobin) Pick(endpoints []*heartbeatpb.EndpointDetail) (*hea
 	}
 }
 
+// endpointKey identifies the peer behind an endpoint.
+func endpointKey(ep *heartbeatpb.EndpointDetail) string {
+	return fmt.Sprintf("%s:%d", ep.Ip, ep.Port)
+}
+
 // FilterHealthy returns only the healthy endpoints.
 func FilterHealthy(endpoints []*heartbeatpb.EndpointDetail) []*heartbeatpb.EndpointDetail {
 	var healthy []*heartbeatpb.EndpointDetail
@@ -1,6 +1,8 @@
 package balancer
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"testing"
 
@@ -51,3 +53,129 @@ func TestWeightedRoundRobin_NoHealthy(t *testing.T) {
 		t.Error("expected error when no healthy endpoints available, got nil")
 	}
 }
+
+func TestLeastRequest_AvoidsBusyEndpoint(t *testing.T) {
+	lb := NewLeastRequest()
+
+	endpoints := []*heartbeatpb.EndpointDetail{
+		{NodeId: "node1", Ip: "10.0.0.1", Port: 50052, Healthy: true, Weight: 1},
+		{NodeId: "node2", Ip: "10.0.0.2", Port: 50052, Healthy: true, Weight: 1},
+	}
+
+	done := lb.Start(endpoints[0])
+	for i := 0; i < 10; i++ {
+		ep, err := lb.Pick(endpoints)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ep.NodeId != "node2" {
+			t.Fatalf("expected idle node2 while node1 has a request in flight, got %s", ep.NodeId)
+		}
+	}
+	done(nil)
+}
+
+func TestPowerOfTwo_AvoidsBusyEndpoint(t *testing.T) {
+	lb := NewPowerOfTwo()
+
+	endpoints := []*heartbeatpb.EndpointDetail{
+		{NodeId: "node1", Ip: "10.0.0.1", Port: 50052, Healthy: true, Weight: 1},
+		{NodeId: "node2", Ip: "10.0.0.2", Port: 50052, Healthy: true, Weight: 1},
+		{NodeId: "node3", Ip: "10.0.0.3", Port: 50052, Healthy: true, Weight: 1},
+	}
+
+	// node1 is busy: it can only win a sample in which it is not chosen twice.
+	for i := 0; i < 5; i++ {
+		lb.Start(endpoints[0])
+	}
+	for i := 0; i < 100; i++ {
+		ep, err := lb.Pick(endpoints)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ep.NodeId == "node1" {
+			t.Fatal("expected busy node1 never to be picked over an idle endpoint")
+		}
+	}
+}
+
+func TestLatencyEWMA_PrefersFastEndpoint(t *testing.T) {
+	lb := NewLatencyEWMA()
+
+	endpoints := []*heartbeatpb.EndpointDetail{
+		{NodeId: "node1", Ip: "10.0.0.1", Port: 50052, Healthy: true, Weight: 1},
+		{NodeId: "node2", Ip: "10.0.0.2", Port: 50052, Healthy: true, Weight: 1},
+	}
+
+	// A failed request counts as slow.
+	lb.Start(endpoints[0])(errors.New("unavailable"))
+	lb.Start(endpoints[1])(nil)
+
+	for i := 0; i < 10; i++ {
+		ep, err := lb.Pick(endpoints)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ep.NodeId != "node2" {
+			t.Fatalf("expected fast node2, got %s", ep.NodeId)
+		}
+	}
+}
+
+func TestConsistentHash_SameKeySamePeer(t *testing.T) {
+	lb := NewConsistentHash()
+
+	var endpoints []*heartbeatpb.EndpointDetail
+	for i := 1; i <= 4; i++ {
+		endpoints = append(endpoints, &heartbeatpb.EndpointDetail{
+			NodeId: fmt.Sprintf("node%d", i), Ip: fmt.Sprintf("10.0.0.%d", i), Port: 50052, Healthy: true, Weight: 1,
+		})
+	}
+
+	owners := make(map[string]string)
+	counts := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprintf("session-%d", i)
+		ep, err := lb.PickKey(endpoints, key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		again, _ := lb.PickKey(endpoints, key)
+		if again.NodeId != ep.NodeId {
+			t.Fatalf("key %s went to %s, then to %s", key, ep.NodeId, again.NodeId)
+		}
+		owners[key] = ep.NodeId
+		counts[ep.NodeId]++
+	}
+	if len(counts) != 4 {
+		t.Errorf("expected keys on all 4 nodes, got %v", counts)
+	}
+
+	// Only the keys of an unhealthy peer move.
+	endpoints[0].Healthy = false
+	for key, owner := range owners {
+		ep, err := lb.PickKey(endpoints, key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if owner != "node1" && ep.NodeId != owner {
+			t.Errorf("key %s moved from %s to %s when node1 became unhealthy", key, owner, ep.NodeId)
+		}
+		if ep.NodeId == "node1" {
+			t.Errorf("key %s still went to unhealthy node1", key)
+		}
+	}
+}
+
+func TestNew_UnknownStrategy(t *testing.T) {
+	if _, err := New("fastest"); err == nil {
+		t.Error("expected error for an unknown strategy, got nil")
+	}
+	lb, err := New("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := lb.(*WeightedRoundRobin); !ok {
+		t.Errorf("expected round robin by default, got %T", lb)
+	}
+}
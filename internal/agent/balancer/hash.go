@@ -0,0 +1,113 @@
+package balancer
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+)
+
+// ringPoints is the number of points each peer gets on the hash ring.
+const ringPoints = 64
+
+type ringPoint struct {
+	hash uint64
+	peer string
+}
+
+// ConsistentHash sends requests with the same key to the same peer, for models
+// that keep state between requests of a session. Peers are placed on a hash
+// ring; a key goes to the first healthy peer after its hash, so a peer leaving
+// only moves the keys it served. Requests without a key go to a random peer.
+type ConsistentHash struct {
+	mu    sync.Mutex
+	peers string // Sorted peers the ring was built from
+	ring  []ringPoint
+}
+
+// NewConsistentHash creates a new ConsistentHash load balancer.
+func NewConsistentHash() *ConsistentHash {
+	return &ConsistentHash{}
+}
+
+// Pick selects a random healthy endpoint.
+func (c *ConsistentHash) Pick(endpoints []*heartbeatpb.EndpointDetail) (*heartbeatpb.EndpointDetail, error) {
+	healthy := FilterHealthy(endpoints)
+	if len(healthy) == 0 {
+		return nil, errors.New("no healthy endpoints available")
+	}
+	return healthy[rand.Intn(len(healthy))], nil
+}
+
+// PickKey selects the healthy endpoint that owns key on the ring.
+func (c *ConsistentHash) PickKey(endpoints []*heartbeatpb.EndpointDetail, key string) (*heartbeatpb.EndpointDetail, error) {
+	healthy := make(map[string]*heartbeatpb.EndpointDetail)
+	for _, ep := range FilterHealthy(endpoints) {
+		if _, ok := healthy[endpointKey(ep)]; !ok {
+			healthy[endpointKey(ep)] = ep
+		}
+	}
+	if len(healthy) == 0 {
+		return nil, errors.New("no healthy endpoints available")
+	}
+
+	ring := c.ringFor(endpoints)
+	h := hashString(key)
+	start := sort.Search(len(ring), func(i int) bool { return ring[i].hash >= h })
+	for i := range ring {
+		if ep, ok := healthy[ring[(start+i)%len(ring)].peer]; ok {
+			return ep, nil
+		}
+	}
+	return nil, errors.New("no healthy endpoints available")
+}
+
+// ringFor returns the ring of every peer in endpoints, healthy or not, so that
+// a peer becoming unhealthy does not move the keys of the others.
+func (c *ConsistentHash) ringFor(endpoints []*heartbeatpb.EndpointDetail) []ringPoint {
+	peers := make([]string, 0, len(endpoints))
+	seen := make(map[string]bool)
+	for _, ep := range endpoints {
+		if key := endpointKey(ep); !seen[key] {
+			seen[key] = true
+			peers = append(peers, key)
+		}
+	}
+	sort.Strings(peers)
+	id := strings.Join(peers, ",")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ring != nil && c.peers == id {
+		return c.ring
+	}
+
+	ring := make([]ringPoint, 0, len(peers)*ringPoints)
+	for _, peer := range peers {
+		for i := 0; i < ringPoints; i++ {
+			ring = append(ring, ringPoint{hash: hashString(peer + "#" + strconv.Itoa(i)), peer: peer})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool { return ring[i].hash < ring[j].hash })
+	c.peers, c.ring = id, ring
+	return ring
+}
+
+// hashString hashes s with FNV-1a, mixed so that similar strings spread over
+// the whole ring.
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
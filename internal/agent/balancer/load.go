@@ -0,0 +1,190 @@
+package balancer
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+)
+
+const (
+	// ewmaDecay is the weight of a new latency sample in the moving average.
+	ewmaDecay = 0.3
+	// failurePenalty is the least latency recorded for a failed request, so a
+	// peer that fails fast does not look fast.
+	failurePenalty = time.Second
+)
+
+// peerLoad is the observed load of one peer.
+type peerLoad struct {
+	inFlight int
+	latency  float64 // Moving average in seconds; 0 until the first response
+}
+
+// loadTracker counts the requests in flight to every peer and keeps a moving
+// average of their latency. It implements Observer.
+type loadTracker struct {
+	mu    sync.Mutex
+	peers map[string]*peerLoad
+}
+
+// get returns the load of the peer behind ep. t.mu must be held.
+func (t *loadTracker) get(ep *heartbeatpb.EndpointDetail) *peerLoad {
+	if t.peers == nil {
+		t.peers = make(map[string]*peerLoad)
+	}
+	key := endpointKey(ep)
+	p, ok := t.peers[key]
+	if !ok {
+		p = &peerLoad{}
+		t.peers[key] = p
+	}
+	return p
+}
+
+// Start records a request sent to ep.
+func (t *loadTracker) Start(ep *heartbeatpb.EndpointDetail) func(err error) {
+	t.mu.Lock()
+	p := t.get(ep)
+	p.inFlight++
+	t.mu.Unlock()
+
+	start := time.Now()
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() {
+			elapsed := time.Since(start)
+			if err != nil && elapsed < failurePenalty {
+				elapsed = failurePenalty
+			}
+
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			p.inFlight--
+			if p.latency == 0 {
+				p.latency = elapsed.Seconds()
+			} else {
+				p.latency = ewmaDecay*elapsed.Seconds() + (1-ewmaDecay)*p.latency
+			}
+		})
+	}
+}
+
+// pickMin returns the healthy endpoint with the lowest score, breaking ties at
+// random.
+func (t *loadTracker) pickMin(endpoints []*heartbeatpb.EndpointDetail, score func(ep *heartbeatpb.EndpointDetail, p *peerLoad) float64) (*heartbeatpb.EndpointDetail, error) {
+	healthy := FilterHealthy(endpoints)
+	if len(healthy) == 0 {
+		return nil, errors.New("no healthy endpoints available")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var best *heartbeatpb.EndpointDetail
+	var bestScore float64
+	ties := 0
+	for _, ep := range healthy {
+		s := score(ep, t.get(ep))
+		switch {
+		case best == nil || s < bestScore:
+			best, bestScore, ties = ep, s, 1
+		case s == bestScore:
+			ties++
+			if rand.Intn(ties) == 0 {
+				best = ep
+			}
+		}
+	}
+	return best, nil
+}
+
+// requestScore is the requests in flight to a peer relative to its weight.
+func requestScore(ep *heartbeatpb.EndpointDetail, p *peerLoad) float64 {
+	if ep.Weight <= 0 {
+		return float64(p.inFlight + 1)
+	}
+	return float64(p.inFlight+1) / ep.Weight
+}
+
+// LeastRequest picks the endpoint with the fewest requests in flight relative
+// to its weight.
+type LeastRequest struct {
+	loadTracker
+}
+
+// NewLeastRequest creates a new LeastRequest load balancer.
+func NewLeastRequest() *LeastRequest {
+	return &LeastRequest{}
+}
+
+// Pick selects the least loaded healthy endpoint.
+func (l *LeastRequest) Pick(endpoints []*heartbeatpb.EndpointDetail) (*heartbeatpb.EndpointDetail, error) {
+	return l.pickMin(endpoints, requestScore)
+}
+
+// PowerOfTwo picks two healthy endpoints at random and takes the one with
+// fewer requests in flight relative to its weight. It avoids the herding of
+// LeastRequest when load information is stale.
+type PowerOfTwo struct {
+	loadTracker
+}
+
+// NewPowerOfTwo creates a new PowerOfTwo load balancer.
+func NewPowerOfTwo() *PowerOfTwo {
+	return &PowerOfTwo{}
+}
+
+// Pick selects the less loaded of two random healthy endpoints.
+func (p *PowerOfTwo) Pick(endpoints []*heartbeatpb.EndpointDetail) (*heartbeatpb.EndpointDetail, error) {
+	healthy := FilterHealthy(endpoints)
+	if len(healthy) <= 2 {
+		return p.pickMin(healthy, requestScore)
+	}
+	i := rand.Intn(len(healthy))
+	j := rand.Intn(len(healthy) - 1)
+	if j >= i {
+		j++
+	}
+	return p.pickMin([]*heartbeatpb.EndpointDetail{healthy[i], healthy[j]}, requestScore)
+}
+
+// LatencyEWMA picks the endpoint with the lowest moving average latency times
+// its requests in flight. Endpoints without a response yet are scored with the
+// average latency of the others.
+type LatencyEWMA struct {
+	loadTracker
+}
+
+// NewLatencyEWMA creates a new LatencyEWMA load balancer.
+func NewLatencyEWMA() *LatencyEWMA {
+	return &LatencyEWMA{}
+}
+
+// Pick selects the healthy endpoint expected to answer first.
+func (l *LatencyEWMA) Pick(endpoints []*heartbeatpb.EndpointDetail) (*heartbeatpb.EndpointDetail, error) {
+	l.mu.Lock()
+	var sum float64
+	var observed int
+	for _, ep := range FilterHealthy(endpoints) {
+		if p := l.get(ep); p.latency > 0 {
+			sum += p.latency
+			observed++
+		}
+	}
+	l.mu.Unlock()
+
+	var unobserved float64
+	if observed > 0 {
+		unobserved = sum / float64(observed)
+	}
+	return l.pickMin(endpoints, func(_ *heartbeatpb.EndpointDetail, p *peerLoad) float64 {
+		latency := p.latency
+		if latency == 0 {
+			latency = unobserved
+		}
+		return latency * float64(p.inFlight+1)
+	})
+}
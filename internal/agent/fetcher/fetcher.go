@@ -0,0 +1,377 @@
+// Package fetcher downloads model files onto an edge node before they are
+// loaded into the inference runtime.
+//
+// Local model paths are streamed from the control plane through
+// ModelTransferService.DownloadModel; network URLs are fetched directly.
+// Files are written to "<final>.downloading", verified against the expected
+// SHA256 digest and then atomically renamed into the cache directory.
+package fetcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/modelpath"
+	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultMaxAttempts  = 5
+	defaultRetryBackoff = 2 * time.Second
+
+	// tempSuffix marks a partially downloaded model file.
+	tempSuffix = ".downloading"
+)
+
+// ErrChecksumMismatch is returned when a downloaded file does not match the expected SHA256 digest.
+var ErrChecksumMismatch = errors.New("sha256 mismatch")
+
+// ErrUnsupportedScheme is returned for network URLs the agent cannot fetch directly.
+var ErrUnsupportedScheme = errors.New("unsupported model URL scheme")
+
+// Fetcher downloads model files into a local cache directory.
+type Fetcher struct {
+	controlPlaneAddr string
+	cacheDir         string
+
+	// MaxAttempts bounds the number of download attempts per Fetch call.
+	MaxAttempts int
+	// RetryBackoff is multiplied by the attempt number between retries.
+	RetryBackoff time.Duration
+	// HTTPClient is used for http:// and https:// model URLs.
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex // per destination file
+}
+
+// New creates a Fetcher that streams local model files from the control plane
+// at controlPlaneAddr and caches them under cacheDir.
+func New(controlPlaneAddr, cacheDir string) *Fetcher {
+	return &Fetcher{
+		controlPlaneAddr: controlPlaneAddr,
+		cacheDir:         cacheDir,
+		MaxAttempts:      defaultMaxAttempts,
+		RetryBackoff:     defaultRetryBackoff,
+		HTTPClient:       http.DefaultClient,
+		locks:            make(map[string]*sync.Mutex),
+	}
+}
+
+// Fetch makes the model described by req available on local disk and returns
+// its path. A cached copy is reused only when it matches req.Sha256Hash; without
+// a hash the model is fetched again. Interrupted downloads are resumed from the
+// last written byte.
+func (f *Fetcher) Fetch(ctx context.Context, req *deploypb.DeployModelRequest) (string, error) {
+	if req.GetModelId() == "" {
+		return "", errors.New("model_id cannot be empty")
+	}
+
+	finalPath := filepath.Join(f.cacheDir, CacheFileName(req))
+	lock := f.lockFor(finalPath)
+	lock.Lock()
+	defer lock.Unlock()
+
+	expected := strings.ToLower(strings.TrimSpace(req.GetSha256Hash()))
+
+	// Reuse a previously fetched copy. Without a hash there is no telling
+	// whether it still holds the requested artifact, so it is replaced.
+	if _, err := os.Stat(finalPath); err == nil && expected != "" {
+		if actual, err := fileSHA256(finalPath); err == nil && actual == expected {
+			return finalPath, nil
+		}
+		log.Printf("[fetcher] cached file %s does not match expected hash, fetching again", finalPath)
+		if err := os.Remove(finalPath); err != nil {
+			return "", fmt.Errorf("remove stale cached file: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(f.cacheDir, 0o755); err != nil {
+		return "", fmt.Errorf("create model cache dir: %w", err)
+	}
+
+	tempPath := finalPath + tempSuffix
+	if err := f.download(ctx, req, tempPath); err != nil {
+		return "", err
+	}
+
+	if expected != "" {
+		actual, err := fileSHA256(tempPath)
+		if err != nil {
+			return "", fmt.Errorf("hash downloaded file: %w", err)
+		}
+		if actual != expected {
+			// The partial file cannot be trusted for a later resume either.
+			_ = os.Remove(tempPath)
+			return "", fmt.Errorf("%w: got %s, expected %s", ErrChecksumMismatch, actual, expected)
+		}
+	}
+
+	if err := os.Rename(tempPath, finalPath); err != nil {
+		return "", fmt.Errorf("rename downloaded file: %w", err)
+	}
+	return finalPath, nil
+}
+
+// Remove deletes a cached model file and any partial download next to it.
+// Paths outside the cache directory are left alone, so model files the agent
+// was pointed at directly are never deleted.
+func (f *Fetcher) Remove(path string) error {
+	rel, err := filepath.Rel(f.cacheDir, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") || filepath.IsAbs(rel) {
+		return fmt.Errorf("%s is not in the model cache", path)
+	}
+
+	lock := f.lockFor(path)
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, p := range []string{path, path + tempSuffix} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove cached file: %w", err)
+		}
+	}
+	return nil
+}
+
+// CacheFileName returns the file name a model is cached under. The name includes
+// a hash prefix, or the version and a hash of the file path, so different
+// revisions of a model do not collide.
+func CacheFileName(req *deploypb.DeployModelRequest) string {
+	name := sanitize(req.GetModelId())
+	if hash := strings.TrimSpace(req.GetSha256Hash()); hash != "" {
+		return name + "-" + sanitize(strings.ToLower(hash[:min(len(hash), 16)])) + ".onnx"
+	}
+	if req.GetVersion() != "" {
+		name += "-" + sanitize(req.GetVersion())
+	}
+	sum := sha256.Sum256([]byte(req.GetFilePath()))
+	return name + "-" + hex.EncodeToString(sum[:8]) + ".onnx"
+}
+
+// download writes the model to tempPath, retrying transient failures and
+// resuming from the size of the partial file on every attempt.
+func (f *Fetcher) download(ctx context.Context, req *deploypb.DeployModelRequest, tempPath string) error {
+	network := modelpath.Classify(req.GetFilePath()) == modelpath.Network
+
+	attempts := max(f.MaxAttempts, 1)
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("download cancelled: %w", ctx.Err())
+			case <-time.After(f.RetryBackoff * time.Duration(attempt-1)):
+			}
+		}
+
+		var err error
+		if network {
+			err = f.downloadURL(ctx, req.GetFilePath(), tempPath)
+		} else {
+			err = f.downloadFromControlPlane(ctx, req.GetModelId(), req.GetRevision(), tempPath)
+		}
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+		if !retryable(err) || ctx.Err() != nil {
+			break
+		}
+		log.Printf("[fetcher] attempt %d/%d for model %s failed: %v", attempt, attempts, req.GetModelId(), err)
+	}
+	return fmt.Errorf("download model %s: %w", req.GetModelId(), lastErr)
+}
+
+// downloadFromControlPlane streams the artifact of the given model revision
+// from the control plane's ModelTransferService, appending to any partial file
+// already on disk.
+func (f *Fetcher) downloadFromControlPlane(ctx context.Context, modelID string, revision int32, tempPath string) error {
+	if f.controlPlaneAddr == "" {
+		return permanent(errors.New("control plane address is not configured"))
+	}
+
+	file, offset, err := openPartial(tempPath)
+	if err != nil {
+		return permanent(err)
+	}
+	defer file.Close()
+
+	conn, err := grpc.NewClient(f.controlPlaneAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := deploypb.NewModelTransferServiceClient(conn)
+	stream, err := client.DownloadModel(ctx, &deploypb.ModelDownloadRequest{
+		ModelId:          modelID,
+		ResumeByteOffset: offset,
+		Revision:         revision,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if chunk.GetChunkOffset() != offset {
+			return fmt.Errorf("unexpected chunk offset %d, expected %d", chunk.GetChunkOffset(), offset)
+		}
+		n, err := file.Write(chunk.GetChunkData())
+		if err != nil {
+			return permanent(fmt.Errorf("write model file: %w", err))
+		}
+		offset += int64(n)
+	}
+}
+
+// downloadURL fetches the model over HTTP(S), using a Range request to resume
+// a partial file when the server supports it.
+func (f *Fetcher) downloadURL(ctx context.Context, url, tempPath string) error {
+	lower := strings.ToLower(url)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return permanent(fmt.Errorf("%w: %s", ErrUnsupportedScheme, url))
+	}
+
+	file, offset, err := openPartial(tempPath)
+	if err != nil {
+		return permanent(err)
+	}
+	defer file.Close()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return permanent(err)
+	}
+	if offset > 0 {
+		httpReq.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := f.HTTPClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		// Resuming from offset.
+	case http.StatusOK:
+		// The server ignored the Range header; start over.
+		if err := file.Truncate(0); err != nil {
+			return permanent(err)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return permanent(err)
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset > 0 {
+			return nil // partial file is already complete
+		}
+		return permanent(fmt.Errorf("GET %s: %s", url, resp.Status))
+	default:
+		err := fmt.Errorf("GET %s: %s", url, resp.Status)
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout {
+			return err
+		}
+		return permanent(err)
+	}
+
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (f *Fetcher) lockFor(path string) *sync.Mutex {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	l, ok := f.locks[path]
+	if !ok {
+		l = &sync.Mutex{}
+		f.locks[path] = l
+	}
+	return l
+}
+
+// openPartial opens path for appending and returns the number of bytes already present.
+func openPartial(path string) (*os.File, int64, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open temp file: %w", err)
+	}
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("seek temp file: %w", err)
+	}
+	return file, offset, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// permanentError marks a failure that retrying cannot fix.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func permanent(err error) error { return &permanentError{err: err} }
+
+func retryable(err error) bool {
+	var pe *permanentError
+	if errors.As(err, &pe) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.NotFound, codes.InvalidArgument, codes.PermissionDenied, codes.Unauthenticated:
+		return false
+	}
+	return true
+}
+
+// sanitize keeps a string safe for use as a file name component.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/inputformat"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fieldsInput converts named fields into a single float32 input of shape
+// (1, N) laid out by the replica's input format.
+func fieldsInput(replica ModelReplicaDetails, fields map[string]any) ([]runway.Tensor, error) {
+	format, err := inputformat.Parse(replica.InputFormat)
+	if err != nil {
+		return nil, err
+	}
+	if format == nil {
+		return nil, fmt.Errorf("%w: model %s declares no input format, send input tensors instead", runway.ErrInvalidInput, replica.ModelID)
+	}
+	row, err := format.Encode(fields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", runway.ErrInvalidInput, err)
+	}
+	return FlatInput(row), nil
+}
+
+// InvalidInputStatus converts an error wrapping runway.ErrInvalidInput into an
+// InvalidArgument status. Rejected fields are attached as BadRequest field
+// violations.
+func InvalidInputStatus(err error) *status.Status {
+	st := status.New(codes.InvalidArgument, err.Error())
+	var verr *inputformat.ValidationError
+	if !errors.As(err, &verr) {
+		return st
+	}
+	details := &errdetails.BadRequest{}
+	for _, f := range verr.Fields {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Description,
+		})
+	}
+	if withDetails, err := st.WithDetails(details); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// ValidationErrorFromStatus returns the rejected fields carried by a status
+// built with InvalidInputStatus, or nil if it has none.
+func ValidationErrorFromStatus(st *status.Status) *inputformat.ValidationError {
+	for _, d := range st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok || len(br.GetFieldViolations()) == 0 {
+			continue
+		}
+		verr := &inputformat.ValidationError{}
+		for _, v := range br.GetFieldViolations() {
+			verr.Fields = append(verr.Fields, inputformat.FieldError{Field: v.GetField(), Description: v.GetDescription()})
+		}
+		return verr
+	}
+	return nil
+}
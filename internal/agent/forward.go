@@ -3,43 +3,81 @@ package agent
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/connpool"
 	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
 	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
-// forwardInfer connects to another agent's gRPC server and delegates the inference request.
-func (a *Agent) forwardInfer(target *heartbeatpb.EndpointDetail, modelID string, inputData []float32, scalingEnabled bool) (float32, error) {
+// forwardTimeout bounds a forwarded call when the caller's deadline is later or unset.
+const forwardTimeout = 15 * time.Second
+
+// forwardInfer delegates the inference request to another agent's gRPC server
+// over the pooled connection to that peer. The peer gets what is left of the
+// deadline of ctx, at most forwardTimeout.
+// With ForwardPolicy.Streams the request is sent over the stream to the peer.
+// The peer gets the request one hop further, with this node added to the nodes
+// it has visited.
+// A peer rejecting the inputs as invalid is reported as runway.ErrInvalidInput,
+// together with the fields it rejected if the call was unary.
+func (a *Agent) forwardInfer(ctx context.Context, target *heartbeatpb.EndpointDetail, req InferRequest) (InferResult, error) {
 	peerAddr := fmt.Sprintf("%s:%d", target.Ip, target.Port)
 
-	conn, err := grpc.NewClient(peerAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, release, err := connpool.Default().Get(peerAddr)
 	if err != nil {
-		return 0, fmt.Errorf("failed to connect to peer %s: %v", peerAddr, err)
+		return InferResult{}, fmt.Errorf("failed to connect to peer %s: %v", peerAddr, err)
 	}
-	defer conn.Close()
+	defer release()
 
 	client := inferpb.NewInferAPIClient(conn)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, forwardTimeout)
 	defer cancel()
 
-	resp, err := client.Infer(ctx, &inferpb.InferRequest{
-		ModelId:        modelID,
-		InputData:      inputData,
-		ScalingEnabled: scalingEnabled,
+	pbReq := &inferpb.InferRequest{
+		ModelId:        req.ModelID,
+		Inputs:         TensorsToProto(req.Inputs),
+		ScalingEnabled: req.ScalingEnabled,
 		IsForwarded:    true,
-	})
+		RoutingKey:     req.RoutingKey,
+		Priority:       string(req.Priority),
+		Tenant:         req.Tenant,
+		HopCount:       int32(req.HopCount + 1),
+		MaxHops:        int32(req.MaxHops),
+		Visited:        append(slices.Clone(req.Visited), a.ID),
+	}
+	if len(req.Inputs) == 0 && req.Fields != nil {
+		if pbReq.Fields, err = structpb.NewStruct(req.Fields); err != nil {
+			return InferResult{}, fmt.Errorf("%w: %v", runway.ErrInvalidInput, err)
+		}
+	}
+
+	resp, err := a.sendInfer(ctx, client, peerAddr, pbReq)
 
+	if status.Code(err) == codes.InvalidArgument {
+		st := status.Convert(err)
+		if verr := ValidationErrorFromStatus(st); verr != nil {
+			return InferResult{}, fmt.Errorf("%w: %w", runway.ErrInvalidInput, verr)
+		}
+		return InferResult{}, fmt.Errorf("%w: %s", runway.ErrInvalidInput, st.Message())
+	}
 	if err != nil {
-		return 0, fmt.Errorf("forwarded inference error: %v", err)
+		return InferResult{}, fmt.Errorf("forwarded inference error: %w", err)
 	}
 
 	if !resp.Success {
-		return 0, fmt.Errorf("peer evaluation returned failure: %s", resp.ErrorMessage)
+		return InferResult{}, fmt.Errorf("peer evaluation returned failure: %s", resp.ErrorMessage)
 	}
 
-	return resp.Prediction, nil
+	outputs, err := TensorsFromProto(resp.Outputs)
+	if err != nil {
+		return InferResult{}, err
+	}
+	return InferResult{Outputs: outputs, ServedBy: resp.ServedBy, HopCount: int(resp.HopCount)}, nil
 }
@@ -10,15 +10,16 @@ import (
 
 func CheckHealth(a *agent.Agent) ([]agent.ModelReplicaDetails, bool, error) {
 
+	replicas := a.ListModelReplicas()
 	success := true
-	for _, model := range a.AssignedModels {
+	for _, model := range replicas {
 
 		if model.Status != constants.ModelReplicaStatusRunning {
 			success = false
 			break
 		}
 	}
-	return a.AssignedModels, success, nil
+	return replicas, success, nil
 }
 
 func MonitorHeartbeatStaleness(agentInfo *agent.Agent, controlPlaneAddress string, registerFn func(string, *agent.Agent) error, deregisterFn func(string, string) error) {
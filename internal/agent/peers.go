@@ -0,0 +1,311 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrModelUnavailable is wrapped by errors about requests that no replica of
+// the model could serve: the model is not on this node and no peer answered.
+var ErrModelUnavailable = errors.New("model unavailable")
+
+// ForwardPolicy configures how forwarded inference reacts to failing peers.
+//
+// A failed forward is retried on another endpoint if its gRPC code is in
+// RetryableCodes. Independently, every peer is watched passively: it is ejected
+// from load balancing after EjectAfter consecutive failures, and its circuit
+// breaker opens when at least BreakerFailureRatio of its last BreakerWindow
+// calls failed. Only failures of the peer itself count, such as an unreachable
+// peer or an internal error; inputs rejected as invalid do not.
+type ForwardPolicy struct {
+	// MaxRetries is how many other endpoints are tried after the first one fails.
+	MaxRetries     int
+	RetryableCodes []codes.Code
+
+	// MaxHops is how many times a request may be forwarded between agents,
+	// unless it carries a budget of its own. With 2, an agent whose endpoint
+	// cache is stale can pass a request on once more. Values below 1 are 1.
+	MaxHops int
+
+	// Streams sends forwarded requests over one StreamInfer stream per peer
+	// instead of a unary Infer call each. Peers without StreamInfer are still
+	// called with Infer.
+	Streams bool
+
+	// EjectAfter consecutive failures eject a peer for EjectionTime, doubled for
+	// every ejection in a row and capped at MaxEjectionTime. 0 disables ejection.
+	EjectAfter      int
+	EjectionTime    time.Duration
+	MaxEjectionTime time.Duration
+
+	// BreakerWindow is the number of recent calls the failure ratio is taken
+	// over; the breaker stays closed until a peer has had that many calls.
+	// An open breaker lets a single probe through after BreakerOpenTime.
+	// 0 disables the breaker.
+	BreakerWindow       int
+	BreakerFailureRatio float64
+	BreakerOpenTime     time.Duration
+}
+
+// DefaultForwardPolicy returns the policy agents use unless configured otherwise.
+func DefaultForwardPolicy() ForwardPolicy {
+	return ForwardPolicy{
+		MaxRetries:          2,
+		RetryableCodes:      []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted},
+		MaxHops:             2,
+		EjectAfter:          5,
+		EjectionTime:        30 * time.Second,
+		MaxEjectionTime:     5 * time.Minute,
+		BreakerWindow:       20,
+		BreakerFailureRatio: 0.5,
+		BreakerOpenTime:     30 * time.Second,
+	}
+}
+
+// retryable reports whether a failed forward may be tried on another endpoint.
+func (p ForwardPolicy) retryable(err error) bool {
+	code, ok := grpcCode(err)
+	return ok && slices.Contains(p.RetryableCodes, code)
+}
+
+// peerFailure reports whether err says something about the health of the peer,
+// as opposed to the request.
+func peerFailure(err error) bool {
+	code, _ := grpcCode(err)
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// grpcCode returns the code of the gRPC status wrapped by err. ok is false for
+// errors that did not come from a gRPC call, such as a peer reporting that
+// the model itself failed.
+func grpcCode(err error) (code codes.Code, ok bool) {
+	var se interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &se) {
+		return codes.OK, false
+	}
+	return se.GRPCStatus().Code(), true
+}
+
+// BreakerState is the state of a peer's circuit breaker.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// PeerStats is a snapshot of the health of forwarding to one peer.
+type PeerStats struct {
+	NodeID              string
+	Address             string
+	Breaker             BreakerState
+	ConsecutiveFailures int
+	EjectedUntil        time.Time // Zero unless the peer is ejected
+	Ejections           int       // Ejections since the agent started
+	Requests            int64
+	Failures            int64
+	Retries             int64 // Requests sent to this peer after another peer failed
+}
+
+// peerHealth tracks one peer. Guarded by peerTracker.mu.
+type peerHealth struct {
+	stats       PeerStats
+	ejectStreak int    // Ejections without a success in between
+	outcomes    []bool // Ring of recent call results, true for failure
+	next        int
+	openedAt    time.Time
+	probing     bool // A half-open probe is in flight
+}
+
+// peerTracker keeps the health of every peer this agent forwards to.
+type peerTracker struct {
+	mu    sync.Mutex
+	peers map[string]*peerHealth
+	now   func() time.Time
+}
+
+func newPeerTracker() *peerTracker {
+	return &peerTracker{peers: make(map[string]*peerHealth), now: time.Now}
+}
+
+func peerKey(ep *heartbeatpb.EndpointDetail) string {
+	return fmt.Sprintf("%s:%d", ep.Ip, ep.Port)
+}
+
+func (t *peerTracker) get(ep *heartbeatpb.EndpointDetail) *peerHealth {
+	key := peerKey(ep)
+	p, ok := t.peers[key]
+	if !ok {
+		p = &peerHealth{stats: PeerStats{NodeID: ep.NodeId, Address: key, Breaker: BreakerClosed}}
+		t.peers[key] = p
+	}
+	return p
+}
+
+// candidates copies endpoints for the load balancer, marking peers that are
+// ejected, whose breaker is open or that were already tried as unhealthy.
+func (t *peerTracker) candidates(endpoints []*heartbeatpb.EndpointDetail, policy ForwardPolicy, tried map[string]bool) []*heartbeatpb.EndpointDetail {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	out := make([]*heartbeatpb.EndpointDetail, 0, len(endpoints))
+	for _, ep := range endpoints {
+		p := t.get(ep)
+		usable := ep.Healthy && !tried[peerKey(ep)] && !now.Before(p.stats.EjectedUntil)
+		switch p.stats.Breaker {
+		case BreakerOpen:
+			usable = usable && now.Sub(p.openedAt) >= policy.BreakerOpenTime
+		case BreakerHalfOpen:
+			usable = usable && !p.probing
+		}
+		out = append(out, &heartbeatpb.EndpointDetail{
+			NodeId: ep.NodeId, ReplicaId: ep.ReplicaId, Ip: ep.Ip, Port: ep.Port, Weight: ep.Weight, Healthy: usable,
+		})
+	}
+	return out
+}
+
+// begin records that a call to ep is starting and reports whether the peer may
+// take it. An open breaker whose open time has passed turns half-open and
+// admits this call as its probe; probe reports whether it did.
+func (t *peerTracker) begin(ep *heartbeatpb.EndpointDetail, policy ForwardPolicy, retry bool) (probe, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.get(ep)
+	switch p.stats.Breaker {
+	case BreakerOpen:
+		if t.now().Sub(p.openedAt) < policy.BreakerOpenTime {
+			return false, false
+		}
+		p.stats.Breaker = BreakerHalfOpen
+		p.probing, probe = true, true
+	case BreakerHalfOpen:
+		if p.probing {
+			return false, false
+		}
+		p.probing, probe = true, true
+	}
+	p.stats.Requests++
+	if retry {
+		p.stats.Retries++
+	}
+	return probe, true
+}
+
+// done records the result of a call to ep started by begin.
+func (t *peerTracker) done(ep *heartbeatpb.EndpointDetail, policy ForwardPolicy, probe bool, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.get(ep)
+	failed := err != nil && peerFailure(err)
+	wasProbe := probe
+	if probe {
+		p.probing = false
+	}
+
+	if !failed {
+		p.stats.ConsecutiveFailures = 0
+		p.ejectStreak = 0
+		p.stats.EjectedUntil = time.Time{}
+		if wasProbe {
+			p.stats.Breaker = BreakerClosed
+			p.outcomes = p.outcomes[:0]
+			p.next = 0
+		}
+		p.observe(false, policy.BreakerWindow)
+		return
+	}
+
+	now := t.now()
+	p.stats.Failures++
+	p.stats.ConsecutiveFailures++
+	if policy.EjectAfter > 0 && p.stats.ConsecutiveFailures >= policy.EjectAfter && !now.Before(p.stats.EjectedUntil) {
+		d := policy.EjectionTime << min(p.ejectStreak, 16)
+		if policy.MaxEjectionTime > 0 && d > policy.MaxEjectionTime {
+			d = policy.MaxEjectionTime
+		}
+		p.stats.EjectedUntil = now.Add(d)
+		p.stats.Ejections++
+		p.ejectStreak++
+		p.stats.ConsecutiveFailures = 0
+	}
+
+	if wasProbe {
+		p.stats.Breaker = BreakerOpen
+		p.openedAt = now
+		return
+	}
+	p.observe(true, policy.BreakerWindow)
+	if policy.BreakerWindow > 0 && len(p.outcomes) == policy.BreakerWindow && p.failureRatio() >= policy.BreakerFailureRatio {
+		p.stats.Breaker = BreakerOpen
+		p.openedAt = now
+	}
+}
+
+// cancel records that the caller gave up a call started by begin. It says
+// nothing about the health of the peer, so only a half-open probe is released.
+func (t *peerTracker) cancel(ep *heartbeatpb.EndpointDetail, probe bool) {
+	if !probe {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.get(ep).probing = false
+}
+
+// observe adds a call result to the ring of recent results.
+func (p *peerHealth) observe(failed bool, window int) {
+	if window <= 0 {
+		return
+	}
+	if len(p.outcomes) < window {
+		p.outcomes = append(p.outcomes, failed)
+		return
+	}
+	p.outcomes[p.next] = failed
+	p.next = (p.next + 1) % window
+}
+
+func (p *peerHealth) failureRatio() float64 {
+	var failed int
+	for _, f := range p.outcomes {
+		if f {
+			failed++
+		}
+	}
+	return float64(failed) / float64(len(p.outcomes))
+}
+
+// snapshot returns the stats of every peer, sorted by address.
+func (t *peerTracker) snapshot() []PeerStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	out := make([]PeerStats, 0, len(t.peers))
+	for _, p := range t.peers {
+		s := p.stats
+		if !now.Before(s.EjectedUntil) {
+			s.EjectedUntil = time.Time{}
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Address < out[j].Address })
+	return out
+}
@@ -0,0 +1,253 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/pipeline"
+)
+
+// ErrPipelineNotFound is wrapped by errors about requests for a pipeline the
+// control plane has not told this agent about.
+var ErrPipelineNotFound = errors.New("pipeline not found")
+
+// Pipeline is a registered pipeline known to the agent.
+type Pipeline struct {
+	ID        string
+	Name      string
+	Namespace string
+	Spec      pipeline.Spec
+
+	levels [][]int        // Indexes of the steps, by dependency
+	index  map[string]int // Index of every step by name
+}
+
+// StepResult reports one step of a pipeline request.
+type StepResult struct {
+	Name     string
+	ModelID  string
+	ServedBy string        // Node whose replica ran the step's model
+	HopCount int           // Times the step was forwarded to reach it
+	Latency  time.Duration // Including the time spent forwarding
+}
+
+// UpdatePipelines replaces the known pipelines. Pipelines whose spec is
+// invalid, or whose steps have no model ID, are skipped.
+func (a *Agent) UpdatePipelines(defs []*heartbeatpb.PipelineDefinition) {
+	pipelines := make(map[string]Pipeline, len(defs))
+	for _, def := range defs {
+		p, err := pipelineFromDefinition(def)
+		if err != nil {
+			log.Printf("Ignoring pipeline %s: %v", def.GetId(), err)
+			continue
+		}
+		pipelines[p.ID] = p
+	}
+
+	a.pipelineMu.Lock()
+	defer a.pipelineMu.Unlock()
+	a.pipelines = pipelines
+}
+
+func pipelineFromDefinition(def *heartbeatpb.PipelineDefinition) (Pipeline, error) {
+	p := Pipeline{ID: def.GetId(), Name: def.GetName(), Namespace: def.GetNamespace()}
+	if err := json.Unmarshal([]byte(def.GetSpec()), &p.Spec); err != nil {
+		return p, fmt.Errorf("decode spec: %w", err)
+	}
+	if err := p.Spec.Validate(); err != nil {
+		return p, err
+	}
+	p.index = make(map[string]int, len(p.Spec.Steps))
+	for i, step := range p.Spec.Steps {
+		if step.ModelID == "" {
+			return p, fmt.Errorf("step %q has no model ID", step.Name)
+		}
+		p.index[step.Name] = i
+	}
+	p.levels, _ = p.Spec.Levels()
+	return p, nil
+}
+
+// LookupPipeline returns the ID of a pipeline known to the agent, given its ID
+// or its name.
+func (a *Agent) LookupPipeline(pipeline string) (string, bool) {
+	a.pipelineMu.RLock()
+	defer a.pipelineMu.RUnlock()
+	if _, ok := a.pipelines[pipeline]; ok {
+		return pipeline, true
+	}
+	for id, p := range a.pipelines {
+		if p.Name == pipeline {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+func (a *Agent) pipeline(id string) (Pipeline, bool) {
+	a.pipelineMu.RLock()
+	defer a.pipelineMu.RUnlock()
+	p, ok := a.pipelines[id]
+	return p, ok
+}
+
+// runPipeline runs the steps of a pipeline request level by level. The steps
+// of a level run concurrently, each handled like a request for its model:
+// in-process if this node runs a replica of it, else forwarded to a peer.
+// The first step that fails cancels the others and fails the request.
+func (a *Agent) runPipeline(ctx context.Context, req InferRequest) (InferResult, error) {
+	p, ok := a.pipeline(req.PipelineID)
+	if !ok {
+		return InferResult{}, fmt.Errorf("%w: %s is not known to node %s", ErrPipelineNotFound, req.PipelineID, a.ID)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outputs := make([][]runway.Tensor, len(p.Spec.Steps))
+	steps := make([]StepResult, len(p.Spec.Steps))
+	var errMu sync.Mutex
+	var firstErr error
+
+	for _, level := range p.levels {
+		var wg sync.WaitGroup
+		for _, i := range level {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var err error
+				if outputs[i], steps[i], err = a.runStep(ctx, p, i, req, outputs); err != nil {
+					errMu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMu.Unlock()
+					cancel()
+				}
+			}()
+		}
+		wg.Wait()
+		if firstErr != nil {
+			return InferResult{}, firstErr
+		}
+	}
+
+	result, err := p.results(req.Inputs, outputs)
+	if err != nil {
+		return InferResult{}, fmt.Errorf("pipeline %s: %w", p.Name, err)
+	}
+	return InferResult{Outputs: result, ServedBy: a.ID, HopCount: req.HopCount, Steps: steps}, nil
+}
+
+// runStep binds the inputs of step i from the pipeline request and the
+// outputs of earlier steps, and runs its model. Errors keep what they wrap,
+// such as runway.ErrInvalidInput or ErrModelUnavailable.
+func (a *Agent) runStep(ctx context.Context, p Pipeline, i int, req InferRequest, outputs [][]runway.Tensor) ([]runway.Tensor, StepResult, error) {
+	step := p.Spec.Steps[i]
+	stepReq := InferRequest{
+		ModelID:        step.ModelID,
+		ScalingEnabled: req.ScalingEnabled,
+		RoutingKey:     req.RoutingKey,
+		Priority:       req.Priority,
+		Tenant:         req.Tenant,
+		MaxHops:        req.MaxHops,
+	}
+	if len(step.Inputs) == 0 {
+		stepReq.Inputs, stepReq.Fields = req.Inputs, req.Fields
+	}
+	for _, b := range step.Inputs {
+		t, err := p.lookup(pipeline.ParseRef(b.From), req.Inputs, outputs)
+		if err != nil {
+			return nil, StepResult{}, fmt.Errorf("pipeline %s step %s: %w", p.Name, step.Name, err)
+		}
+		t.Name = b.Input
+		stepReq.Inputs = append(stepReq.Inputs, t)
+	}
+
+	start := time.Now()
+	result, err := a.HandleInfer(ctx, stepReq)
+	if err != nil {
+		return nil, StepResult{}, fmt.Errorf("pipeline %s step %s: %w", p.Name, step.Name, err)
+	}
+	return result.Outputs, StepResult{
+		Name:     step.Name,
+		ModelID:  step.ModelID,
+		ServedBy: result.ServedBy,
+		HopCount: result.HopCount,
+		Latency:  time.Since(start),
+	}, nil
+}
+
+// lookup returns the tensor ref names among the inputs of the pipeline
+// request or the outputs of a finished step. A missing request input wraps
+// runway.ErrInvalidInput.
+func (p Pipeline) lookup(ref pipeline.Ref, inputs []runway.Tensor, outputs [][]runway.Tensor) (runway.Tensor, error) {
+	if ref.Step == pipeline.Input {
+		t, ok := tensorByName(inputs, ref.Output)
+		switch {
+		case !ok && ref.Output == "":
+			return t, fmt.Errorf("%w: request has no input tensors", runway.ErrInvalidInput)
+		case !ok:
+			return t, fmt.Errorf("%w: request has no input %q", runway.ErrInvalidInput, ref.Output)
+		}
+		return t, nil
+	}
+	t, ok := tensorByName(outputs[p.index[ref.Step]], ref.Output)
+	switch {
+	case !ok && ref.Output == "":
+		return t, fmt.Errorf("step %s has no outputs", ref.Step)
+	case !ok:
+		return t, fmt.Errorf("step %s has no output %q", ref.Step, ref.Output)
+	}
+	return t, nil
+}
+
+// results returns the outputs of the pipeline, named after their reference.
+// Without declared outputs, every output of the steps no other step reads
+// from is returned as "<step>.<output>".
+func (p Pipeline) results(inputs []runway.Tensor, outputs [][]runway.Tensor) ([]runway.Tensor, error) {
+	var out []runway.Tensor
+	if len(p.Spec.Outputs) == 0 {
+		for _, name := range p.Spec.Sinks() {
+			for _, t := range outputs[p.index[name]] {
+				t.Name = pipeline.Ref{Step: name, Output: t.Name}.String()
+				out = append(out, t)
+			}
+		}
+		return out, nil
+	}
+
+	for _, o := range p.Spec.Outputs {
+		t, err := p.lookup(pipeline.ParseRef(o), inputs, outputs)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = o
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// tensorByName returns the tensor called name, or the first one if name is
+// empty.
+func tensorByName(tensors []runway.Tensor, name string) (runway.Tensor, bool) {
+	if name == "" {
+		if len(tensors) == 0 {
+			return runway.Tensor{}, false
+		}
+		return tensors[0], true
+	}
+	for _, t := range tensors {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return runway.Tensor{}, false
+}
@@ -0,0 +1,224 @@
+package runway
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BatchConfig enables dynamic batching for a replica. A worker collects queued
+// jobs until they hold MaxBatchSize rows or MaxWait has passed since it took
+// the first one, stacks their inputs along the leading dimension and runs the
+// model once. A MaxBatchSize of 1 or less disables batching.
+type BatchConfig struct {
+	MaxBatchSize int
+	MaxWait      time.Duration
+}
+
+// Enabled reports whether jobs are batched.
+func (c BatchConfig) Enabled() bool {
+	return c.MaxBatchSize > 1
+}
+
+// Batchable reports whether every input and output of the model has a dynamic
+// leading dimension that requests can be stacked along.
+func (s Signature) Batchable() bool {
+	for _, spec := range slices.Concat(s.Inputs, s.Outputs) {
+		if len(spec.Shape) == 0 || spec.Shape[0] != -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// batchKey returns the rows of a job and a key that is equal for jobs whose
+// inputs can be stacked. ok is false if the inputs disagree on their row count,
+// in which case the job runs on its own.
+func batchKey(inputs []Tensor) (rows int64, key string, ok bool) {
+	var b strings.Builder
+	for i, in := range inputs {
+		if len(in.Shape) == 0 {
+			return 0, "", false
+		}
+		if i == 0 {
+			rows = in.Shape[0]
+		} else if in.Shape[0] != rows {
+			return 0, "", false
+		}
+		fmt.Fprintf(&b, "%s%v;", in.DType, in.Shape[1:])
+	}
+	return rows, b.String(), true
+}
+
+// StackInputs concatenates the inputs of several jobs along their leading
+// dimension. Each job's inputs must be ordered like the model's inputs and
+// agree with the other jobs on dtype and trailing dimensions. It returns the
+// stacked inputs and the number of rows each job contributed.
+func StackInputs(jobs [][]Tensor) ([]Tensor, []int64, error) {
+	if len(jobs) == 0 {
+		return nil, nil, nil
+	}
+	rows := make([]int64, len(jobs))
+	_, want, _ := batchKey(jobs[0])
+	for i, inputs := range jobs {
+		n, key, ok := batchKey(inputs)
+		if !ok || key != want || len(inputs) != len(jobs[0]) {
+			return nil, nil, fmt.Errorf("inputs of request %d cannot be stacked with the batch", i)
+		}
+		rows[i] = n
+	}
+
+	stacked := make([]Tensor, len(jobs[0]))
+	for k, first := range jobs[0] {
+		parts := make([]any, len(jobs))
+		var total int64
+		for i, inputs := range jobs {
+			parts[i] = inputs[k].Data
+			total += inputs[k].Shape[0]
+		}
+		shape := slices.Clone(first.Shape)
+		shape[0] = total
+		stacked[k] = Tensor{Name: first.Name, DType: first.DType, Shape: shape, Data: concatData(parts)}
+	}
+	return stacked, rows, nil
+}
+
+// SplitOutputs splits batched outputs back into one set per job, given the
+// rows each job contributed. Every output's leading dimension must equal the
+// total number of rows.
+func SplitOutputs(outputs []Tensor, rows []int64) ([][]Tensor, error) {
+	var total int64
+	for _, n := range rows {
+		total += n
+	}
+
+	split := make([][]Tensor, len(rows))
+	for i := range split {
+		split[i] = make([]Tensor, len(outputs))
+	}
+	for k, out := range outputs {
+		if len(out.Shape) == 0 || out.Shape[0] != total {
+			return nil, fmt.Errorf("output %q has shape %v, cannot split %d batched rows", out.Name, out.Shape, total)
+		}
+		rowLen := int64(out.Len()) / total
+		var offset int64
+		for i, n := range rows {
+			shape := slices.Clone(out.Shape)
+			shape[0] = n
+			split[i][k] = Tensor{
+				Name:  out.Name,
+				DType: out.DType,
+				Shape: shape,
+				Data:  sliceData(out.Data, int(offset*rowLen), int((offset+n)*rowLen)),
+			}
+			offset += n
+		}
+	}
+	return split, nil
+}
+
+func concatData(parts []any) any {
+	switch parts[0].(type) {
+	case []float32:
+		return concatOf[float32](parts)
+	case []float64:
+		return concatOf[float64](parts)
+	case []int32:
+		return concatOf[int32](parts)
+	case []int64:
+		return concatOf[int64](parts)
+	case []uint8:
+		return concatOf[uint8](parts)
+	case []bool:
+		return concatOf[bool](parts)
+	case []string:
+		return concatOf[string](parts)
+	}
+	return nil
+}
+
+func concatOf[T any](parts []any) []T {
+	var out []T
+	for _, p := range parts {
+		out = append(out, p.([]T)...)
+	}
+	return out
+}
+
+func sliceData(data any, from, to int) any {
+	switch d := data.(type) {
+	case []float32:
+		return slices.Clone(d[from:to])
+	case []float64:
+		return slices.Clone(d[from:to])
+	case []int32:
+		return slices.Clone(d[from:to])
+	case []int64:
+		return slices.Clone(d[from:to])
+	case []uint8:
+		return slices.Clone(d[from:to])
+	case []bool:
+		return slices.Clone(d[from:to])
+	case []string:
+		return slices.Clone(d[from:to])
+	}
+	return nil
+}
+
+// collectBatch gathers queued jobs after first until they hold MaxBatchSize
+// rows, MaxWait passes or the worker is stopped. With no MaxWait only jobs
+// already queued are taken.
+func collectBatch(first *InferenceJob, queue *FairQueue, quit <-chan struct{}, cfg BatchConfig) []*InferenceJob {
+	jobs := []*InferenceJob{first}
+	rows, _, _ := batchKey(first.Inputs)
+
+	var timeout <-chan time.Time
+	if cfg.MaxWait > 0 {
+		timer := time.NewTimer(cfg.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for rows < int64(cfg.MaxBatchSize) {
+		var job *InferenceJob
+		var ok bool
+		if timeout == nil {
+			job, ok = queue.TryPop()
+		} else {
+			job, ok = queue.Pop(quit, timeout)
+		}
+		if !ok {
+			return jobs
+		}
+		jobs = append(jobs, job)
+		n, _, _ := batchKey(job.Inputs)
+		rows += max(n, 1)
+	}
+	return jobs
+}
+
+// groupBatch splits collected jobs into groups that can be stacked together,
+// keeping their order and at most maxRows rows per group. Jobs that cannot be
+// stacked, or that alone exceed maxRows, form groups of their own.
+func groupBatch(jobs []*InferenceJob, maxRows int) [][]*InferenceJob {
+	var groups [][]*InferenceJob
+	open := make(map[string]int) // key -> index of the group still accepting jobs
+	rowsOf := make(map[int]int64)
+	for _, job := range jobs {
+		n, key, ok := batchKey(job.Inputs)
+		if !ok {
+			groups = append(groups, []*InferenceJob{job})
+			continue
+		}
+		if i, found := open[key]; found && rowsOf[i]+n <= int64(maxRows) {
+			groups[i] = append(groups[i], job)
+			rowsOf[i] += n
+			continue
+		}
+		groups = append(groups, []*InferenceJob{job})
+		open[key] = len(groups) - 1
+		rowsOf[len(groups)-1] = n
+	}
+	return groups
+}
@@ -0,0 +1,84 @@
+package runway
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/preprocess"
+)
+
+// bindPreprocessing checks that a preprocessing spec fits the model's signature
+// and returns a copy naming the input its features feed.
+func bindPreprocessing(spec *preprocess.Spec, sig Signature) (*preprocess.Spec, error) {
+	bound := *spec
+	if bound.Input == "" {
+		if len(sig.Inputs) != 1 {
+			return nil, fmt.Errorf("preprocessing: model has %d inputs (%s), input must be set", len(sig.Inputs), specNames(sig.Inputs))
+		}
+		bound.Input = sig.Inputs[0].Name
+	}
+
+	i := slices.IndexFunc(sig.Inputs, func(s TensorSpec) bool { return s.Name == bound.Input })
+	if i < 0 {
+		return nil, fmt.Errorf("preprocessing: model has no input %q (inputs: %s)", bound.Input, specNames(sig.Inputs))
+	}
+	in := sig.Inputs[i]
+	if len(bound.Features) > 0 {
+		if in.DType != DataTypeFloat32 || len(in.Shape) == 0 {
+			return nil, fmt.Errorf("preprocessing: input %s cannot take transformed features", in)
+		}
+		if last := in.Shape[len(in.Shape)-1]; last >= 0 && last != int64(bound.Width()) {
+			return nil, fmt.Errorf("preprocessing: features produce %d columns, input %s expects %d", bound.Width(), in, last)
+		}
+	}
+	if bound.Target != nil && sig.Outputs[0].DType != DataTypeFloat32 {
+		return nil, fmt.Errorf("preprocessing: target needs a float32 first output, got %s", sig.Outputs[0])
+	}
+	return &bound, nil
+}
+
+// preprocessInputs applies the feature transforms to the input named by spec.
+// An unnamed input is taken to be it when the model has a single input. The
+// input's last dimension must hold one value per feature. Errors wrap
+// ErrInvalidInput.
+func preprocessInputs(spec *preprocess.Spec, sig Signature, inputs []Tensor) ([]Tensor, error) {
+	if len(spec.Features) == 0 {
+		return inputs, nil
+	}
+	out := slices.Clone(inputs)
+	for i, in := range out {
+		if in.Name != spec.Input && (in.Name != "" || len(sig.Inputs) != 1) {
+			continue
+		}
+		data, ok := in.Data.([]float32)
+		if !ok || in.DType != DataTypeFloat32 {
+			return nil, fmt.Errorf("%w: input %q must be float32 to be preprocessed, got %s", ErrInvalidInput, spec.Input, in.DType)
+		}
+		if len(in.Shape) == 0 || in.Shape[len(in.Shape)-1] != int64(len(spec.Features)) {
+			return nil, fmt.Errorf("%w: input %q has shape %v, preprocessing expects %d features in the last dimension",
+				ErrInvalidInput, spec.Input, in.Shape, len(spec.Features))
+		}
+		transformed, err := spec.Transform(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: input %q: %v", ErrInvalidInput, spec.Input, err)
+		}
+		shape := slices.Clone(in.Shape)
+		shape[len(shape)-1] = int64(spec.Width())
+		out[i].Shape, out[i].Data = shape, transformed
+	}
+	return out, nil
+}
+
+// postprocessOutputs maps the model's first output back to the target's scale.
+func postprocessOutputs(spec *preprocess.Spec, outputs []Tensor) error {
+	if spec.Target == nil || len(outputs) == 0 {
+		return nil
+	}
+	data, ok := outputs[0].Data.([]float32)
+	if !ok {
+		return errors.New("preprocessing: target needs a float32 output")
+	}
+	spec.InverseTarget(data)
+	return nil
+}
@@ -0,0 +1,307 @@
+package runway
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+)
+
+// ErrQueueFull is wrapped by errors about jobs rejected because the replica's
+// queue, or the share of it a tenant may hold, is full.
+var ErrQueueFull = errors.New("inference worker queue is full")
+
+// ErrReplicaStopped is wrapped by errors about jobs for a replica whose
+// workers were stopped before they could run them.
+var ErrReplicaStopped = errors.New("model replica stopped")
+
+const (
+	// DefaultRetryAfter is the retry hint of a full queue whose replica has not
+	// finished a job yet.
+	DefaultRetryAfter = time.Second
+	// minRetryAfter and maxRetryAfter bound the retry hint of a full queue.
+	minRetryAfter = 100 * time.Millisecond
+	maxRetryAfter = 10 * time.Second
+)
+
+// QueueFullError reports a job the replica's queue had no room for. It wraps
+// the error of FairQueue.Push and suggests when a retry may find room.
+type QueueFullError struct {
+	ReplicaID  string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *QueueFullError) Error() string {
+	return fmt.Sprintf("replica %s: %v, retry after %s", e.ReplicaID, e.Err, e.RetryAfter)
+}
+
+func (e *QueueFullError) Unwrap() error { return e.Err }
+
+// retryAfter estimates how long until a full queue of the worker has room
+// again: the 95th percentile of its recent job latencies, within
+// [minRetryAfter, maxRetryAfter].
+func (w *ModelWorker) retryAfter() time.Duration {
+	p95 := w.latencies.Percentile(95)
+	if p95 <= 0 {
+		return DefaultRetryAfter
+	}
+	return min(max(p95, minRetryAfter), maxRetryAfter)
+}
+
+// QueueConfig sizes the job queue of a replica.
+type QueueConfig struct {
+	// Capacity is the number of jobs that may wait across all classes.
+	Capacity int
+	// MaxPerTenant is the number of jobs one tenant may have waiting, so a
+	// noisy client cannot fill the queue. 0 means no limit beyond Capacity.
+	MaxPerTenant int
+	// TenantWeights gives tenants a larger share of the replica when it is
+	// busy. Tenants without a weight have weight 1.
+	TenantWeights map[string]float64
+}
+
+// DefaultQueueConfig returns the queue settings agents use unless configured otherwise.
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{Capacity: 100, MaxPerTenant: 50}
+}
+
+var (
+	queueConfig   = DefaultQueueConfig()
+	queueConfigMu sync.RWMutex
+)
+
+// SetQueueConfig sets the queue settings of replicas started afterwards.
+func SetQueueConfig(cfg QueueConfig) {
+	queueConfigMu.Lock()
+	defer queueConfigMu.Unlock()
+	queueConfig = cfg
+}
+
+// CurrentQueueConfig returns the queue settings of replicas started now.
+func CurrentQueueConfig() QueueConfig {
+	queueConfigMu.RLock()
+	defer queueConfigMu.RUnlock()
+	return queueConfig
+}
+
+// ClassStats is a snapshot of one priority class of a replica's queue.
+type ClassStats struct {
+	Class      constants.PriorityClass
+	QueueDepth int     // Jobs waiting
+	Enqueued   int64   // Jobs accepted since the replica started
+	Rejected   int64   // Jobs turned away because the queue was full
+	P95WaitMs  float64 // 95th percentile of the time recent jobs waited
+}
+
+// FairQueue holds the jobs waiting for a replica's workers. Classes are served
+// in strict priority order. Within a class, tenants are served by weighted
+// fair queuing: each job gets a virtual finish time of its rows divided by its
+// tenant's weight after the tenant's previous job, and the job that finishes
+// first runs next. A tenant sending many requests thus only delays its own.
+type FairQueue struct {
+	mu      sync.Mutex
+	cfg     QueueConfig
+	classes []*classQueue // Highest priority first
+	depth   map[string]int
+	size    int
+	seq     uint64        // Orders jobs with the same finish time
+	ready   chan struct{} // One token per queued job
+	closed  bool
+}
+
+type classQueue struct {
+	class    constants.PriorityClass
+	tenants  map[string]*tenantQueue
+	vtime    float64 // Finish time of the last job taken
+	enqueued int64
+	rejected int64
+	waits    *LatencyWindow
+}
+
+type tenantQueue struct {
+	jobs       []*InferenceJob
+	lastFinish float64
+}
+
+// NewFairQueue creates an empty queue. A Capacity below 1 is raised to 1.
+func NewFairQueue(cfg QueueConfig) *FairQueue {
+	cfg.Capacity = max(cfg.Capacity, 1)
+	q := &FairQueue{
+		cfg:   cfg,
+		depth: make(map[string]int),
+		ready: make(chan struct{}, cfg.Capacity),
+	}
+	for _, class := range constants.PriorityClasses {
+		q.classes = append(q.classes, &classQueue{
+			class:   class,
+			tenants: make(map[string]*tenantQueue),
+			waits:   NewLatencyWindow(latencySamples, latencyMaxAge),
+		})
+	}
+	return q
+}
+
+// classOf returns the queue of a job's class; unknown classes are normal.
+func (q *FairQueue) classOf(job *InferenceJob) *classQueue {
+	i := slices.Index(constants.PriorityClasses, job.Priority)
+	if i < 0 {
+		i = slices.Index(constants.PriorityClasses, constants.PriorityNormal)
+	}
+	return q.classes[i]
+}
+
+// Push queues job, or fails with an error wrapping ErrQueueFull. Once the
+// queue is closed it fails with ErrReplicaStopped.
+func (q *FairQueue) Push(job *InferenceJob) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return ErrReplicaStopped
+	}
+	cq := q.classOf(job)
+	if q.size >= q.cfg.Capacity {
+		cq.rejected++
+		q.mu.Unlock()
+		return ErrQueueFull
+	}
+	if q.cfg.MaxPerTenant > 0 && q.depth[job.Tenant] >= q.cfg.MaxPerTenant {
+		cq.rejected++
+		q.mu.Unlock()
+		return fmt.Errorf("%w: tenant %q has %d requests waiting", ErrQueueFull, job.Tenant, q.cfg.MaxPerTenant)
+	}
+
+	tq, ok := cq.tenants[job.Tenant]
+	if !ok {
+		tq = &tenantQueue{}
+		cq.tenants[job.Tenant] = tq
+	}
+	weight := q.cfg.TenantWeights[job.Tenant]
+	if weight <= 0 {
+		weight = 1
+	}
+	rows, _, _ := batchKey(job.Inputs)
+	job.finish = max(cq.vtime, tq.lastFinish) + float64(max(rows, 1))/weight
+	tq.lastFinish = job.finish
+	q.seq++
+	job.seq = q.seq
+	tq.jobs = append(tq.jobs, job)
+
+	cq.enqueued++
+	q.depth[job.Tenant]++
+	q.size++
+	q.mu.Unlock()
+
+	q.ready <- struct{}{}
+	return nil
+}
+
+// Pop waits for the next job until quit is closed or timeout fires, either of
+// which may be nil. ok is false if no job was taken.
+func (q *FairQueue) Pop(quit <-chan struct{}, timeout <-chan time.Time) (job *InferenceJob, ok bool) {
+	select {
+	case <-q.ready:
+		return q.take(), true
+	case <-quit:
+		return nil, false
+	case <-timeout:
+		return nil, false
+	}
+}
+
+// TryPop takes the next job if one is waiting.
+func (q *FairQueue) TryPop() (job *InferenceJob, ok bool) {
+	select {
+	case <-q.ready:
+		return q.take(), true
+	default:
+		return nil, false
+	}
+}
+
+// take removes the next job: the one finishing first in the highest class
+// with jobs waiting. A token must have been taken from q.ready.
+func (q *FairQueue) take() *InferenceJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, cq := range q.classes {
+		var next string
+		var head *InferenceJob
+		for tenant, tq := range cq.tenants {
+			if j := tq.jobs[0]; head == nil || j.finish < head.finish || (j.finish == head.finish && j.seq < head.seq) {
+				next, head = tenant, j
+			}
+		}
+		if head == nil {
+			continue
+		}
+
+		tq := cq.tenants[next]
+		tq.jobs = tq.jobs[1:]
+		if len(tq.jobs) == 0 {
+			delete(cq.tenants, next)
+		}
+		cq.vtime = head.finish
+		cq.waits.Observe(time.Since(head.enqueuedAt))
+
+		q.size--
+		if q.depth[next]--; q.depth[next] == 0 {
+			delete(q.depth, next)
+		}
+		return head
+	}
+	panic("runway: job queue token without a queued job")
+}
+
+// Close stops the queue from taking jobs and returns the jobs still waiting,
+// in no particular order. It must not be called while Pop or TryPop may still
+// be called.
+func (q *FairQueue) Close() []*InferenceJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	var left []*InferenceJob
+	for _, cq := range q.classes {
+		for tenant, tq := range cq.tenants {
+			left = append(left, tq.jobs...)
+			delete(cq.tenants, tenant)
+		}
+	}
+	clear(q.depth)
+	q.size = 0
+	return left
+}
+
+// Len returns the number of jobs waiting.
+func (q *FairQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Stats returns the state of every class, highest priority first.
+func (q *FairQueue) Stats() []ClassStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	out := make([]ClassStats, len(q.classes))
+	for i, cq := range q.classes {
+		depth := 0
+		for _, tq := range cq.tenants {
+			depth += len(tq.jobs)
+		}
+		out[i] = ClassStats{
+			Class:      cq.class,
+			QueueDepth: depth,
+			Enqueued:   cq.enqueued,
+			Rejected:   cq.rejected,
+			P95WaitMs:  float64(cq.waits.Percentile(95)) / float64(time.Millisecond),
+		}
+	}
+	return out
+}
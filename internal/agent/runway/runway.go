@@ -1,45 +1,41 @@
 package runway
 
 import (
-	"log"
+	"fmt"
+	"os"
 	"runtime"
 
 	ort "github.com/yalue/onnxruntime_go"
 )
 
-func InitRuntime() {
-
-	switch runtime.GOOS {
-	case "windows":
-		ort.SetSharedLibraryPath(`./assets/onnxruntime_local_windows_x64.dll`)
-	// case "linux":
-	// 	ort.SetSharedLibraryPath(`./assets/onnxruntime_local_linux_x64.so`)
-	// case "darwin":
-	// 	ort.SetSharedLibraryPath(`./assets/onnxruntime_local_darwin_x64.dylib`)
-	default:
-		log.Fatalf("Failed to initialize onnxruntime: Unsupported OS - %s", runtime.GOOS)
+// sharedLibraryEnv overrides the default onnxruntime shared library location.
+const sharedLibraryEnv = "ONNXRUNTIME_SHARED_LIBRARY_PATH"
+
+// InitRuntime loads the onnxruntime shared library and initializes the environment.
+// The library path defaults to the bundled asset for the current OS and can be
+// overridden with ONNXRUNTIME_SHARED_LIBRARY_PATH.
+func InitRuntime() error {
+	libPath := os.Getenv(sharedLibraryEnv)
+	if libPath == "" {
+		switch runtime.GOOS {
+		case "windows":
+			libPath = `./assets/onnxruntime_local_windows_x64.dll`
+		case "linux":
+			libPath = `./assets/onnxruntime_local_linux_x64.so`
+		case "darwin":
+			libPath = `./assets/onnxruntime_local_darwin_x64.dylib`
+		default:
+			return fmt.Errorf("failed to initialize onnxruntime: unsupported OS %s (set %s)", runtime.GOOS, sharedLibraryEnv)
+		}
 	}
+	ort.SetSharedLibraryPath(libPath)
 
-	err := ort.InitializeEnvironment()
-	if err != nil {
-		log.Fatalf("Failed to initialize onnxruntime: %v", err)
+	if err := ort.InitializeEnvironment(); err != nil {
+		return fmt.Errorf("failed to initialize onnxruntime from %s: %w", libPath, err)
 	}
+	return nil
 }
 
 func CloseRuntime() {
 	ort.DestroyEnvironment()
 }
-
-var xMeans = []float32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
-var xScales = []float32{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
-var yMean float32 = 0
-var yScale float32 = 1
-
-func ScaleFeatures(raw []float32) []float32 {
-
-	scaled := make([]float32, len(raw))
-	for i := range raw {
-		scaled[i] = (raw[i] - xMeans[i]) / xScales[i]
-	}
-	return scaled
-}
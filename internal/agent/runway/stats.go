@@ -0,0 +1,109 @@
+package runway
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// latencySamples is how many recent job latencies each worker pool keeps.
+	latencySamples = 200
+	// latencyMaxAge is how long a job latency counts as recent. Once a replica
+	// has been idle this long its percentiles drop to 0 rather than keep
+	// reporting its last busy period.
+	latencyMaxAge = time.Minute
+)
+
+// WorkerStats is a snapshot of the load on a replica's worker pool.
+type WorkerStats struct {
+	QueueDepth   int          // Jobs waiting for a free worker
+	InFlight     int          // Jobs currently being processed
+	P95LatencyMs float64      // 95th percentile of recent job latencies, queueing included
+	RecentJobs   int          // Jobs finished recently, that P95LatencyMs is taken over
+	Classes      []ClassStats // Queue of every priority class, highest first
+}
+
+// LatencyWindow keeps the most recent job latencies in a ring buffer.
+// Samples older than the window's maximum age are left out of its statistics.
+type LatencyWindow struct {
+	mu      sync.Mutex
+	samples []latencySample
+	next    int
+	maxAge  time.Duration
+}
+
+type latencySample struct {
+	at time.Time
+	d  time.Duration
+}
+
+// NewLatencyWindow creates a window holding up to size samples that count
+// for maxAge after they are observed. A maxAge of 0 keeps them until replaced.
+func NewLatencyWindow(size int, maxAge time.Duration) *LatencyWindow {
+	return &LatencyWindow{samples: make([]latencySample, 0, max(size, 1)), maxAge: maxAge}
+}
+
+// Observe records a job latency, replacing the oldest sample once the window is full.
+func (w *LatencyWindow) Observe(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	sample := latencySample{at: time.Now(), d: d}
+	if len(w.samples) < cap(w.samples) {
+		w.samples = append(w.samples, sample)
+		return
+	}
+	w.samples[w.next] = sample
+	w.next = (w.next + 1) % len(w.samples)
+}
+
+// recent returns the latencies of the samples that have not aged out.
+func (w *LatencyWindow) recent() []time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	out := make([]time.Duration, 0, len(w.samples))
+	for _, s := range w.samples {
+		if w.maxAge <= 0 || now.Sub(s.at) <= w.maxAge {
+			out = append(out, s.d)
+		}
+	}
+	return out
+}
+
+// Len returns the number of samples in the window that have not aged out.
+func (w *LatencyWindow) Len() int {
+	return len(w.recent())
+}
+
+// Percentile returns the p-th percentile (0-100) of the samples in the window
+// that have not aged out, or 0 if there are none.
+func (w *LatencyWindow) Percentile(p float64) time.Duration {
+	sorted := w.recent()
+	if len(sorted) == 0 {
+		return 0
+	}
+	slices.Sort(sorted)
+	// Nearest-rank percentile.
+	rank := int(math.Ceil(float64(len(sorted))*p/100)) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// Stats returns the load of the worker pool serving replicaID.
+func Stats(replicaID string) (WorkerStats, bool) {
+	registryMu.RLock()
+	worker, exists := workerRegistry[replicaID]
+	registryMu.RUnlock()
+
+	if !exists {
+		return WorkerStats{}, false
+	}
+	return WorkerStats{
+		QueueDepth:   worker.Queue.Len(),
+		InFlight:     int(worker.inFlight.Load()),
+		P95LatencyMs: float64(worker.latencies.Percentile(95)) / float64(time.Millisecond),
+		RecentJobs:   worker.latencies.Len(),
+		Classes:      worker.Queue.Stats(),
+	}, true
+}
@@ -0,0 +1,267 @@
+package runway
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// ErrInvalidInput is wrapped by errors about inference inputs that do not
+// match the model's signature.
+var ErrInvalidInput = errors.New("invalid inference input")
+
+// DataType is the element type of a tensor.
+type DataType string
+
+const (
+	DataTypeFloat32 DataType = "float32"
+	DataTypeFloat64 DataType = "float64"
+	DataTypeInt32   DataType = "int32"
+	DataTypeInt64   DataType = "int64"
+	DataTypeUint8   DataType = "uint8"
+	DataTypeBool    DataType = "bool"
+	DataTypeString  DataType = "string"
+)
+
+// Tensor is a named n-dimensional array passed to or returned by a model.
+// Data holds the elements in row-major order as a []float32, []float64,
+// []int32, []int64, []uint8, []bool or []string matching DType.
+type Tensor struct {
+	Name  string
+	DType DataType
+	Shape []int64
+	Data  any
+}
+
+// dataTypeOf returns the DataType matching the Go type of tensor data, or ""
+// if the type is not a supported element slice.
+func dataTypeOf(data any) DataType {
+	switch data.(type) {
+	case []float32:
+		return DataTypeFloat32
+	case []float64:
+		return DataTypeFloat64
+	case []int32:
+		return DataTypeInt32
+	case []int64:
+		return DataTypeInt64
+	case []uint8:
+		return DataTypeUint8
+	case []bool:
+		return DataTypeBool
+	case []string:
+		return DataTypeString
+	}
+	return ""
+}
+
+// Len returns the number of elements in Data.
+func (t Tensor) Len() int {
+	switch d := t.Data.(type) {
+	case []float32:
+		return len(d)
+	case []float64:
+		return len(d)
+	case []int32:
+		return len(d)
+	case []int64:
+		return len(d)
+	case []uint8:
+		return len(d)
+	case []bool:
+		return len(d)
+	case []string:
+		return len(d)
+	}
+	return 0
+}
+
+// TensorSpec describes a model input or output as declared in the ONNX graph.
+// Dimensions of -1 are dynamic and accept any size.
+type TensorSpec struct {
+	Name  string
+	DType DataType
+	Shape []int64
+}
+
+func (s TensorSpec) String() string {
+	return fmt.Sprintf("%s %s%v", s.Name, s.DType, s.Shape)
+}
+
+// Signature lists the inputs and outputs of a loaded model.
+type Signature struct {
+	Inputs  []TensorSpec
+	Outputs []TensorSpec
+}
+
+// ValidateInputs checks inputs against the model's input specs and returns
+// them in the order the model declares its inputs. An input without a name is
+// bound to the model's only input. Errors wrap ErrInvalidInput.
+func ValidateInputs(specs []TensorSpec, inputs []Tensor) ([]Tensor, error) {
+	byName := make(map[string]Tensor, len(inputs))
+	for _, in := range inputs {
+		if in.Name == "" {
+			if len(specs) != 1 {
+				return nil, fmt.Errorf("%w: model has %d inputs (%s), every input must be named",
+					ErrInvalidInput, len(specs), specNames(specs))
+			}
+			in.Name = specs[0].Name
+		}
+		if _, dup := byName[in.Name]; dup {
+			return nil, fmt.Errorf("%w: input %q given more than once", ErrInvalidInput, in.Name)
+		}
+		byName[in.Name] = in
+	}
+
+	ordered := make([]Tensor, 0, len(specs))
+	for _, spec := range specs {
+		in, ok := byName[spec.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing input %q (%s)", ErrInvalidInput, spec.Name, spec)
+		}
+		delete(byName, spec.Name)
+		if err := checkTensor(spec, in); err != nil {
+			return nil, err
+		}
+		ordered = append(ordered, in)
+	}
+	for name := range byName {
+		return nil, fmt.Errorf("%w: model has no input %q (inputs: %s)", ErrInvalidInput, name, specNames(specs))
+	}
+	return ordered, nil
+}
+
+// checkTensor verifies that a tensor matches the dtype and shape of spec and
+// holds as many elements as its shape requires.
+func checkTensor(spec TensorSpec, t Tensor) error {
+	if t.DType != spec.DType {
+		return fmt.Errorf("%w: input %q has dtype %s, model expects %s", ErrInvalidInput, spec.Name, t.DType, spec.DType)
+	}
+	if t.DType == DataTypeString {
+		return fmt.Errorf("%w: input %q: string tensors are not supported by the runtime", ErrInvalidInput, spec.Name)
+	}
+	if got := dataTypeOf(t.Data); got != t.DType {
+		return fmt.Errorf("%w: input %q has dtype %s but holds %s values", ErrInvalidInput, spec.Name, t.DType, got)
+	}
+	if len(t.Shape) != len(spec.Shape) {
+		return fmt.Errorf("%w: input %q has shape %v, model expects %v", ErrInvalidInput, spec.Name, t.Shape, spec.Shape)
+	}
+	elements := int64(1)
+	for i, dim := range t.Shape {
+		if dim < 0 || (spec.Shape[i] >= 0 && dim != spec.Shape[i]) {
+			return fmt.Errorf("%w: input %q has shape %v, model expects %v", ErrInvalidInput, spec.Name, t.Shape, spec.Shape)
+		}
+		elements *= dim
+	}
+	if int64(t.Len()) != elements {
+		return fmt.Errorf("%w: input %q of shape %v needs %d values, got %d", ErrInvalidInput, spec.Name, t.Shape, elements, t.Len())
+	}
+	return nil
+}
+
+func specNames(specs []TensorSpec) string {
+	return strings.Join(specNamesOf(specs), ", ")
+}
+
+func specNamesOf(specs []TensorSpec) []string {
+	names := make([]string, len(specs))
+	for i, s := range specs {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// readSignature reads the input and output tensors declared by an ONNX model.
+// Outputs that are not tensors, such as the maps emitted by some classifiers,
+// are left out; inputs must all be tensors of a supported type.
+func readSignature(modelPath string) (Signature, error) {
+	inputs, outputs, err := ort.GetInputOutputInfo(modelPath)
+	if err != nil {
+		return Signature{}, fmt.Errorf("read model inputs and outputs: %w", err)
+	}
+
+	var sig Signature
+	for _, info := range inputs {
+		dtype, ok := fromOrtType(info.DataType)
+		if info.OrtValueType != ort.ONNXTypeTensor || !ok {
+			return Signature{}, fmt.Errorf("unsupported model input %s", info.String())
+		}
+		sig.Inputs = append(sig.Inputs, TensorSpec{Name: info.Name, DType: dtype, Shape: slices.Clone(info.Dimensions)})
+	}
+	for _, info := range outputs {
+		dtype, ok := fromOrtType(info.DataType)
+		if info.OrtValueType != ort.ONNXTypeTensor || !ok {
+			continue
+		}
+		sig.Outputs = append(sig.Outputs, TensorSpec{Name: info.Name, DType: dtype, Shape: slices.Clone(info.Dimensions)})
+	}
+	if len(sig.Outputs) == 0 {
+		return Signature{}, errors.New("model has no tensor outputs")
+	}
+	return sig, nil
+}
+
+func fromOrtType(t ort.TensorElementDataType) (DataType, bool) {
+	switch t {
+	case ort.TensorElementDataTypeFloat:
+		return DataTypeFloat32, true
+	case ort.TensorElementDataTypeDouble:
+		return DataTypeFloat64, true
+	case ort.TensorElementDataTypeInt32:
+		return DataTypeInt32, true
+	case ort.TensorElementDataTypeInt64:
+		return DataTypeInt64, true
+	case ort.TensorElementDataTypeUint8:
+		return DataTypeUint8, true
+	case ort.TensorElementDataTypeBool:
+		return DataTypeBool, true
+	case ort.TensorElementDataTypeString:
+		return DataTypeString, true
+	}
+	return "", false
+}
+
+// toOrtValue copies a validated tensor into a new onnxruntime tensor.
+func toOrtValue(t Tensor) (ort.Value, error) {
+	shape := ort.NewShape(t.Shape...)
+	switch d := t.Data.(type) {
+	case []float32:
+		return ort.NewTensor(shape, slices.Clone(d))
+	case []float64:
+		return ort.NewTensor(shape, slices.Clone(d))
+	case []int32:
+		return ort.NewTensor(shape, slices.Clone(d))
+	case []int64:
+		return ort.NewTensor(shape, slices.Clone(d))
+	case []uint8:
+		return ort.NewTensor(shape, slices.Clone(d))
+	case []bool:
+		return ort.NewTensor(shape, slices.Clone(d))
+	}
+	return nil, fmt.Errorf("%s tensors are not supported by the runtime", t.DType)
+}
+
+// fromOrtValue copies an output produced by the runtime into a Tensor.
+func fromOrtValue(name string, v ort.Value) (Tensor, error) {
+	t := Tensor{Name: name, Shape: slices.Clone(v.GetShape())}
+	switch o := v.(type) {
+	case *ort.Tensor[float32]:
+		t.DType, t.Data = DataTypeFloat32, slices.Clone(o.GetData())
+	case *ort.Tensor[float64]:
+		t.DType, t.Data = DataTypeFloat64, slices.Clone(o.GetData())
+	case *ort.Tensor[int32]:
+		t.DType, t.Data = DataTypeInt32, slices.Clone(o.GetData())
+	case *ort.Tensor[int64]:
+		t.DType, t.Data = DataTypeInt64, slices.Clone(o.GetData())
+	case *ort.Tensor[uint8]:
+		t.DType, t.Data = DataTypeUint8, slices.Clone(o.GetData())
+	case *ort.Tensor[bool]:
+		t.DType, t.Data = DataTypeBool, slices.Clone(o.GetData())
+	default:
+		return Tensor{}, fmt.Errorf("output %q has unsupported type %T", name, v)
+	}
+	return t, nil
+}
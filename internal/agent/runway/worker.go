@@ -1,29 +1,59 @@
 package runway
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/preprocess"
 	ort "github.com/yalue/onnxruntime_go"
 )
 
-// InferenceJob describes an inference request holding unscaled input data
-// and channels to asynchronously pass the prediction or error back to the caller.
+// DefaultInferenceTimeout bounds the inferences of callers that set no deadline.
+const DefaultInferenceTimeout = 5 * time.Second
+
+// InferenceJob describes an inference request holding preprocessed input
+// tensors, ordered like the model's inputs, and channels to asynchronously pass
+// the output tensors or error back to the caller. Priority and Tenant place the
+// job in the replica's FairQueue.
 type InferenceJob struct {
-	InputData      []float32
-	ScalingEnabled bool
-	Result         chan float32
-	Err            chan error
+	Inputs   []Tensor
+	Result   chan []Tensor
+	Err      chan error
+	Priority constants.PriorityClass
+	Tenant   string
+
+	ctx        context.Context // Done once the caller stopped waiting
+	enqueuedAt time.Time
+	finish     float64 // Virtual finish time in the job's class
+	seq        uint64
+}
+
+// InferOptions are the per-request settings of ModelInference.
+type InferOptions struct {
+	ScalingEnabled bool                    // Apply the replica's preprocessing and target transform
+	Priority       constants.PriorityClass // Queue class; empty is normal
+	Tenant         string                  // Client the queue is shared fairly between
 }
 
-// ModelWorker holds the ONNX Session and the job queue for a specific replica.
+// ModelWorker holds the ONNX Session, the model's input and output signature,
+// its batching and preprocessing settings and the job queue for a specific replica.
 type ModelWorker struct {
-	Session *ort.DynamicAdvancedSession
-	Queue   chan *InferenceJob
-	Quit    chan struct{}
+	Session       *ort.DynamicAdvancedSession
+	Signature     Signature
+	Batch         BatchConfig
+	Preprocessing *preprocess.Spec
+	Queue         *FairQueue
+	Quit          chan struct{}
+
+	inFlight  atomic.Int64
+	latencies *LatencyWindow
+	running   sync.WaitGroup // Worker goroutines that have not exited
 }
 
 // workerRegistry keeps track of all running model workers by ReplicaID.
@@ -34,7 +64,10 @@ var (
 
 // StartModelWorkers preloads an ONNX model into memory and spins up the
 // specified number of goroutines to perform inference sequentially pulled from a queue.
-func StartModelWorkers(replicaID string, modelPath string, instanceCount int) error {
+// With batching enabled each goroutine runs queued jobs in batches; batching
+// is turned off if the model has no dynamic batch dimension. A non-nil prep is
+// applied to requests made with scaling enabled and must fit the model.
+func StartModelWorkers(replicaID string, modelPath string, instanceCount int, batch BatchConfig, prep *preprocess.Spec) error {
 	registryMu.Lock()
 	defer registryMu.Unlock()
 
@@ -47,139 +80,309 @@ func StartModelWorkers(replicaID string, modelPath string, instanceCount int) er
 	}
 
 	// 1. Preload the Model
+	// The input and output names and shapes are read from the model itself.
+	sig, err := readSignature(modelPath)
+	if err != nil {
+		return fmt.Errorf("failed to load model %s: %w", modelPath, err)
+	}
+
 	// We create an Advanced Session because it allows dynamic creation of distinct input/output tensors
 	// per inference job natively, which prevents memory corruption across concurrent goroutines.
 	session, err := ort.NewDynamicAdvancedSession(
 		modelPath,
-		[]string{"X"},
-		[]string{"variable"},
+		specNamesOf(sig.Inputs),
+		specNamesOf(sig.Outputs),
 		nil,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to load model %s: %w", modelPath, err)
 	}
 
-	queue := make(chan *InferenceJob, 100) // 100 backlog capacity
+	if prep != nil {
+		if prep, err = bindPreprocessing(prep, sig); err != nil {
+			return fmt.Errorf("failed to load model %s: %w", modelPath, err)
+		}
+	}
+
+	if batch.Enabled() && !sig.Batchable() {
+		log.Printf("Batching disabled for replica %s: model inputs and outputs need a dynamic leading dimension", replicaID)
+		batch = BatchConfig{}
+	}
+
+	queue := NewFairQueue(CurrentQueueConfig())
 	quit := make(chan struct{})
+	worker := &ModelWorker{
+		Session:       session,
+		Signature:     sig,
+		Batch:         batch,
+		Preprocessing: prep,
+		Queue:         queue,
+		Quit:          quit,
+		latencies:     NewLatencyWindow(latencySamples, latencyMaxAge),
+	}
 
 	// 2. Start workers
 	for i := 0; i < instanceCount; i++ {
+		worker.running.Add(1)
 		go func(workerID int) {
+			defer worker.running.Done()
 			log.Printf("Started worker %d for replica %s", workerID, replicaID)
 			for {
+				// Stop before taking another job even if some are queued
 				select {
 				case <-quit:
 					log.Printf("Worker %d for replica %s shutting down", workerID, replicaID)
 					return
-				case job := <-queue:
-					prediction, err := processJob(session, job)
-					if err != nil {
-						job.Err <- err
-					} else {
-						job.Result <- prediction
-					}
+				default:
+				}
+				job, ok := queue.Pop(quit, nil)
+				if !ok {
+					log.Printf("Worker %d for replica %s shutting down", workerID, replicaID)
+					return
+				}
+				if !batch.Enabled() {
+					worker.runBatch([]*InferenceJob{job})
+					continue
+				}
+				for _, group := range groupBatch(collectBatch(job, queue, quit, batch), batch.MaxBatchSize) {
+					worker.runBatch(group)
 				}
 			}
 		}(i)
 	}
 
 	// 3. Register the worker pool
-	workerRegistry[replicaID] = &ModelWorker{
-		Session: session,
-		Queue:   queue,
-		Quit:    quit,
-	}
+	workerRegistry[replicaID] = worker
 
 	return nil
 }
 
 // StopModelWorkers stops the worker pool and unloads the model from memory.
+// Jobs the workers have taken are run to completion before the session is
+// destroyed; jobs still queued fail with an error wrapping ErrReplicaStopped.
 func StopModelWorkers(replicaID string) error {
 	registryMu.Lock()
-	defer registryMu.Unlock()
-
 	worker, exists := workerRegistry[replicaID]
 	if !exists {
+		registryMu.Unlock()
 		return fmt.Errorf("replica %s is not running", replicaID)
 	}
+	delete(workerRegistry, replicaID)
+	registryMu.Unlock()
 
-	// Signal all workers to shut down
+	// Signal all workers to shut down and wait for their runs to finish
 	close(worker.Quit)
+	worker.running.Wait()
+
+	for _, job := range worker.Queue.Close() {
+		job.Err <- fmt.Errorf("replica %s: %w", replicaID, ErrReplicaStopped)
+	}
 
 	// Clean up resources
-	err := worker.Session.Destroy()
-	delete(workerRegistry, replicaID)
+	return worker.Session.Destroy()
+}
 
-	return err
+// ModelSignature returns the inputs and outputs of the model loaded for a replica.
+func ModelSignature(replicaID string) (Signature, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	worker, exists := workerRegistry[replicaID]
+	if !exists {
+		return Signature{}, false
+	}
+	return worker.Signature, true
 }
 
 // ModelInference safely submits an inference request to the correct worker pool.
-func ModelInference(replicaID string, inputData []float32, scalingEnabled bool) (float32, error) {
+// With opts.ScalingEnabled the replica's preprocessing is applied to the inputs and
+// its target transform to the outputs. The inputs are checked against the
+// model's signature before they are queued; mismatches are reported as errors
+// wrapping ErrInvalidInput.
+//
+// ModelInference waits until ctx is done, or DefaultInferenceTimeout if ctx has
+// no deadline, and then fails with an error wrapping ctx.Err(). A job whose
+// caller stopped waiting is dropped instead of being run. A job the queue has
+// no room for fails with a *QueueFullError, which wraps ErrQueueFull. A job
+// for a replica that is not loaded, or is stopped before the job runs, fails
+// with an error wrapping ErrReplicaStopped.
+func ModelInference(ctx context.Context, replicaID string, inputs []Tensor, opts InferOptions) ([]Tensor, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultInferenceTimeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	registryMu.RLock()
 	worker, exists := workerRegistry[replicaID]
 	registryMu.RUnlock()
 
 	if !exists {
-		return 0, fmt.Errorf("model replica %s is not currently loaded", replicaID)
+		return nil, fmt.Errorf("replica %s is not loaded: %w", replicaID, ErrReplicaStopped)
+	}
+
+	prep := worker.Preprocessing
+	if !opts.ScalingEnabled {
+		prep = nil
+	}
+	if prep != nil {
+		var err error
+		if inputs, err = preprocessInputs(prep, worker.Signature, inputs); err != nil {
+			return nil, err
+		}
+	}
+
+	ordered, err := ValidateInputs(worker.Signature.Inputs, inputs)
+	if err != nil {
+		return nil, err
 	}
 
 	job := &InferenceJob{
-		InputData:      inputData,
-		ScalingEnabled: scalingEnabled,
-		Result:         make(chan float32, 1),
-		Err:            make(chan error, 1),
+		Inputs:     ordered,
+		Result:     make(chan []Tensor, 1),
+		Err:        make(chan error, 1),
+		Priority:   opts.Priority,
+		Tenant:     opts.Tenant,
+		ctx:        ctx,
+		enqueuedAt: time.Now(),
 	}
 
-	// Submit job (non-blocking if queue isn't full)
-	select {
-	case worker.Queue <- job:
-	default:
-		return 0, errors.New("inference worker queue is full")
+	// Submit job (non-blocking; fails if the queue is full or the replica stopped)
+	if err := worker.Queue.Push(job); errors.Is(err, ErrReplicaStopped) {
+		return nil, fmt.Errorf("replica %s: %w", replicaID, err)
+	} else if err != nil {
+		return nil, &QueueFullError{ReplicaID: replicaID, RetryAfter: worker.retryAfter(), Err: err}
 	}
 
 	// Wait for response
 	select {
 	case res := <-job.Result:
+		if prep != nil {
+			if err := postprocessOutputs(prep, res); err != nil {
+				return nil, err
+			}
+		}
 		return res, nil
 	case err := <-job.Err:
-		return 0, err
-	case <-time.After(5 * time.Second):
-		return 0, errors.New("timeout waiting for inference result")
+		return nil, err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for inference result: %w", ctx.Err())
 	}
 }
 
-// processJob encapsulates the actual tensor mapping and inference for a single job request.
-func processJob(session *ort.DynamicAdvancedSession, job *InferenceJob) (float32, error) {
-	data := job.InputData
-	if job.ScalingEnabled {
-		data = ScaleFeatures(data)
+// runBatch runs a group of stackable jobs as one inference and hands each job
+// its rows of the outputs. If the batch fails, for example because the model's
+// outputs do not have one row per input row, the jobs are run one by one.
+// Jobs whose caller stopped waiting are dropped first.
+func (w *ModelWorker) runBatch(jobs []*InferenceJob) {
+	if jobs = dropExpired(jobs); len(jobs) == 0 {
+		return
+	}
+	w.inFlight.Add(int64(len(jobs)))
+	defer w.inFlight.Add(-int64(len(jobs)))
+
+	if len(jobs) == 1 {
+		outputs, err := processJob(w.Session, w.Signature, jobs[0])
+		w.finish(jobs[0], outputs, err)
+		return
 	}
 
-	// Create Output Tensor
-	outputShape := ort.NewShape(1, 1)
-	outputTensor, err := ort.NewTensor(outputShape, []float32{0})
+	inputs := make([][]Tensor, len(jobs))
+	for i, job := range jobs {
+		inputs[i] = job.Inputs
+	}
+	stacked, rows, err := StackInputs(inputs)
+	var split [][]Tensor
+	if err == nil {
+		var outputs []Tensor
+		if outputs, err = runSession(w.Session, w.Signature, stacked); err == nil {
+			split, err = SplitOutputs(outputs, rows)
+		}
+	}
 	if err != nil {
-		return 0, fmt.Errorf("failed to create output tensor: %w", err)
+		log.Printf("Batch of %d jobs failed, running them one by one: %v", len(jobs), err)
+		for _, job := range jobs {
+			outputs, err := processJob(w.Session, w.Signature, job)
+			w.finish(job, outputs, err)
+		}
+		return
+	}
+	for i, job := range jobs {
+		w.finish(job, split[i], nil)
+	}
+}
+
+// dropExpired fails the jobs whose context is done and returns the others.
+func dropExpired(jobs []*InferenceJob) []*InferenceJob {
+	live := jobs[:0]
+	for _, job := range jobs {
+		if job.ctx != nil && job.ctx.Err() != nil {
+			job.Err <- fmt.Errorf("inference dropped before running: %w", job.ctx.Err())
+			continue
+		}
+		live = append(live, job)
 	}
-	defer outputTensor.Destroy()
+	return live
+}
 
-	// Create Input Tensor
-	inputShape := ort.NewShape(1, int64(len(data)))
-	inputTensor, err := ort.NewTensor(inputShape, data)
+// finish records the job's latency and passes its outputs or error back.
+func (w *ModelWorker) finish(job *InferenceJob, outputs []Tensor, err error) {
+	w.latencies.Observe(time.Since(job.enqueuedAt))
 	if err != nil {
-		return 0, fmt.Errorf("failed to create input tensor: %w", err)
+		job.Err <- err
+	} else {
+		job.Result <- outputs
 	}
-	defer inputTensor.Destroy()
+}
+
+// processJob runs the inference for a single job request.
+func processJob(session *ort.DynamicAdvancedSession, sig Signature, job *InferenceJob) ([]Tensor, error) {
+	return runSession(session, sig, job.Inputs)
+}
+
+// runSession encapsulates the actual tensor mapping and inference for a set of
+// inputs ordered like the model's inputs.
+func runSession(session *ort.DynamicAdvancedSession, sig Signature, tensors []Tensor) ([]Tensor, error) {
+	// Create Input Tensors
+	inputs := make([]ort.Value, 0, len(tensors))
+	defer func() {
+		for _, v := range inputs {
+			v.Destroy()
+		}
+	}()
+	for _, in := range tensors {
+		v, err := toOrtValue(in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create input tensor %q: %w", in.Name, err)
+		}
+		inputs = append(inputs, v)
+	}
+
+	// Output Tensors are allocated by the runtime, since their shapes may depend on the inputs
+	outputs := make([]ort.Value, len(sig.Outputs))
+	defer func() {
+		for _, v := range outputs {
+			if v != nil {
+				v.Destroy()
+			}
+		}
+	}()
 
 	// Execute Inference
-	err = session.Run([]ort.Value{inputTensor}, []ort.Value{outputTensor})
-	if err != nil {
-		return 0, fmt.Errorf("inference run failed: %w", err)
+	if err := session.Run(inputs, outputs); err != nil {
+		return nil, fmt.Errorf("inference run failed: %w", err)
 	}
 
-	scaledPrediction := outputTensor.GetData()[0]
-	if job.ScalingEnabled {
-		return scaledPrediction*yScale + yMean, nil
+	results := make([]Tensor, len(outputs))
+	for i, v := range outputs {
+		t, err := fromOrtValue(sig.Outputs[i].Name, v)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = t
 	}
-	return scaledPrediction, nil
+	return results, nil
 }
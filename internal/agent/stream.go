@@ -0,0 +1,193 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/connpool"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// streamForwarder sends forwarded requests over one StreamInfer stream per
+// peer instead of a unary call each. A stream is opened on first use and
+// reopened by the next request after it breaks. Peers that do not implement
+// StreamInfer are remembered and called with Infer.
+type streamForwarder struct {
+	mu          sync.Mutex
+	streams     map[string]*peerStream
+	unsupported map[string]bool
+	nextID      atomic.Uint64
+}
+
+func newStreamForwarder() *streamForwarder {
+	return &streamForwarder{streams: make(map[string]*peerStream), unsupported: make(map[string]bool)}
+}
+
+// peerStream is an open stream to one peer and the requests waiting for a
+// response on it.
+type peerStream struct {
+	stream  inferpb.InferAPI_StreamInferClient
+	sendMu  sync.Mutex
+	mu      sync.Mutex
+	pending map[string]chan *inferpb.InferResponse
+	done    chan struct{} // Closed once the stream broke
+	err     error         // Why the stream broke; set before done is closed
+}
+
+// supported reports whether addr may be sent requests over a stream.
+func (f *streamForwarder) supported(addr string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return !f.unsupported[addr]
+}
+
+// call sends req to the peer at addr and waits for its response. The request
+// gets a fresh request ID and what is left of the deadline of ctx as its
+// timeout. Failures of the stream are returned as gRPC status errors, with
+// codes.Unimplemented if the peer does not support streams.
+func (f *streamForwarder) call(ctx context.Context, addr string, req *inferpb.InferRequest) (*inferpb.InferResponse, error) {
+	ps, err := f.open(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	req.RequestId = strconv.FormatUint(f.nextID.Add(1), 10)
+	if deadline, ok := ctx.Deadline(); ok {
+		req.TimeoutMs = max(time.Until(deadline).Milliseconds(), 1)
+	}
+	ch := make(chan *inferpb.InferResponse, 1)
+	ps.mu.Lock()
+	ps.pending[req.RequestId] = ch
+	ps.mu.Unlock()
+	defer func() {
+		ps.mu.Lock()
+		delete(ps.pending, req.RequestId)
+		ps.mu.Unlock()
+	}()
+
+	// A failed send breaks the stream, and receive reports why.
+	ps.sendMu.Lock()
+	_ = ps.stream.Send(req)
+	ps.sendMu.Unlock()
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-ps.done:
+		return nil, ps.err
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// open returns the stream to addr, opening it if there is none.
+func (f *streamForwarder) open(addr string) (*peerStream, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if ps, ok := f.streams[addr]; ok {
+		return ps, nil
+	}
+
+	conn, release, err := connpool.Default().Get(addr)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to connect to peer %s: %v", addr, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := inferpb.NewInferAPIClient(conn).StreamInfer(ctx)
+	if err != nil {
+		cancel()
+		release()
+		return nil, err
+	}
+
+	ps := &peerStream{stream: stream, pending: make(map[string]chan *inferpb.InferResponse), done: make(chan struct{})}
+	f.streams[addr] = ps
+	go func() {
+		defer release()
+		defer cancel()
+		f.receive(addr, ps)
+	}()
+	return ps, nil
+}
+
+// receive hands the responses of a stream to their waiting requests until the
+// stream breaks, then fails the requests still waiting.
+func (f *streamForwarder) receive(addr string, ps *peerStream) {
+	for {
+		resp, err := ps.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = status.Errorf(codes.Unavailable, "stream to peer %s closed", addr)
+			}
+			f.mu.Lock()
+			delete(f.streams, addr)
+			if status.Code(err) == codes.Unimplemented {
+				f.unsupported[addr] = true
+			}
+			f.mu.Unlock()
+
+			ps.err = err
+			close(ps.done)
+			return
+		}
+
+		ps.mu.Lock()
+		ch, ok := ps.pending[resp.GetRequestId()]
+		delete(ps.pending, resp.GetRequestId())
+		ps.mu.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+}
+
+// streamError returns the error a unary Infer call would have failed with for
+// a stream response whose code is set, or nil.
+func streamError(resp *inferpb.InferResponse) error {
+	code := codes.Code(resp.GetCode())
+	if code == codes.OK {
+		return nil
+	}
+	st := status.New(code, resp.GetErrorMessage())
+	if ms := resp.GetRetryAfterMs(); ms > 0 {
+		if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(ms) * time.Millisecond)}); err == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
+}
+
+// sendInfer sends a forwarded request to the peer at addr, over a stream if
+// the forward policy asks for it and the peer supports it.
+func (a *Agent) sendInfer(ctx context.Context, client inferpb.InferAPIClient, addr string, req *inferpb.InferRequest) (*inferpb.InferResponse, error) {
+	if !a.ForwardPolicy.Streams {
+		return client.Infer(ctx, req)
+	}
+	streams := a.peerStreams()
+	if !streams.supported(addr) {
+		return client.Infer(ctx, req)
+	}
+
+	resp, err := streams.call(ctx, addr, req)
+	if status.Code(err) == codes.Unimplemented {
+		req.RequestId, req.TimeoutMs = "", 0
+		return client.Infer(ctx, req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stream to peer %s: %w", addr, err)
+	}
+	if err := streamError(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
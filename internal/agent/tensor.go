@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+)
+
+// TensorsFromProto converts the tensors of an InferRequest or InferResponse.
+// Errors wrap runway.ErrInvalidInput.
+func TensorsFromProto(tensors []*inferpb.Tensor) ([]runway.Tensor, error) {
+	result := make([]runway.Tensor, 0, len(tensors))
+	for i, pb := range tensors {
+		t := runway.Tensor{Name: pb.GetName(), Shape: pb.GetShape()}
+		switch pb.GetDtype() {
+		case inferpb.DataType_FLOAT32:
+			t.DType, t.Data = runway.DataTypeFloat32, pb.GetFloatData()
+		case inferpb.DataType_FLOAT64:
+			t.DType, t.Data = runway.DataTypeFloat64, pb.GetDoubleData()
+		case inferpb.DataType_INT32:
+			t.DType, t.Data = runway.DataTypeInt32, pb.GetInt32Data()
+		case inferpb.DataType_INT64:
+			t.DType, t.Data = runway.DataTypeInt64, pb.GetInt64Data()
+		case inferpb.DataType_UINT8:
+			data := make([]uint8, len(pb.GetInt32Data()))
+			for j, v := range pb.GetInt32Data() {
+				if v < 0 || v > 255 {
+					return nil, fmt.Errorf("%w: tensor %q: value %d out of range for uint8", runway.ErrInvalidInput, pb.GetName(), v)
+				}
+				data[j] = uint8(v)
+			}
+			t.DType, t.Data = runway.DataTypeUint8, data
+		case inferpb.DataType_BOOL:
+			t.DType, t.Data = runway.DataTypeBool, pb.GetBoolData()
+		case inferpb.DataType_STRING:
+			t.DType, t.Data = runway.DataTypeString, pb.GetStringData()
+		default:
+			return nil, fmt.Errorf("%w: tensor %d (%q) has no dtype", runway.ErrInvalidInput, i, pb.GetName())
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// TensorsToProto converts tensors for an InferRequest or InferResponse.
+func TensorsToProto(tensors []runway.Tensor) []*inferpb.Tensor {
+	result := make([]*inferpb.Tensor, 0, len(tensors))
+	for _, t := range tensors {
+		pb := &inferpb.Tensor{Name: t.Name, Shape: t.Shape}
+		switch data := t.Data.(type) {
+		case []float32:
+			pb.Dtype, pb.FloatData = inferpb.DataType_FLOAT32, data
+		case []float64:
+			pb.Dtype, pb.DoubleData = inferpb.DataType_FLOAT64, data
+		case []int32:
+			pb.Dtype, pb.Int32Data = inferpb.DataType_INT32, data
+		case []int64:
+			pb.Dtype, pb.Int64Data = inferpb.DataType_INT64, data
+		case []uint8:
+			pb.Dtype = inferpb.DataType_UINT8
+			pb.Int32Data = make([]int32, len(data))
+			for i, v := range data {
+				pb.Int32Data[i] = int32(v)
+			}
+		case []bool:
+			pb.Dtype, pb.BoolData = inferpb.DataType_BOOL, data
+		case []string:
+			pb.Dtype, pb.StringData = inferpb.DataType_STRING, data
+		}
+		result = append(result, pb)
+	}
+	return result
+}
+
+// FlatInput wraps the legacy flat input_data as a single unnamed float32
+// tensor of shape (1, N).
+func FlatInput(data []float32) []runway.Tensor {
+	return []runway.Tensor{{
+		DType: runway.DataTypeFloat32,
+		Shape: []int64{1, int64(len(data))},
+		Data:  data,
+	}}
+}
+
+// Prediction returns the first element of the first output as a float32, or
+// 0 if there is none or it is not numeric.
+func Prediction(outputs []runway.Tensor) float32 {
+	if len(outputs) == 0 || outputs[0].Len() == 0 {
+		return 0
+	}
+	switch data := outputs[0].Data.(type) {
+	case []float32:
+		return data[0]
+	case []float64:
+		return float32(data[0])
+	case []int32:
+		return float32(data[0])
+	case []int64:
+		return float32(data[0])
+	case []uint8:
+		return float32(data[0])
+	}
+	return 0
+}
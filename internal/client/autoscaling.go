@@ -0,0 +1,25 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
+)
+
+// FormatAutoscaling renders autoscaling settings as "min-max" followed by the
+// targets, e.g. "1-5 queue=4 p95=250ms". It returns "" for models that are not autoscaled.
+func FormatAutoscaling(a *modelpb.Autoscaling) string {
+	if a == nil {
+		return ""
+	}
+	parts := []string{fmt.Sprintf("%d-%d", a.MinReplicas, a.MaxReplicas)}
+	if a.TargetQueueDepth > 0 {
+		parts = append(parts, "queue="+strconv.FormatFloat(a.TargetQueueDepth, 'g', -1, 64))
+	}
+	if a.TargetP95LatencyMs > 0 {
+		parts = append(parts, "p95="+strconv.FormatFloat(a.TargetP95LatencyMs, 'g', -1, 64)+"ms")
+	}
+	return strings.Join(parts, " ")
+}
@@ -10,7 +10,6 @@ import (
 
 	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
 	discoverypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/discovery"
-	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
 	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
 	nodepb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/node"
 )
@@ -24,7 +23,6 @@ type Client struct {
 	Nodes     nodepb.NodeRegistryAPIClient
 	Deploy    deploypb.DeployAPIClient
 	Transfer  deploypb.ModelTransferServiceClient
-	Infer     inferpb.InferAPIClient
 	Discovery discoverypb.DiscoveryAPIClient
 }
 
@@ -44,7 +42,6 @@ func New(endpoint string, timeout time.Duration) (*Client, error) {
 		Nodes:     nodepb.NewNodeRegistryAPIClient(conn),
 		Deploy:    deploypb.NewDeployAPIClient(conn),
 		Transfer:  deploypb.NewModelTransferServiceClient(conn),
-		Infer:     inferpb.NewInferAPIClient(conn),
 		Discovery: discoverypb.NewDiscoveryAPIClient(conn),
 	}, nil
 }
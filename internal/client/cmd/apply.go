@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -48,16 +49,71 @@ var applyCmd = &cobra.Command{
 		for _, m := range manifest.Models {
 			ns := client.ResolveNamespace(flagNamespace, m.Namespace, manifest.Namespace, cfg.DefaultNamespace)
 
+			var affinity string
+			if m.Affinity != nil {
+				b, err := json.Marshal(m.Affinity)
+				if err != nil {
+					return fmt.Errorf("encode affinity of %s: %w", m.Name, err)
+				}
+				affinity = string(b)
+			}
+
+			tolerations := make([]*modelpb.Toleration, len(m.Tolerations))
+			for i, t := range m.Tolerations {
+				tolerations[i] = &modelpb.Toleration{Key: t.Key, Operator: t.Operator, Value: t.Value, Effect: t.Effect}
+			}
+
+			var autoscaling *modelpb.Autoscaling
+			if a := m.Autoscaling; a != nil {
+				autoscaling = &modelpb.Autoscaling{
+					MinReplicas:        int32(a.MinReplicas),
+					MaxReplicas:        int32(a.MaxReplicas),
+					TargetQueueDepth:   a.TargetQueueDepth,
+					TargetP95LatencyMs: a.TargetP95LatencyMs,
+				}
+			}
+
+			var rollout *modelpb.RolloutStrategy
+			if r := m.Rollout; r != nil {
+				rollout = &modelpb.RolloutStrategy{
+					MaxSurge:       int32(r.MaxSurge),
+					MaxUnavailable: int32(r.MaxUnavailable),
+				}
+			}
+
+			var batching *modelpb.Batching
+			if b := m.Batching; b != nil {
+				batching = &modelpb.Batching{
+					MaxBatchSize:   int32(b.MaxBatchSize),
+					MaxBatchWaitMs: int32(b.MaxBatchWaitMs),
+				}
+			}
+
+			preprocessing, err := m.Preprocessing.Encode()
+			if err != nil {
+				return fmt.Errorf("encode preprocessing of %s: %w", m.Name, err)
+			}
+
 			ctx, cancel := c.Context()
-			_, err := c.Models.RegisterModel(ctx, &modelpb.ModelInfo{
-				Name:        m.Name,
-				Namespace:   ns,
-				Version:     m.Version,
-				FilePath:    m.FilePath,
-				ModelType:   m.ModelType,
-				ModelSize:   m.ModelSize,
-				Replicas:    m.Replicas,
-				InputFormat: m.InputFormat,
+			_, err = c.Models.RegisterModel(ctx, &modelpb.ModelInfo{
+				Name:          m.Name,
+				Namespace:     ns,
+				Version:       m.Version,
+				FilePath:      m.FilePath,
+				Sha256Hash:    m.SHA256Hash,
+				ModelType:     m.ModelType,
+				ModelSize:     m.ModelSize,
+				Replicas:      m.Replicas,
+				MinAvailable:  m.MinAvailable,
+				InputFormat:   m.InputFormat,
+				NodeSelector:  m.NodeSelector,
+				Affinity:      affinity,
+				Tolerations:   tolerations,
+				Autoscaling:   autoscaling,
+				Rollout:       rollout,
+				Batching:      batching,
+				Preprocessing: preprocessing,
+				LoadBalancer:  string(m.LoadBalancer),
 			})
 			cancel()
 
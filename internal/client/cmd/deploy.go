@@ -20,6 +20,9 @@ var deployCmd = &cobra.Command{
 		modelType, _ := cmd.Flags().GetString("model-type")
 		modelSize, _ := cmd.Flags().GetInt64("model-size")
 		sha256Hash, _ := cmd.Flags().GetString("sha256")
+		maxBatchSize, _ := cmd.Flags().GetInt32("max-batch-size")
+		maxBatchWait, _ := cmd.Flags().GetInt32("max-batch-wait-ms")
+		preprocessing, _ := cmd.Flags().GetString("preprocessing")
 
 		c, err := newClient()
 		if err != nil {
@@ -31,15 +34,18 @@ var deployCmd = &cobra.Command{
 		defer cancel()
 
 		resp, err := c.Deploy.DeployModel(ctx, &deploypb.DeployModelRequest{
-			ModelId:       args[0],
-			Name:          name,
-			Version:       version,
-			FilePath:      filePath,
-			ModelType:     modelType,
-			ModelSize:     modelSize,
-			InstanceCount: instances,
-			Namespace:     resolveNS(),
-			Sha256Hash:    sha256Hash,
+			ModelId:        args[0],
+			Name:           name,
+			Version:        version,
+			FilePath:       filePath,
+			ModelType:      modelType,
+			ModelSize:      modelSize,
+			InstanceCount:  instances,
+			Namespace:      resolveNS(),
+			Sha256Hash:     sha256Hash,
+			MaxBatchSize:   maxBatchSize,
+			MaxBatchWaitMs: maxBatchWait,
+			Preprocessing:  preprocessing,
 		})
 		if err != nil {
 			exitOnErr(err)
@@ -58,4 +64,7 @@ func init() {
 	deployCmd.Flags().String("model-type", "", "Model type")
 	deployCmd.Flags().Int64("model-size", 0, "Model size in bytes")
 	deployCmd.Flags().String("sha256", "", "SHA256 hash of the model file")
+	deployCmd.Flags().Int32("max-batch-size", 0, "Rows of concurrent requests run as one inference (0 disables batching)")
+	deployCmd.Flags().Int32("max-batch-wait-ms", 5, "Milliseconds to wait for a batch to fill")
+	deployCmd.Flags().String("preprocessing", "", "Preprocessing spec as JSON (input, features, target)")
 }
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -11,27 +13,76 @@ import (
 	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 var inferCmd = &cobra.Command{
 	Use:   "infer",
-	Short: "Run inference on a model",
+	Short: "Run inference on a model or pipeline",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		modelID, _ := cmd.Flags().GetString("model-id")
+		modelName, _ := cmd.Flags().GetString("model")
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+		pipelineName, _ := cmd.Flags().GetString("pipeline")
+		cacheTTL, _ := cmd.Flags().GetDuration("cache-ttl")
 		inputStr, _ := cmd.Flags().GetString("input")
 		target, _ := cmd.Flags().GetString("target")
 		scaling, _ := cmd.Flags().GetBool("scaling")
 
+		tensorSpecs, _ := cmd.Flags().GetStringArray("tensor")
+		fieldsJSON, _ := cmd.Flags().GetString("json")
+		routingKey, _ := cmd.Flags().GetString("routing-key")
+		priority, _ := cmd.Flags().GetString("priority")
+		tenant, _ := cmd.Flags().GetString("tenant")
+
+		targets := 0
+		for _, v := range []string{modelID, modelName, pipelineID, pipelineName} {
+			if v != "" {
+				targets++
+			}
+		}
+		if targets != 1 {
+			return fmt.Errorf("exactly one of --model, --model-id, --pipeline or --pipeline-id is required")
+		}
+		if modelName != "" && target != "" {
+			return fmt.Errorf("--model cannot be combined with --target; use --model-id")
+		}
+		if pipelineName != "" && target != "" {
+			return fmt.Errorf("--pipeline cannot be combined with --target; use --pipeline-id")
+		}
+
 		inputData, err := parseFloatList(inputStr)
 		if err != nil {
 			return fmt.Errorf("invalid --input: %w", err)
 		}
+		inputs := make([]*inferpb.Tensor, 0, len(tensorSpecs))
+		for _, spec := range tensorSpecs {
+			t, err := client.ParseTensor(spec)
+			if err != nil {
+				return err
+			}
+			inputs = append(inputs, t)
+		}
+		var fields *structpb.Struct
+		if fieldsJSON != "" {
+			if fields, err = client.ParseFields(fieldsJSON); err != nil {
+				return err
+			}
+		}
+		if len(inputData) == 0 && len(inputs) == 0 && fields == nil {
+			return fmt.Errorf("one of --input, --tensor or --json is required")
+		}
 
 		req := &inferpb.InferRequest{
 			ModelId:        modelID,
+			PipelineId:     pipelineID,
 			InputData:      inputData,
+			Inputs:         inputs,
+			Fields:         fields,
 			ScalingEnabled: scaling,
-			IsForwarded:    false,
+			RoutingKey:     routingKey,
+			Priority:       priority,
+			Tenant:         tenant,
 		}
 
 		var resp *inferpb.InferResponse
@@ -46,34 +97,51 @@ var inferCmd = &cobra.Command{
 			}
 			defer conn.Close()
 
-			agentInfer := inferpb.NewInferAPIClient(conn)
-			c2, err := client.New(resolveEndpoint(), resolveTimeout())
-			if err != nil {
-				return err
-			}
-			ctx, cancel := c2.Context()
+			ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout())
 			defer cancel()
-			c2.Close()
 
-			resp, err = agentInfer.Infer(ctx, req)
+			resp, err = inferpb.NewInferAPIClient(conn).Infer(ctx, req)
 			if err != nil {
 				exitOnErr(err)
 			}
 		} else {
-			// Via control plane
+			// Straight to an agent chosen from the endpoints the control plane
+			// reports; the control plane itself does not serve inference.
 			c, err := newClient()
 			if err != nil {
 				return err
 			}
 			defer c.Close()
 
-			ctx, cancel := c.Context()
-			defer cancel()
+			path, err := client.EndpointCachePath()
+			if err != nil {
+				return err
+			}
+			router := client.NewRouter(c, client.LoadEndpointCache(path, cacheTTL), resolveTimeout())
 
-			resp, err = c.Infer.Infer(ctx, req)
+			var served client.Endpoint
+			switch {
+			case modelName != "":
+				resp, served, err = router.InferByName(resolveNS(), modelName, req)
+			case pipelineName != "":
+				resp, served, err = router.InferPipeline(resolveNS(), pipelineName, req)
+			default:
+				resp, served, err = router.InferByID(req)
+			}
 			if err != nil {
 				exitOnErr(err)
 			}
+			if flagVerbose {
+				fmt.Fprintf(os.Stderr, "sent to node %s (%s)\n", served.NodeID, served.Address())
+			}
+		}
+		if flagVerbose && resp.ServedBy != "" {
+			fmt.Fprintf(os.Stderr, "served by node %s after %d forward(s)\n", resp.ServedBy, resp.HopCount)
+		}
+		if flagVerbose {
+			for _, s := range resp.Steps {
+				fmt.Fprintf(os.Stderr, "step %s served by node %s after %d forward(s) in %.1fms\n", s.Name, s.ServedBy, s.HopCount, s.LatencyMs)
+			}
 		}
 
 		f := client.NewFormatter(resolveFormat())
@@ -86,17 +154,35 @@ var inferCmd = &cobra.Command{
 					resp.ErrorMessage,
 				}},
 			)
+			if len(resp.Outputs) == 0 {
+				return
+			}
+			fmt.Println()
+			rows := make([][]string, 0, len(resp.Outputs))
+			for _, o := range resp.Outputs {
+				rows = append(rows, []string{
+					o.Name, strings.ToLower(o.Dtype.String()), client.FormatShape(o.Shape), client.FormatTensorValues(o),
+				})
+			}
+			f.PrintTable([]string{"OUTPUT", "DTYPE", "SHAPE", "VALUES"}, rows)
 		})
 	},
 }
 
 func init() {
-	inferCmd.Flags().String("model-id", "", "Model ID to infer on (required)")
-	inferCmd.Flags().String("input", "", "Comma-separated float input data")
+	inferCmd.Flags().String("model", "", "Model name to infer on, resolved to the nodes serving it in the namespace")
+	inferCmd.Flags().String("model-id", "", "Model ID to infer on")
+	inferCmd.Flags().String("pipeline", "", "Pipeline name to run, resolved in the namespace")
+	inferCmd.Flags().String("pipeline-id", "", "Pipeline ID to run")
+	inferCmd.Flags().String("input", "", "Comma-separated float input data for single-input models")
+	inferCmd.Flags().String("json", "", `Named input fields as a JSON object matching the model's input format, e.g. '{"price":25000,"year":2019}'`)
+	inferCmd.Flags().StringArray("tensor", nil, "Named input tensor: name:dtype:shape=values, e.g. ids:int64:1x4=1,2,3,4 (repeatable)")
 	inferCmd.Flags().String("target", "", "Agent address (host:port) for direct inference")
-	inferCmd.Flags().Bool("scaling", false, "Enable auto-scaling")
-	_ = inferCmd.MarkFlagRequired("model-id")
-	_ = inferCmd.MarkFlagRequired("input")
+	inferCmd.Flags().Bool("scaling", false, "Apply the model's preprocessing to inputs and prediction")
+	inferCmd.Flags().String("priority", "", "Queue class on the replica: high, normal (default) or low")
+	inferCmd.Flags().String("tenant", "", "Client the replica's queue is shared fairly between (default: this host)")
+	inferCmd.Flags().String("routing-key", "", "Send requests with the same key to the same node, e.g. a session ID (consistent_hash models)")
+	inferCmd.Flags().Duration("cache-ttl", client.DefaultEndpointTTL, "How long resolved node addresses are reused before asking the control plane again")
 }
 
 // parseFloatList parses "1.0,2.0,3.0" into []float32.
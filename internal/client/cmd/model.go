@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -27,7 +28,20 @@ var modelRegisterCmd = &cobra.Command{
 		modelType, _ := cmd.Flags().GetString("model-type")
 		modelSize, _ := cmd.Flags().GetInt64("model-size")
 		replicas, _ := cmd.Flags().GetInt32("replicas")
+		minAvailable, _ := cmd.Flags().GetInt32("min-available")
 		inputFormat, _ := cmd.Flags().GetString("input-format")
+		sha256Hash, _ := cmd.Flags().GetString("sha256")
+		nodeSelector, _ := cmd.Flags().GetStringToString("node-selector")
+		affinity, _ := cmd.Flags().GetString("affinity")
+		preprocessing, _ := cmd.Flags().GetString("preprocessing")
+		loadBalancer, _ := cmd.Flags().GetString("load-balancer")
+		tolerations, err := tolerationsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		autoscaling := autoscalingFromFlags(cmd)
+		rollout := rolloutFromFlags(cmd)
+		batching := batchingFromFlags(cmd)
 
 		c, err := newClient()
 		if err != nil {
@@ -39,14 +53,24 @@ var modelRegisterCmd = &cobra.Command{
 		defer cancel()
 
 		resp, err := c.Models.RegisterModel(ctx, &modelpb.ModelInfo{
-			Name:        name,
-			Namespace:   resolveNS(),
-			Version:     version,
-			FilePath:    filePath,
-			ModelType:   modelType,
-			ModelSize:   modelSize,
-			Replicas:    replicas,
-			InputFormat: inputFormat,
+			Name:          name,
+			Namespace:     resolveNS(),
+			Version:       version,
+			FilePath:      filePath,
+			ModelType:     modelType,
+			ModelSize:     modelSize,
+			Replicas:      replicas,
+			MinAvailable:  minAvailable,
+			InputFormat:   inputFormat,
+			Sha256Hash:    sha256Hash,
+			NodeSelector:  nodeSelector,
+			Affinity:      affinity,
+			Tolerations:   tolerations,
+			Autoscaling:   autoscaling,
+			Rollout:       rollout,
+			Batching:      batching,
+			Preprocessing: preprocessing,
+			LoadBalancer:  loadBalancer,
 		})
 		if err != nil {
 			exitOnErr(err)
@@ -96,7 +120,20 @@ var modelUpdateCmd = &cobra.Command{
 		modelType, _ := cmd.Flags().GetString("model-type")
 		modelSize, _ := cmd.Flags().GetInt64("model-size")
 		replicas, _ := cmd.Flags().GetInt32("replicas")
+		minAvailable, _ := cmd.Flags().GetInt32("min-available")
 		inputFormat, _ := cmd.Flags().GetString("input-format")
+		sha256Hash, _ := cmd.Flags().GetString("sha256")
+		nodeSelector, _ := cmd.Flags().GetStringToString("node-selector")
+		affinity, _ := cmd.Flags().GetString("affinity")
+		preprocessing, _ := cmd.Flags().GetString("preprocessing")
+		loadBalancer, _ := cmd.Flags().GetString("load-balancer")
+		tolerations, err := tolerationsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		autoscaling := autoscalingFromFlags(cmd)
+		rollout := rolloutFromFlags(cmd)
+		batching := batchingFromFlags(cmd)
 
 		c, err := newClient()
 		if err != nil {
@@ -108,15 +145,25 @@ var modelUpdateCmd = &cobra.Command{
 		defer cancel()
 
 		resp, err := c.Models.UpdateModel(ctx, &modelpb.UpdateModelRequest{
-			Id:          args[0],
-			Name:        name,
-			Namespace:   resolveNS(),
-			Version:     version,
-			FilePath:    filePath,
-			ModelType:   modelType,
-			ModelSize:   modelSize,
-			Replicas:    replicas,
-			InputFormat: inputFormat,
+			Id:            args[0],
+			Name:          name,
+			Namespace:     resolveNS(),
+			Version:       version,
+			FilePath:      filePath,
+			ModelType:     modelType,
+			ModelSize:     modelSize,
+			Replicas:      replicas,
+			MinAvailable:  minAvailable,
+			InputFormat:   inputFormat,
+			Sha256Hash:    sha256Hash,
+			NodeSelector:  nodeSelector,
+			Affinity:      affinity,
+			Tolerations:   tolerations,
+			Autoscaling:   autoscaling,
+			Rollout:       rollout,
+			Batching:      batching,
+			Preprocessing: preprocessing,
+			LoadBalancer:  loadBalancer,
 		})
 		if err != nil {
 			exitOnErr(err)
@@ -151,11 +198,14 @@ var modelGetCmd = &cobra.Command{
 		f := client.NewFormatter(resolveFormat())
 		return f.Print(model, func() {
 			f.PrintTable(
-				[]string{"ID", "NAME", "NAMESPACE", "VERSION", "TYPE", "SIZE", "REPLICAS"},
+				[]string{"ID", "NAME", "NAMESPACE", "VERSION", "REVISION", "TYPE", "SIZE", "REPLICAS", "DESIRED", "AUTOSCALING"},
 				[][]string{{
 					model.Id, model.Name, model.Namespace, model.Version,
+					strconv.FormatInt(int64(model.Revision), 10),
 					model.ModelType, strconv.FormatInt(model.ModelSize, 10),
 					strconv.FormatInt(int64(model.Replicas), 10),
+					strconv.FormatInt(int64(model.DesiredReplicas), 10),
+					client.FormatAutoscaling(model.Autoscaling),
 				}},
 			)
 		})
@@ -200,9 +250,10 @@ var modelListCmd = &cobra.Command{
 				rows = append(rows, []string{
 					m.Id, m.Name, m.Namespace, m.Version, m.ModelType,
 					strconv.FormatInt(int64(m.Replicas), 10),
+					strconv.FormatInt(int64(m.DesiredReplicas), 10),
 				})
 			}
-			f.PrintTable([]string{"ID", "NAME", "NAMESPACE", "VERSION", "TYPE", "REPLICAS"}, rows)
+			f.PrintTable([]string{"ID", "NAME", "NAMESPACE", "VERSION", "TYPE", "REPLICAS", "DESIRED"}, rows)
 		})
 	},
 }
@@ -285,6 +336,124 @@ var modelNodesCmd = &cobra.Command{
 	},
 }
 
+// --- rollout ---
+
+var modelRolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Inspect and undo rollouts of model revisions",
+}
+
+var modelRolloutStatusCmd = &cobra.Command{
+	Use:   "status [model-id]",
+	Short: "Show how far the current revision has been rolled out",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Models.GetRolloutStatus(ctx, &modelpb.ModelID{Id: args[0]})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		state := "progressing"
+		if resp.Complete {
+			state = "complete"
+		}
+
+		f := client.NewFormatter(resolveFormat())
+		return f.Print(resp, func() {
+			f.PrintTable(
+				[]string{"MODEL ID", "REVISION", "DESIRED", "UPDATED", "UPDATED RUNNING", "OLD", "OLD RUNNING", "STATUS"},
+				[][]string{{
+					resp.ModelId,
+					strconv.FormatInt(int64(resp.Revision), 10),
+					strconv.FormatInt(int64(resp.DesiredReplicas), 10),
+					strconv.FormatInt(int64(resp.UpdatedReplicas), 10),
+					strconv.FormatInt(int64(resp.UpdatedRunning), 10),
+					strconv.FormatInt(int64(resp.OldReplicas), 10),
+					strconv.FormatInt(int64(resp.OldRunning), 10),
+					state,
+				}},
+			)
+		})
+	},
+}
+
+var modelRolloutHistoryCmd = &cobra.Command{
+	Use:   "history [model-id]",
+	Short: "List the recorded revisions of a model",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Models.GetRolloutStatus(ctx, &modelpb.ModelID{Id: args[0]})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		f := client.NewFormatter(resolveFormat())
+		return f.Print(resp.Revisions, func() {
+			rows := make([][]string, 0, len(resp.Revisions))
+			for _, r := range resp.Revisions {
+				current := ""
+				if r.Revision == resp.Revision {
+					current = "*"
+				}
+				rows = append(rows, []string{
+					strconv.FormatInt(int64(r.Revision), 10) + current,
+					r.Version, r.FilePath,
+					time.Unix(r.CreatedAt, 0).Format(time.RFC3339),
+					r.ChangeCause,
+				})
+			}
+			f.PrintTable([]string{"REVISION", "VERSION", "FILE PATH", "CREATED", "CHANGE CAUSE"}, rows)
+		})
+	},
+}
+
+var modelRolloutUndoCmd = &cobra.Command{
+	Use:   "undo [model-id]",
+	Short: "Roll a model back to an earlier revision",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		toRevision, _ := cmd.Flags().GetInt32("to-revision")
+
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Models.UndoRollout(ctx, &modelpb.UndoRolloutRequest{
+			Id:         args[0],
+			ToRevision: toRevision,
+		})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		fmt.Printf("Model rolled back: success=%v revision=%d\n", resp.Success, resp.Revision)
+		return nil
+	},
+}
+
 // --- upload ---
 
 var modelUploadCmd = &cobra.Command{
@@ -322,7 +491,17 @@ func init() {
 	modelRegisterCmd.Flags().String("model-type", "", "Model type (cnn|linear|decision_tree|llm)")
 	modelRegisterCmd.Flags().Int64("model-size", 0, "Model size in bytes")
 	modelRegisterCmd.Flags().Int32("replicas", 1, "Number of replicas")
+	modelRegisterCmd.Flags().Int32("min-available", 0, "Running replicas to keep while draining nodes")
 	modelRegisterCmd.Flags().String("input-format", "", "Input format JSON schema")
+	modelRegisterCmd.Flags().String("sha256", "", "SHA256 hash of the model file")
+	modelRegisterCmd.Flags().StringToString("node-selector", nil, "Node labels required to host a replica (key=value,...)")
+	modelRegisterCmd.Flags().String("affinity", "", "Affinity rules as JSON (node_affinity, model_affinity, model_anti_affinity)")
+	modelRegisterCmd.Flags().String("preprocessing", "", "Preprocessing spec as JSON (input, features, target)")
+	modelRegisterCmd.Flags().String("load-balancer", "", "How agents pick the peer for forwarded requests (round_robin|least_request|power_of_two|latency_ewma|consistent_hash)")
+	modelRegisterCmd.Flags().StringArray("toleration", nil, "Tolerate a node taint: key[=value][:Effect] (repeatable)")
+	addAutoscalingFlags(modelRegisterCmd)
+	addRolloutFlags(modelRegisterCmd)
+	addBatchingFlags(modelRegisterCmd)
 	_ = modelRegisterCmd.MarkFlagRequired("name")
 
 	// update flags
@@ -332,7 +511,23 @@ func init() {
 	modelUpdateCmd.Flags().String("model-type", "", "New model type")
 	modelUpdateCmd.Flags().Int64("model-size", 0, "New model size")
 	modelUpdateCmd.Flags().Int32("replicas", 0, "New replica count")
+	modelUpdateCmd.Flags().Int32("min-available", 0, "New minimum of running replicas kept while draining nodes")
 	modelUpdateCmd.Flags().String("input-format", "", "New input format")
+	modelUpdateCmd.Flags().String("sha256", "", "New SHA256 hash of the model file")
+	modelUpdateCmd.Flags().StringToString("node-selector", nil, "New node selector (key=value,...)")
+	modelUpdateCmd.Flags().String("affinity", "", "New affinity rules as JSON")
+	modelUpdateCmd.Flags().String("preprocessing", "", "New preprocessing spec as JSON")
+	modelUpdateCmd.Flags().String("load-balancer", "", "New load balancing strategy")
+	modelUpdateCmd.Flags().StringArray("toleration", nil, "New tolerations: key[=value][:Effect] (repeatable)")
+	addAutoscalingFlags(modelUpdateCmd)
+	addRolloutFlags(modelUpdateCmd)
+	addBatchingFlags(modelUpdateCmd)
+
+	// rollout flags
+	modelRolloutUndoCmd.Flags().Int32("to-revision", 0, "Revision to roll back to (default: the previous revision)")
+	modelRolloutCmd.AddCommand(modelRolloutStatusCmd)
+	modelRolloutCmd.AddCommand(modelRolloutHistoryCmd)
+	modelRolloutCmd.AddCommand(modelRolloutUndoCmd)
 
 	// upload flags
 	modelUploadCmd.Flags().String("filename", "", "Override uploaded filename")
@@ -344,5 +539,86 @@ func init() {
 	modelCmd.AddCommand(modelListCmd)
 	modelCmd.AddCommand(modelStatusCmd)
 	modelCmd.AddCommand(modelNodesCmd)
+	modelCmd.AddCommand(modelRolloutCmd)
 	modelCmd.AddCommand(modelUploadCmd)
 }
+
+// tolerationsFromFlags parses the repeatable --toleration flag.
+func tolerationsFromFlags(cmd *cobra.Command) ([]*modelpb.Toleration, error) {
+	specs, _ := cmd.Flags().GetStringArray("toleration")
+	tolerations := make([]*modelpb.Toleration, 0, len(specs))
+	for _, spec := range specs {
+		tol, err := client.ParseToleration(spec)
+		if err != nil {
+			return nil, err
+		}
+		tolerations = append(tolerations, tol)
+	}
+	return tolerations, nil
+}
+
+// addAutoscalingFlags adds the flags read by autoscalingFromFlags.
+func addAutoscalingFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32("min-replicas", 1, "Autoscaling: minimum replica count")
+	cmd.Flags().Int32("max-replicas", 0, "Autoscaling: maximum replica count (enables autoscaling)")
+	cmd.Flags().Float64("target-queue-depth", 0, "Autoscaling: target queued plus in-flight jobs per replica")
+	cmd.Flags().Float64("target-p95-latency-ms", 0, "Autoscaling: target p95 job latency in milliseconds")
+}
+
+// autoscalingFromFlags returns the autoscaling settings, or nil if
+// --max-replicas is not set.
+func autoscalingFromFlags(cmd *cobra.Command) *modelpb.Autoscaling {
+	maxReplicas, _ := cmd.Flags().GetInt32("max-replicas")
+	if maxReplicas == 0 {
+		return nil
+	}
+	minReplicas, _ := cmd.Flags().GetInt32("min-replicas")
+	targetQueueDepth, _ := cmd.Flags().GetFloat64("target-queue-depth")
+	targetLatency, _ := cmd.Flags().GetFloat64("target-p95-latency-ms")
+	return &modelpb.Autoscaling{
+		MinReplicas:        minReplicas,
+		MaxReplicas:        maxReplicas,
+		TargetQueueDepth:   targetQueueDepth,
+		TargetP95LatencyMs: targetLatency,
+	}
+}
+
+// addRolloutFlags adds the flags read by rolloutFromFlags.
+func addRolloutFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32("max-surge", 1, "Rollout: replicas allowed above the desired count while replacing replicas")
+	cmd.Flags().Int32("max-unavailable", 0, "Rollout: running replicas allowed below the desired count while replacing replicas")
+}
+
+// rolloutFromFlags returns the rollout strategy, or nil if neither
+// --max-surge nor --max-unavailable is set.
+func rolloutFromFlags(cmd *cobra.Command) *modelpb.RolloutStrategy {
+	if !cmd.Flags().Changed("max-surge") && !cmd.Flags().Changed("max-unavailable") {
+		return nil
+	}
+	maxSurge, _ := cmd.Flags().GetInt32("max-surge")
+	maxUnavailable, _ := cmd.Flags().GetInt32("max-unavailable")
+	return &modelpb.RolloutStrategy{
+		MaxSurge:       maxSurge,
+		MaxUnavailable: maxUnavailable,
+	}
+}
+
+// addBatchingFlags adds the flags read by batchingFromFlags.
+func addBatchingFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32("max-batch-size", 0, "Batching: rows of concurrent requests run as one inference (enables batching)")
+	cmd.Flags().Int32("max-batch-wait-ms", 5, "Batching: milliseconds to wait for a batch to fill")
+}
+
+// batchingFromFlags returns the batching settings, or nil if --max-batch-size
+// is not set.
+func batchingFromFlags(cmd *cobra.Command) *modelpb.Batching {
+	if !cmd.Flags().Changed("max-batch-size") {
+		return nil
+	}
+	maxBatchSize, _ := cmd.Flags().GetInt32("max-batch-size")
+	maxWait, _ := cmd.Flags().GetInt32("max-batch-wait-ms")
+	return &modelpb.Batching{
+		MaxBatchSize:   maxBatchSize,
+		MaxBatchWaitMs: maxWait,
+	}
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -39,14 +41,37 @@ var nodeGetCmd = &cobra.Command{
 		f := client.NewFormatter(resolveFormat())
 		return f.Print(node, func() {
 			f.PrintTable(
-				[]string{"NODE ID", "NAME", "IP", "PORT", "OS", "HOSTNAME"},
+				[]string{"NODE ID", "NAME", "IP", "PORT", "OS", "HOSTNAME", "SCHEDULING", "REPLICAS", "LABELS", "TAINTS"},
 				[][]string{{
 					node.NodeId, node.Name, node.Ip,
 					strconv.FormatInt(int64(node.Port), 10),
 					metaField(node, "os_type"),
 					metaField(node, "hostname"),
+					schedulingState(node),
+					strconv.FormatInt(int64(node.ReplicaCount), 10),
+					client.FormatLabels(node.Labels),
+					client.FormatTaints(node.Taints),
 				}},
 			)
+			if len(node.Peers) == 0 {
+				return
+			}
+			fmt.Println()
+			rows := make([][]string, 0, len(node.Peers))
+			for _, p := range node.Peers {
+				ejected := "-"
+				if p.EjectedUntil > 0 {
+					ejected = "until " + time.Unix(p.EjectedUntil, 0).Format(time.TimeOnly)
+				}
+				rows = append(rows, []string{
+					p.NodeId, p.Address, p.Breaker, ejected,
+					strconv.FormatInt(p.Requests, 10),
+					strconv.FormatInt(p.Failures, 10),
+					strconv.FormatInt(p.Retries, 10),
+					strconv.FormatInt(int64(p.Ejections), 10),
+				})
+			}
+			f.PrintTable([]string{"PEER", "ADDRESS", "BREAKER", "EJECTED", "REQUESTS", "FAILURES", "RETRIES", "EJECTIONS"}, rows)
 		})
 	},
 }
@@ -80,10 +105,191 @@ var nodeListCmd = &cobra.Command{
 					strconv.FormatInt(int64(n.Port), 10),
 					metaField(n, "os_type"),
 					metaField(n, "hostname"),
+					schedulingState(n),
 				})
 			}
-			f.PrintTable([]string{"NODE ID", "NAME", "IP", "PORT", "OS", "HOSTNAME"}, rows)
+			f.PrintTable([]string{"NODE ID", "NAME", "IP", "PORT", "OS", "HOSTNAME", "SCHEDULING"}, rows)
+		})
+	},
+}
+
+// --- label ---
+
+var nodeLabelCmd = &cobra.Command{
+	Use:   "label [node-id] key=value... key-...",
+	Short: "Add, update or remove node labels",
+	Long: `Set labels on a node with key=value and remove them with key-.
+
+Example:
+  edgectl node label 3f2a... zone=lab camera=true legacy-`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		set, remove, err := client.ParseLabelArgs(args[1:])
+		if err != nil {
+			return err
+		}
+
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Nodes.LabelNode(ctx, &nodepb.LabelNodeRequest{
+			NodeId: args[0],
+			Labels: set,
+			Remove: remove,
+		})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		fmt.Printf("Node labeled: success=%v\n", resp.Success)
+		return nil
+	},
+}
+
+// --- taint ---
+
+var nodeTaintCmd = &cobra.Command{
+	Use:   "taint [node-id] key[=value]:Effect... key[:Effect]-...",
+	Short: "Add or remove node taints",
+	Long: `Taint a node with key[=value]:Effect, where Effect is NoSchedule,
+PreferNoSchedule or NoExecute. Append "-" to remove a taint; "key-" removes
+every taint with that key. NoExecute evicts replicas of models that do not
+tolerate the taint.
+
+Example:
+  edgectl node taint 3f2a... power=battery:NoSchedule reserved:NoExecute-`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		add, remove, err := client.ParseTaintArgs(args[1:])
+		if err != nil {
+			return err
+		}
+
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Nodes.TaintNode(ctx, &nodepb.TaintNodeRequest{
+			NodeId: args[0],
+			Add:    add,
+			Remove: remove,
 		})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		fmt.Printf("Node tainted: success=%v\n", resp.Success)
+		return nil
+	},
+}
+
+// --- cordon / uncordon / drain ---
+
+var nodeCordonCmd = &cobra.Command{
+	Use:   "cordon [node-id]",
+	Short: "Mark a node unschedulable",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Nodes.CordonNode(ctx, &nodepb.NodeID{NodeId: args[0]})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		fmt.Printf("Node cordoned: success=%v\n", resp.Success)
+		return nil
+	},
+}
+
+var nodeUncordonCmd = &cobra.Command{
+	Use:   "uncordon [node-id]",
+	Short: "Mark a node schedulable again (also stops a drain)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Nodes.UncordonNode(ctx, &nodepb.NodeID{NodeId: args[0]})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		fmt.Printf("Node uncordoned: success=%v\n", resp.Success)
+		return nil
+	},
+}
+
+var nodeDrainCmd = &cobra.Command{
+	Use:   "drain [node-id]",
+	Short: "Cordon a node and migrate its replicas to other nodes",
+	Long: `Cordon a node and migrate its replicas to other nodes. Each replica is
+replaced on another node before it is removed from the drained node, and a
+model never drops below its min_available running replicas.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		resp, err := c.Nodes.DrainNode(ctx, &nodepb.NodeID{NodeId: args[0]})
+		cancel()
+		if err != nil {
+			exitOnErr(err)
+		}
+		fmt.Printf("Node draining: success=%v\n", resp.Success)
+		if !wait {
+			return nil
+		}
+
+		deadline := time.Now().Add(timeout)
+		for {
+			ctx, cancel := c.Context()
+			node, err := c.Nodes.GetNode(ctx, &nodepb.NodeID{NodeId: args[0]})
+			cancel()
+			if err != nil {
+				exitOnErr(err)
+			}
+			if !node.Draining {
+				fmt.Println("Node drained")
+				return nil
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("node still has %d replica(s) after %v", node.ReplicaCount, timeout)
+			}
+			fmt.Printf("  %d replica(s) left\n", node.ReplicaCount)
+			time.Sleep(2 * time.Second)
+		}
 	},
 }
 
@@ -123,6 +329,14 @@ var nodeEndpointsCmd = &cobra.Command{
 func init() {
 	nodeCmd.AddCommand(nodeGetCmd)
 	nodeCmd.AddCommand(nodeListCmd)
+	nodeCmd.AddCommand(nodeLabelCmd)
+	nodeCmd.AddCommand(nodeTaintCmd)
+	nodeCmd.AddCommand(nodeCordonCmd)
+	nodeCmd.AddCommand(nodeUncordonCmd)
+	nodeCmd.AddCommand(nodeDrainCmd)
+
+	nodeDrainCmd.Flags().Bool("wait", false, "Wait until every replica has been moved off the node")
+	nodeDrainCmd.Flags().Duration("timeout", 10*time.Minute, "How long --wait waits for the drain to finish")
 	nodeCmd.AddCommand(nodeEndpointsCmd)
 }
 
@@ -142,3 +356,15 @@ func metaField(n *nodepb.NodeInfo, field string) string {
 		return ""
 	}
 }
+
+// schedulingState describes whether new replicas may be placed on a node.
+func schedulingState(n *nodepb.NodeInfo) string {
+	switch {
+	case n.Draining:
+		return "draining"
+	case n.Unschedulable:
+		return "cordoned"
+	default:
+		return "schedulable"
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/client"
+	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
+)
+
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Manage pipelines of models",
+}
+
+// --- register ---
+
+var pipelineRegisterCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Register the pipelines of a YAML manifest",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+
+		manifest, err := client.ParsePipelineManifest(file)
+		if err != nil {
+			return err
+		}
+
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		for _, p := range manifest.Pipelines {
+			req := &modelpb.PipelineInfo{
+				Name:      p.Name,
+				Namespace: client.ResolveNamespace(flagNamespace, p.Namespace, manifest.Namespace, cfg.DefaultNamespace),
+				Outputs:   p.Outputs,
+			}
+			for _, s := range p.Steps {
+				step := &modelpb.PipelineStep{Name: s.Name, Model: s.Model, ModelId: s.ModelID}
+				for _, b := range s.Inputs {
+					step.Inputs = append(step.Inputs, &modelpb.PipelineBinding{Input: b.Input, From: b.From})
+				}
+				req.Steps = append(req.Steps, step)
+			}
+
+			ctx, cancel := c.Context()
+			registered, err := c.Models.RegisterPipeline(ctx, req)
+			cancel()
+			if err != nil {
+				exitOnErr(err)
+			}
+			fmt.Printf("Pipeline registered: %s/%s (id=%s)\n", registered.Namespace, registered.Name, registered.Id)
+		}
+		return nil
+	},
+}
+
+// --- deregister ---
+
+var pipelineDeregisterCmd = &cobra.Command{
+	Use:   "deregister [pipeline-id]",
+	Short: "Deregister a pipeline by ID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Models.DeRegisterPipeline(ctx, &modelpb.PipelineID{Id: args[0]})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		fmt.Printf("Pipeline deregistered: success=%v\n", resp.Success)
+		return nil
+	},
+}
+
+// --- get ---
+
+var pipelineGetCmd = &cobra.Command{
+	Use:   "get [pipeline-name]",
+	Short: "Show the steps of a pipeline",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		p, err := c.Models.GetPipelineByName(ctx, &modelpb.PipelineName{Name: args[0], Namespace: resolveNS()})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		f := client.NewFormatter(resolveFormat())
+		return f.Print(p, func() {
+			f.PrintTable(
+				[]string{"ID", "NAME", "NAMESPACE", "OUTPUTS"},
+				[][]string{{p.Id, p.Name, p.Namespace, formatPipelineOutputs(p.Outputs)}},
+			)
+			fmt.Println()
+			rows := make([][]string, 0, len(p.Steps))
+			for _, s := range p.Steps {
+				bindings := make([]string, 0, len(s.Inputs))
+				for _, b := range s.Inputs {
+					if b.Input == "" {
+						bindings = append(bindings, b.From)
+						continue
+					}
+					bindings = append(bindings, b.Input+"<-"+b.From)
+				}
+				inputs := strings.Join(bindings, ",")
+				if inputs == "" {
+					inputs = "(request inputs)"
+				}
+				rows = append(rows, []string{s.Name, s.Model, s.ModelId, inputs})
+			}
+			f.PrintTable([]string{"STEP", "MODEL", "MODEL ID", "INPUTS"}, rows)
+		})
+	},
+}
+
+// --- list ---
+
+var pipelineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all pipelines",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newClient()
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx, cancel := c.Context()
+		defer cancel()
+
+		resp, err := c.Models.ListPipelines(ctx, &modelpb.None{})
+		if err != nil {
+			exitOnErr(err)
+		}
+
+		ns := resolveNS()
+		filtered := resp.Pipelines
+		if flagNamespace != "" {
+			filtered = nil
+			for _, p := range resp.Pipelines {
+				if p.Namespace == ns {
+					filtered = append(filtered, p)
+				}
+			}
+		}
+
+		f := client.NewFormatter(resolveFormat())
+		return f.Print(filtered, func() {
+			rows := make([][]string, 0, len(filtered))
+			for _, p := range filtered {
+				rows = append(rows, []string{
+					p.Id, p.Name, p.Namespace,
+					strconv.Itoa(len(p.Steps)),
+					formatPipelineOutputs(p.Outputs),
+				})
+			}
+			f.PrintTable([]string{"ID", "NAME", "NAMESPACE", "STEPS", "OUTPUTS"}, rows)
+		})
+	},
+}
+
+func formatPipelineOutputs(outputs []string) string {
+	if len(outputs) == 0 {
+		return "(final steps)"
+	}
+	return strings.Join(outputs, ",")
+}
+
+func init() {
+	pipelineRegisterCmd.Flags().StringP("file", "f", "", "Path to YAML pipeline manifest (required)")
+	_ = pipelineRegisterCmd.MarkFlagRequired("file")
+
+	pipelineCmd.AddCommand(pipelineRegisterCmd)
+	pipelineCmd.AddCommand(pipelineDeregisterCmd)
+	pipelineCmd.AddCommand(pipelineGetCmd)
+	pipelineCmd.AddCommand(pipelineListCmd)
+}
@@ -52,6 +52,7 @@ func init() {
 
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(modelCmd)
+	rootCmd.AddCommand(pipelineCmd)
 	rootCmd.AddCommand(nodeCmd)
 	rootCmd.AddCommand(deployCmd)
 	rootCmd.AddCommand(inferCmd)
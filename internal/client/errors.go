@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -22,9 +23,25 @@ func FormatError(err error) string {
 	case codes.AlreadyExists:
 		return fmt.Sprintf("error: already exists — %s", st.Message())
 	case codes.InvalidArgument:
-		return fmt.Sprintf("error: invalid input — %s", st.Message())
+		msg := fmt.Sprintf("error: invalid input — %s", st.Message())
+		for _, d := range st.Details() {
+			if br, ok := d.(*errdetails.BadRequest); ok {
+				for _, v := range br.GetFieldViolations() {
+					msg += fmt.Sprintf("\n  %s: %s", v.GetField(), v.GetDescription())
+				}
+			}
+		}
+		return msg
 	case codes.DeadlineExceeded:
 		return "error: request timed out"
+	case codes.ResourceExhausted:
+		msg := fmt.Sprintf("error: overloaded — %s", st.Message())
+		for _, d := range st.Details() {
+			if ri, ok := d.(*errdetails.RetryInfo); ok && ri.GetRetryDelay() != nil {
+				msg += fmt.Sprintf("\n  retry after %s", ri.GetRetryDelay().AsDuration())
+			}
+		}
+		return msg
 	default:
 		return fmt.Sprintf("error [%s]: %s", st.Code(), st.Message())
 	}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseLabelArgs parses `edgectl node label` arguments: "key=value" sets a
+// label and "key-" removes it.
+func ParseLabelArgs(args []string) (map[string]string, []string, error) {
+	set := make(map[string]string)
+	var remove []string
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(arg, "="); ok {
+			if key == "" {
+				return nil, nil, fmt.Errorf("invalid label %q: key cannot be empty", arg)
+			}
+			set[key] = value
+			continue
+		}
+		if key, ok := strings.CutSuffix(arg, "-"); ok && key != "" {
+			remove = append(remove, key)
+			continue
+		}
+		return nil, nil, fmt.Errorf("invalid label %q (expected key=value or key-)", arg)
+	}
+	return set, remove, nil
+}
+
+// FormatLabels renders labels as a sorted, comma-separated list of key=value pairs.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
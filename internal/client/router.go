@@ -0,0 +1,313 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/balancer"
+	discoverypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/discovery"
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
+)
+
+// DefaultEndpointTTL is how long a resolved endpoint list is reused before the
+// control plane is asked again.
+const DefaultEndpointTTL = 30 * time.Second
+
+// Endpoint is the address of an agent that can serve inference.
+type Endpoint struct {
+	NodeID string `json:"node_id"`
+	IP     string `json:"ip"`
+	Port   int32  `json:"port"`
+}
+
+// Address returns the endpoint as host:port.
+func (e Endpoint) Address() string {
+	return fmt.Sprintf("%s:%d", e.IP, e.Port)
+}
+
+// Endpoints is a resolved endpoint list. ModelID is set when the list was
+// resolved from a model name, PipelineID when it was resolved from a pipeline
+// name.
+type Endpoints struct {
+	ModelID    string     `json:"model_id,omitempty"`
+	PipelineID string     `json:"pipeline_id,omitempty"`
+	Nodes      []Endpoint `json:"nodes"`
+	FetchedAt  time.Time  `json:"fetched_at"`
+}
+
+// EndpointCache keeps resolved endpoint lists in a JSON file so that
+// consecutive edgectl invocations do not each ask the control plane.
+type EndpointCache struct {
+	path    string
+	ttl     time.Duration
+	entries map[string]Endpoints
+}
+
+// EndpointCachePath returns the path of the endpoint cache under ~/.edgectl/.
+func EndpointCachePath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "endpoints.json"), nil
+}
+
+// LoadEndpointCache reads the cache at path. A missing or unreadable file
+// yields an empty cache, which is filled on first use.
+func LoadEndpointCache(path string, ttl time.Duration) *EndpointCache {
+	c := &EndpointCache{path: path, ttl: ttl, entries: make(map[string]Endpoints)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil || c.entries == nil {
+		c.entries = make(map[string]Endpoints)
+	}
+	return c
+}
+
+// Get returns the entry for key if it is younger than the TTL.
+func (c *EndpointCache) Get(key string) (Endpoints, bool) {
+	eps, ok := c.entries[key]
+	if !ok || time.Since(eps.FetchedAt) > c.ttl {
+		return Endpoints{}, false
+	}
+	return eps, true
+}
+
+// Put stores the entry for key and writes the cache to disk.
+func (c *EndpointCache) Put(key string, eps Endpoints) error {
+	c.entries[key] = eps
+	return c.save()
+}
+
+// Invalidate drops the entry for key so the next lookup resolves it again.
+func (c *EndpointCache) Invalidate(key string) error {
+	if _, ok := c.entries[key]; !ok {
+		return nil
+	}
+	delete(c.entries, key)
+	return c.save()
+}
+
+func (c *EndpointCache) save() error {
+	if c.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode endpoint cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("could not write endpoint cache: %w", err)
+	}
+	return nil
+}
+
+// Router sends inference requests straight to agents, keeping the control
+// plane out of the data path. It resolves the agents once per TTL, picks one
+// with the same load balancer the agents use and fails over to the next agent
+// when one cannot be reached.
+type Router struct {
+	// Client resolves endpoints from the control plane. It may be nil when
+	// every lookup is served from the cache.
+	Client  *Client
+	Cache   *EndpointCache
+	LB      balancer.LoadBalancer
+	Timeout time.Duration
+}
+
+// NewRouter creates a Router using the agents' weighted round-robin balancer.
+func NewRouter(c *Client, cache *EndpointCache, timeout time.Duration) *Router {
+	return &Router{Client: c, Cache: cache, LB: balancer.NewWeightedRoundRobin(), Timeout: timeout}
+}
+
+// InferByName runs req on a node serving the named model and fills in its
+// model ID. It returns the response and the endpoint that served it.
+func (r *Router) InferByName(namespace, name string, req *inferpb.InferRequest) (*inferpb.InferResponse, Endpoint, error) {
+	key := "model/" + namespace + "/" + name
+	return r.route(key, req, func(ctx context.Context) (Endpoints, error) {
+		resp, err := r.Client.Models.GetNodesByModelName(ctx, &modelpb.ModelName{Name: name, Namespace: namespace})
+		if err != nil {
+			return Endpoints{}, err
+		}
+		eps := Endpoints{ModelID: resp.ModelId}
+		for _, n := range resp.Nodes {
+			eps.Nodes = append(eps.Nodes, Endpoint{NodeID: n.NodeId, IP: n.Ip, Port: n.Port})
+		}
+		return eps, nil
+	})
+}
+
+// InferByID runs req on any online agent. Agents that do not serve the model
+// forward the request to one that does.
+func (r *Router) InferByID(req *inferpb.InferRequest) (*inferpb.InferResponse, Endpoint, error) {
+	return r.route("nodes", req, func(ctx context.Context) (Endpoints, error) {
+		resp, err := r.Client.Discovery.GetNodes(ctx, &discoverypb.GetNodesRequest{})
+		if err != nil {
+			return Endpoints{}, err
+		}
+		var eps Endpoints
+		for _, n := range resp.Nodes {
+			eps.Nodes = append(eps.Nodes, Endpoint{NodeID: n.NodeId, IP: n.Ip, Port: n.Port})
+		}
+		return eps, nil
+	})
+}
+
+// InferPipeline runs req as the named pipeline on any online agent, which runs
+// the pipeline's steps and forwards those it does not serve.
+func (r *Router) InferPipeline(namespace, name string, req *inferpb.InferRequest) (*inferpb.InferResponse, Endpoint, error) {
+	key := "pipeline/" + namespace + "/" + name
+	return r.route(key, req, func(ctx context.Context) (Endpoints, error) {
+		p, err := r.Client.Models.GetPipelineByName(ctx, &modelpb.PipelineName{Name: name, Namespace: namespace})
+		if err != nil {
+			return Endpoints{}, err
+		}
+		resp, err := r.Client.Discovery.GetNodes(ctx, &discoverypb.GetNodesRequest{})
+		if err != nil {
+			return Endpoints{}, err
+		}
+		eps := Endpoints{PipelineID: p.Id}
+		for _, n := range resp.Nodes {
+			eps.Nodes = append(eps.Nodes, Endpoint{NodeID: n.NodeId, IP: n.Ip, Port: n.Port})
+		}
+		return eps, nil
+	})
+}
+
+// route tries the endpoints of key until one answers. If every cached endpoint
+// is unreachable the list is resolved again once, skipping the nodes already
+// tried.
+func (r *Router) route(key string, req *inferpb.InferRequest, resolve func(context.Context) (Endpoints, error)) (*inferpb.InferResponse, Endpoint, error) {
+	eps, cached := r.Cache.Get(key)
+	if !cached {
+		var err error
+		if eps, err = r.resolve(key, resolve); err != nil {
+			return nil, Endpoint{}, err
+		}
+	}
+
+	tried := make(map[string]bool)
+	for {
+		resp, ep, err := r.try(eps, req, tried)
+		if err == nil || !isUnavailable(err) {
+			return resp, ep, err
+		}
+		// Every endpoint was unreachable: the list is stale.
+		_ = r.Cache.Invalidate(key)
+		if !cached || r.Client == nil {
+			return nil, Endpoint{}, err
+		}
+		cached = false
+		if eps, err = r.resolve(key, resolve); err != nil {
+			return nil, Endpoint{}, err
+		}
+	}
+}
+
+// resolve asks the control plane for the endpoints of key and caches them.
+func (r *Router) resolve(key string, resolve func(context.Context) (Endpoints, error)) (Endpoints, error) {
+	if r.Client == nil {
+		return Endpoints{}, fmt.Errorf("no cached endpoints for %s and no control plane to resolve them", key)
+	}
+	ctx, cancel := r.Client.Context()
+	defer cancel()
+
+	eps, err := resolve(ctx)
+	if err != nil {
+		return Endpoints{}, err
+	}
+	if len(eps.Nodes) == 0 {
+		return Endpoints{}, status.Error(codes.Unavailable, "no nodes are serving the model")
+	}
+	eps.FetchedAt = time.Now()
+	if err := r.Cache.Put(key, eps); err != nil {
+		return Endpoints{}, err
+	}
+	return eps, nil
+}
+
+// try sends req to the endpoints not yet in tried, picked by the load
+// balancer, until one answers or returns an error other than Unavailable.
+func (r *Router) try(eps Endpoints, req *inferpb.InferRequest, tried map[string]bool) (*inferpb.InferResponse, Endpoint, error) {
+	if eps.ModelID != "" {
+		req.ModelId = eps.ModelID
+	}
+	if eps.PipelineID != "" {
+		req.PipelineId = eps.PipelineID
+	}
+
+	// The balancer starts over in every edgectl process; shuffling spreads the
+	// first pick of consecutive invocations across the nodes.
+	candidates := make([]*heartbeatpb.EndpointDetail, 0, len(eps.Nodes))
+	byAddr := make(map[string]Endpoint, len(eps.Nodes))
+	for _, ep := range eps.Nodes {
+		addr := ep.Address()
+		byAddr[addr] = ep
+		candidates = append(candidates, &heartbeatpb.EndpointDetail{
+			NodeId: ep.NodeID, Ip: ep.IP, Port: ep.Port, Healthy: !tried[addr], Weight: 1,
+		})
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+
+	// A request with a routing key goes to the node owning the key, so that
+	// every invocation of a session reaches the same node.
+	lb := r.LB
+	if req.GetRoutingKey() != "" {
+		lb = balancer.NewConsistentHash()
+	}
+
+	var lastErr error
+	for {
+		picked, err := balancer.PickFor(lb, candidates, req.GetRoutingKey())
+		if err != nil {
+			if lastErr == nil {
+				lastErr = status.Error(codes.Unavailable, "all nodes serving the model have been tried")
+			}
+			return nil, Endpoint{}, lastErr
+		}
+		picked.Healthy = false
+		addr := fmt.Sprintf("%s:%d", picked.Ip, picked.Port)
+		tried[addr] = true
+
+		resp, err := r.send(addr, req)
+		if err == nil || !isUnavailable(err) {
+			return resp, byAddr[addr], err
+		}
+		lastErr = fmt.Errorf("node %s (%s): %w", picked.NodeId, addr, err)
+	}
+}
+
+// send runs one inference call against the agent at addr.
+func (r *Router) send(addr string, req *inferpb.InferRequest) (*inferpb.InferResponse, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+	return inferpb.NewInferAPIClient(conn).Infer(ctx, req)
+}
+
+// isUnavailable reports whether err means the agent could not be reached, in
+// which case another agent may still answer.
+func isUnavailable(err error) bool {
+	var st interface{ GRPCStatus() *status.Status }
+	return errors.As(err, &st) && st.GRPCStatus().Code() == codes.Unavailable
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
+	nodepb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/node"
+)
+
+// ParseTaintArgs parses `edgectl node taint` arguments: "key=value:Effect" or
+// "key:Effect" adds a taint, "key:Effect-" removes it and "key-" removes every
+// taint with that key.
+func ParseTaintArgs(args []string) (add, remove []*nodepb.Taint, err error) {
+	for _, arg := range args {
+		spec, isRemove := strings.CutSuffix(arg, "-")
+		key, value, effect := splitTaint(spec)
+		if key == "" {
+			return nil, nil, fmt.Errorf("invalid taint %q: key cannot be empty", arg)
+		}
+		taint := &nodepb.Taint{Key: key, Value: value, Effect: effect}
+		if isRemove {
+			remove = append(remove, taint)
+			continue
+		}
+		if effect == "" {
+			return nil, nil, fmt.Errorf("invalid taint %q (expected key[=value]:Effect)", arg)
+		}
+		add = append(add, taint)
+	}
+	return add, remove, nil
+}
+
+// ParseToleration parses a --toleration flag of the form key[=value][:Effect].
+// Without a value the toleration uses the Exists operator.
+func ParseToleration(s string) (*modelpb.Toleration, error) {
+	key, value, effect := splitTaint(s)
+	if key == "" {
+		return nil, fmt.Errorf("invalid toleration %q: key cannot be empty", s)
+	}
+	tol := &modelpb.Toleration{Key: key, Operator: "Equal", Value: value, Effect: effect}
+	if !strings.Contains(s, "=") {
+		tol.Operator = "Exists"
+	}
+	return tol, nil
+}
+
+// FormatTaints renders taints as a comma-separated list of key=value:Effect.
+func FormatTaints(taints []*nodepb.Taint) string {
+	parts := make([]string, 0, len(taints))
+	for _, t := range taints {
+		if t.Value == "" {
+			parts = append(parts, t.Key+":"+t.Effect)
+		} else {
+			parts = append(parts, t.Key+"="+t.Value+":"+t.Effect)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// splitTaint splits key[=value][:effect].
+func splitTaint(s string) (key, value, effect string) {
+	s, effect, _ = strings.Cut(s, ":")
+	key, value, _ = strings.Cut(s, "=")
+	return key, value, effect
+}
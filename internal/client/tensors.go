@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxFormattedValues is the number of tensor values FormatTensorValues prints.
+const maxFormattedValues = 8
+
+// ParseFields parses a --json flag holding a JSON object of named input
+// fields, e.g. '{"price": 25000, "year": 2019}'.
+func ParseFields(s string) (*structpb.Struct, error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(s), &fields); err != nil {
+		return nil, fmt.Errorf("invalid --json %q: expected a JSON object: %w", s, err)
+	}
+	return structpb.NewStruct(fields)
+}
+
+// ParseTensor parses a --tensor flag of the form name:dtype:shape=values,
+// e.g. "ids:int64:2x2=1,2,3,4". dtype is one of float32, float64, int32,
+// int64, uint8, bool and string; shape lists the dimensions separated by "x".
+func ParseTensor(s string) (*inferpb.Tensor, error) {
+	head, values, ok := strings.Cut(s, "=")
+	parts := strings.Split(head, ":")
+	if !ok || len(parts) != 3 || parts[0] == "" {
+		return nil, fmt.Errorf("invalid tensor %q (expected name:dtype:shape=values)", s)
+	}
+
+	t := &inferpb.Tensor{Name: parts[0]}
+	dtype, ok := inferpb.DataType_value[strings.ToUpper(parts[1])]
+	if !ok || dtype == int32(inferpb.DataType_DATA_TYPE_UNSPECIFIED) {
+		return nil, fmt.Errorf("invalid tensor %q: unknown dtype %q", s, parts[1])
+	}
+	t.Dtype = inferpb.DataType(dtype)
+
+	for _, dim := range strings.Split(parts[2], "x") {
+		n, err := strconv.ParseInt(strings.TrimSpace(dim), 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid tensor %q: bad dimension %q", s, dim)
+		}
+		t.Shape = append(t.Shape, n)
+	}
+
+	for _, v := range strings.Split(values, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" && t.Dtype != inferpb.DataType_STRING {
+			continue
+		}
+		if err := appendTensorValue(t, v); err != nil {
+			return nil, fmt.Errorf("invalid tensor %q: %w", s, err)
+		}
+	}
+	return t, nil
+}
+
+func appendTensorValue(t *inferpb.Tensor, v string) error {
+	switch t.Dtype {
+	case inferpb.DataType_FLOAT32:
+		f, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return err
+		}
+		t.FloatData = append(t.FloatData, float32(f))
+	case inferpb.DataType_FLOAT64:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		t.DoubleData = append(t.DoubleData, f)
+	case inferpb.DataType_INT32, inferpb.DataType_UINT8:
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return err
+		}
+		t.Int32Data = append(t.Int32Data, int32(n))
+	case inferpb.DataType_INT64:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		t.Int64Data = append(t.Int64Data, n)
+	case inferpb.DataType_BOOL:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		t.BoolData = append(t.BoolData, b)
+	case inferpb.DataType_STRING:
+		t.StringData = append(t.StringData, v)
+	}
+	return nil
+}
+
+// FormatShape renders a tensor shape as e.g. "1x3x224x224".
+func FormatShape(shape []int64) string {
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = strconv.FormatInt(d, 10)
+	}
+	return strings.Join(dims, "x")
+}
+
+// FormatTensorValues renders the first values of a tensor as a comma-separated
+// list, followed by "..." if there are more.
+func FormatTensorValues(t *inferpb.Tensor) string {
+	var values []string
+	switch t.Dtype {
+	case inferpb.DataType_FLOAT32:
+		for _, v := range t.FloatData {
+			values = append(values, strconv.FormatFloat(float64(v), 'g', 6, 32))
+		}
+	case inferpb.DataType_FLOAT64:
+		for _, v := range t.DoubleData {
+			values = append(values, strconv.FormatFloat(v, 'g', 6, 64))
+		}
+	case inferpb.DataType_INT32, inferpb.DataType_UINT8:
+		for _, v := range t.Int32Data {
+			values = append(values, strconv.FormatInt(int64(v), 10))
+		}
+	case inferpb.DataType_INT64:
+		for _, v := range t.Int64Data {
+			values = append(values, strconv.FormatInt(v, 10))
+		}
+	case inferpb.DataType_BOOL:
+		for _, v := range t.BoolData {
+			values = append(values, strconv.FormatBool(v))
+		}
+	case inferpb.DataType_STRING:
+		values = t.StringData
+	}
+	if len(values) > maxFormattedValues {
+		return strings.Join(values[:maxFormattedValues], ",") + ",..."
+	}
+	return strings.Join(values, ",")
+}
@@ -5,6 +5,12 @@ import (
 	"os"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/inputformat"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/pipeline"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/preprocess"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
 )
 
 // ModelManifest is the top-level structure of a YAML manifest file.
@@ -17,14 +23,36 @@ type ModelManifest struct {
 
 // ModelSpec describes a single model inside a manifest.
 type ModelSpec struct {
-	Name        string `yaml:"name" json:"name"`
-	Namespace   string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
-	Version     string `yaml:"version,omitempty" json:"version,omitempty"`
-	FilePath    string `yaml:"file_path,omitempty" json:"file_path,omitempty"`
-	ModelType   string `yaml:"model_type,omitempty" json:"model_type,omitempty"`
-	ModelSize   int64  `yaml:"model_size,omitempty" json:"model_size,omitempty"`
-	Replicas    int32  `yaml:"replicas,omitempty" json:"replicas,omitempty"`
-	InputFormat string `yaml:"input_format,omitempty" json:"input_format,omitempty"`
+	Name         string `yaml:"name" json:"name"`
+	Namespace    string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Version      string `yaml:"version,omitempty" json:"version,omitempty"`
+	FilePath     string `yaml:"file_path,omitempty" json:"file_path,omitempty"`
+	SHA256Hash   string `yaml:"sha256_hash,omitempty" json:"sha256_hash,omitempty"`
+	ModelType    string `yaml:"model_type,omitempty" json:"model_type,omitempty"`
+	ModelSize    int64  `yaml:"model_size,omitempty" json:"model_size,omitempty"`
+	Replicas     int32  `yaml:"replicas,omitempty" json:"replicas,omitempty"`
+	MinAvailable int32  `yaml:"min_available,omitempty" json:"min_available,omitempty"`
+	InputFormat  string `yaml:"input_format,omitempty" json:"input_format,omitempty"`
+
+	// NodeSelector, Affinity and Tolerations constrain which nodes may host the model's replicas.
+	NodeSelector map[string]string  `yaml:"node_selector,omitempty" json:"node_selector,omitempty"`
+	Affinity     *store.Affinity    `yaml:"affinity,omitempty" json:"affinity,omitempty"`
+	Tolerations  []store.Toleration `yaml:"tolerations,omitempty" json:"tolerations,omitempty"`
+
+	// Autoscaling lets the control plane scale the model between min and max replicas on load.
+	Autoscaling *store.Autoscaling `yaml:"autoscaling,omitempty" json:"autoscaling,omitempty"`
+
+	// Rollout limits how replicas are replaced when the model's artifact changes.
+	Rollout *store.RolloutStrategy `yaml:"rollout,omitempty" json:"rollout,omitempty"`
+
+	// Batching stacks concurrent requests into one model run on each replica.
+	Batching *store.Batching `yaml:"batching,omitempty" json:"batching,omitempty"`
+
+	// Preprocessing transforms raw features and the prediction of scaled requests.
+	Preprocessing *preprocess.Spec `yaml:"preprocessing,omitempty" json:"preprocessing,omitempty"`
+
+	// LoadBalancer is how agents pick the peer for requests forwarded to the model.
+	LoadBalancer constants.LoadBalancer `yaml:"load_balancer,omitempty" json:"load_balancer,omitempty"`
 }
 
 // ParseManifest reads a YAML manifest file and returns the parsed structure.
@@ -70,6 +98,95 @@ func validateManifest(m *ModelManifest) error {
 			return fmt.Errorf("model[%d] %q: invalid model_type %q (expected cnn|linear|decision_tree|llm)",
 				i, model.Name, model.ModelType)
 		}
+		if _, err := inputformat.Parse([]byte(model.InputFormat)); err != nil {
+			return fmt.Errorf("model[%d] %q: %w", i, model.Name, err)
+		}
+		if err := model.Affinity.Validate(); err != nil {
+			return fmt.Errorf("model[%d] %q: invalid affinity: %w", i, model.Name, err)
+		}
+		if model.MinAvailable < 0 {
+			return fmt.Errorf("model[%d] %q: min_available cannot be negative", i, model.Name)
+		}
+		for j, tol := range model.Tolerations {
+			if err := tol.Validate(); err != nil {
+				return fmt.Errorf("model[%d] %q: tolerations[%d]: %w", i, model.Name, j, err)
+			}
+		}
+		if err := model.Autoscaling.Validate(); err != nil {
+			return fmt.Errorf("model[%d] %q: %w", i, model.Name, err)
+		}
+		if err := model.Rollout.Validate(); err != nil {
+			return fmt.Errorf("model[%d] %q: %w", i, model.Name, err)
+		}
+		if err := model.Batching.Validate(); err != nil {
+			return fmt.Errorf("model[%d] %q: %w", i, model.Name, err)
+		}
+		if err := model.Preprocessing.Validate(); err != nil {
+			return fmt.Errorf("model[%d] %q: %w", i, model.Name, err)
+		}
+		if err := store.ValidateLoadBalancer(model.LoadBalancer); err != nil {
+			return fmt.Errorf("model[%d] %q: %w", i, model.Name, err)
+		}
+	}
+	return nil
+}
+
+// PipelineManifest is the top-level structure of a YAML pipeline manifest.
+type PipelineManifest struct {
+	APIVersion string         `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string         `yaml:"kind" json:"kind"`
+	Namespace  string         `yaml:"namespace" json:"namespace"`
+	Pipelines  []PipelineSpec `yaml:"pipelines" json:"pipelines"`
+}
+
+// PipelineSpec describes a single pipeline inside a manifest. Its steps name
+// models of the pipeline's namespace.
+type PipelineSpec struct {
+	Name          string `yaml:"name" json:"name"`
+	Namespace     string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	pipeline.Spec `yaml:",inline"`
+}
+
+// ParsePipelineManifest reads a YAML pipeline manifest file and returns the
+// parsed structure.
+func ParsePipelineManifest(path string) (*PipelineManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest %s: %w", path, err)
+	}
+
+	var manifest PipelineManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid YAML in %s: %w", path, err)
+	}
+
+	if err := validatePipelineManifest(&manifest); err != nil {
+		return nil, fmt.Errorf("manifest validation failed: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// validatePipelineManifest checks required fields and that every pipeline is
+// a valid DAG.
+func validatePipelineManifest(m *PipelineManifest) error {
+	if m.APIVersion != "edgernetes.ai/v1" {
+		return fmt.Errorf("unsupported apiVersion %q (expected edgernetes.ai/v1)", m.APIVersion)
+	}
+	if m.Kind != "PipelineManifest" {
+		return fmt.Errorf("unsupported kind %q (expected PipelineManifest)", m.Kind)
+	}
+	if len(m.Pipelines) == 0 {
+		return fmt.Errorf("manifest must contain at least one pipeline")
+	}
+
+	for i, p := range m.Pipelines {
+		if p.Name == "" {
+			return fmt.Errorf("pipeline[%d]: name is required", i)
+		}
+		if err := p.Spec.Validate(); err != nil {
+			return fmt.Errorf("pipeline[%d] %q: %w", i, p.Name, err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,244 @@
+// Package connpool shares long-lived gRPC client connections between calls to
+// the same peer.
+//
+// Agents forwarding inference to each other and the control plane sending
+// heartbeats and deployments to agents used to dial a new connection for every
+// call. A Pool keeps one connection per peer address instead, with keepalive
+// pings to detect dead peers, and closes connections that have not been used
+// for a while.
+package connpool
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// DefaultIdleTimeout is how long an unused connection is kept open.
+	DefaultIdleTimeout = 5 * time.Minute
+	// DefaultKeepaliveTime is the interval of keepalive pings on a connection.
+	DefaultKeepaliveTime = 30 * time.Second
+	// DefaultKeepaliveTimeout is how long a ping may go unanswered before the
+	// connection is considered broken.
+	DefaultKeepaliveTimeout = 10 * time.Second
+)
+
+// Options configures a Pool. Zero values use the defaults above.
+type Options struct {
+	IdleTimeout      time.Duration
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+}
+
+// ServerOptions returns the options servers need to accept the keepalive
+// pings sent by pooled connections. Without them a server treats pings more
+// frequent than every five minutes as abuse and closes the connection.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             DefaultKeepaliveTime / 2,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// entry is one pooled connection.
+type entry struct {
+	conn     *grpc.ClientConn
+	state    connectivity.State
+	inUse    int
+	lastUsed time.Time
+}
+
+// Pool is a set of client connections keyed by peer address. It is safe for
+// concurrent use.
+type Pool struct {
+	mu      sync.Mutex
+	conns   map[string]*entry
+	opts    Options
+	dial    []grpc.DialOption
+	stop    chan struct{}
+	stopped sync.Once
+}
+
+// New creates a Pool and starts closing idle connections in the background.
+// Call Close to stop it.
+func New(opts Options) *Pool {
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = DefaultIdleTimeout
+	}
+	if opts.KeepaliveTime <= 0 {
+		opts.KeepaliveTime = DefaultKeepaliveTime
+	}
+	if opts.KeepaliveTimeout <= 0 {
+		opts.KeepaliveTimeout = DefaultKeepaliveTimeout
+	}
+
+	p := &Pool{
+		conns: make(map[string]*entry),
+		opts:  opts,
+		dial: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithKeepaliveParams(keepalive.ClientParameters{
+				Time:                opts.KeepaliveTime,
+				Timeout:             opts.KeepaliveTimeout,
+				PermitWithoutStream: true,
+			}),
+		},
+		stop: make(chan struct{}),
+	}
+	go p.evictLoop()
+	return p
+}
+
+var (
+	defaultPool *Pool
+	defaultOnce sync.Once
+)
+
+// Default returns the process-wide Pool shared by all callers.
+func Default() *Pool {
+	defaultOnce.Do(func() {
+		defaultPool = New(Options{})
+	})
+	return defaultPool
+}
+
+// Get returns the connection to addr, dialing it on first use. The caller must
+// call release when its call is done; a connection is only closed for idleness
+// once every caller has released it.
+func (p *Pool) Get(addr string) (conn *grpc.ClientConn, release func(), err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.conns[addr]
+	if !ok {
+		c, err := grpc.NewClient(addr, p.dial...)
+		if err != nil {
+			return nil, nil, err
+		}
+		e = &entry{conn: c, state: c.GetState()}
+		p.conns[addr] = e
+		go p.watch(addr, e)
+	}
+	e.inUse++
+	e.lastUsed = time.Now()
+
+	var once sync.Once
+	return e.conn, func() {
+		once.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			e.inUse--
+			e.lastUsed = time.Now()
+		})
+	}, nil
+}
+
+// State returns the last connectivity state seen on the connection to addr.
+// ok is false if the pool holds no connection to addr.
+func (p *Pool) State(addr string) (state connectivity.State, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e, ok := p.conns[addr]
+	if !ok {
+		return 0, false
+	}
+	return e.state, true
+}
+
+// Len returns the number of open connections.
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.conns)
+}
+
+// Evict closes the connection to addr, for example after the peer left the
+// cluster. Calls still using it fail.
+func (p *Pool) Evict(addr string) {
+	p.mu.Lock()
+	e, ok := p.conns[addr]
+	delete(p.conns, addr)
+	p.mu.Unlock()
+	if ok {
+		e.conn.Close()
+	}
+}
+
+// EvictIdle closes connections that no call has used for the idle timeout and
+// returns how many were closed.
+func (p *Pool) EvictIdle() int {
+	p.mu.Lock()
+	var idle []*grpc.ClientConn
+	for addr, e := range p.conns {
+		if e.inUse == 0 && time.Since(e.lastUsed) >= p.opts.IdleTimeout {
+			idle = append(idle, e.conn)
+			delete(p.conns, addr)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		c.Close()
+	}
+	return len(idle)
+}
+
+// Close stops idle eviction and closes every connection.
+func (p *Pool) Close() {
+	p.stopped.Do(func() { close(p.stop) })
+
+	p.mu.Lock()
+	conns := p.conns
+	p.conns = make(map[string]*entry)
+	p.mu.Unlock()
+
+	for _, e := range conns {
+		e.conn.Close()
+	}
+}
+
+func (p *Pool) evictLoop() {
+	ticker := time.NewTicker(p.opts.IdleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if n := p.EvictIdle(); n > 0 {
+				log.Printf("connpool: closed %d idle connection(s)", n)
+			}
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// watch records the connectivity state of a pooled connection until it is
+// closed, logging when a peer becomes unreachable and when it recovers.
+func (p *Pool) watch(addr string, e *entry) {
+	state := e.conn.GetState()
+	for e.conn.WaitForStateChange(context.Background(), state) {
+		next := e.conn.GetState()
+		if next == connectivity.Shutdown {
+			return
+		}
+		if next == connectivity.TransientFailure {
+			log.Printf("connpool: peer %s unreachable", addr)
+		} else if state == connectivity.TransientFailure && next == connectivity.Ready {
+			log.Printf("connpool: peer %s reachable again", addr)
+		}
+
+		p.mu.Lock()
+		e.state = next
+		p.mu.Unlock()
+		state = next
+	}
+}
@@ -28,3 +28,45 @@ const (
 	ModelStatusPartialRunning ModelStatus = "partial_running"
 	ModelStatusFailed         ModelStatus = "failed"
 )
+
+// LoadBalancer is the strategy agents use to pick the peer that serves a
+// forwarded request for a model.
+type LoadBalancer string
+
+const (
+	LoadBalancerRoundRobin     LoadBalancer = "round_robin"     // Weighted by node TOPS (default)
+	LoadBalancerLeastRequest   LoadBalancer = "least_request"   // Fewest requests in flight
+	LoadBalancerPowerOfTwo     LoadBalancer = "power_of_two"    // Less loaded of two random peers
+	LoadBalancerLatencyEWMA    LoadBalancer = "latency_ewma"    // Lowest moving average latency
+	LoadBalancerConsistentHash LoadBalancer = "consistent_hash" // Same peer for the same routing key
+)
+
+// LoadBalancers lists every known load balancing strategy.
+var LoadBalancers = []LoadBalancer{
+	LoadBalancerRoundRobin,
+	LoadBalancerLeastRequest,
+	LoadBalancerPowerOfTwo,
+	LoadBalancerLatencyEWMA,
+	LoadBalancerConsistentHash,
+}
+
+// PriorityClass orders inference requests waiting in a replica's queue: queued
+// requests of a higher class always run first.
+type PriorityClass string
+
+const (
+	PriorityHigh   PriorityClass = "high"   // Latency-sensitive control traffic
+	PriorityNormal PriorityClass = "normal" // Default
+	PriorityLow    PriorityClass = "low"    // Bulk and analytics
+)
+
+// PriorityClasses lists the priority classes, highest first.
+var PriorityClasses = []PriorityClass{PriorityHigh, PriorityNormal, PriorityLow}
+
+// Replica error codes reported by agents in ModelReplicaDetails.ErrorCode.
+const (
+	ReplicaErrorNone             = 0
+	ReplicaErrorDownloadFailed   = 1 // model file could not be fetched
+	ReplicaErrorChecksumMismatch = 2 // fetched file does not match sha256_hash
+	ReplicaErrorLoadFailed       = 3 // inference runtime could not load the model
+)
@@ -35,3 +35,10 @@ const (
 	StatusOffline Status = "offline"
 	StatusError   Status = "error"
 )
+
+// Well-known node labels set by the agent at startup.
+const (
+	LabelOS       = "edgernetes.ai/os"
+	LabelArch     = "edgernetes.ai/arch"
+	LabelHostname = "edgernetes.ai/hostname"
+)
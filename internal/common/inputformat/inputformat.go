@@ -0,0 +1,255 @@
+// Package inputformat reads a model's declared input format and converts
+// named input fields into the flat feature row the model expects.
+//
+// An input format is a JSON object mapping field names to types, e.g.
+//
+//	{"name": "string", "price": "number", "year": "number"}
+//
+// A type may also be given as an object, to list the allowed values of a
+// categorical string or the length of an array:
+//
+//	{"fuel": {"type": "string", "enum": ["petrol", "diesel"]}, "pixels": {"type": "array", "length": 784}}
+//
+// Fields are laid out in the order they are declared. Numbers, integers and
+// booleans take one column, arrays one column per element and enum strings one
+// column holding the index of their value. Strings without an enum, such as
+// identifiers, are validated but not passed to the model.
+package inputformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Type is the type of an input field.
+type Type string
+
+const (
+	Number  Type = "number"
+	Integer Type = "integer"
+	Boolean Type = "boolean"
+	String  Type = "string"
+	Array   Type = "array"
+)
+
+// Field is one declared input field.
+type Field struct {
+	Name   string
+	Type   Type
+	Enum   []string // String only: allowed values, encoded as their index
+	Length int      // Array only: required number of elements, 0 for any
+}
+
+// Format is a model's input format with its fields in declaration order.
+type Format struct {
+	Fields []Field
+}
+
+// fieldSpec is the object form of a field type.
+type fieldSpec struct {
+	Type   Type     `json:"type"`
+	Enum   []string `json:"enum,omitempty"`
+	Length int      `json:"length,omitempty"`
+}
+
+// Parse reads a JSON input format, keeping the declaration order of its
+// fields. An empty format returns nil.
+func Parse(raw []byte) (*Format, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("invalid input format: expected a JSON object of field names to types")
+	}
+
+	var format Format
+	seen := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid input format: %w", err)
+		}
+		name := tok.(string)
+		if seen[name] {
+			return nil, fmt.Errorf("invalid input format: field %q declared twice", name)
+		}
+		seen[name] = true
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("invalid input format: field %q: %w", name, err)
+		}
+		var spec fieldSpec
+		if err := json.Unmarshal(value, &spec.Type); err != nil {
+			if err := json.Unmarshal(value, &spec); err != nil {
+				return nil, fmt.Errorf("invalid input format: field %q: expected a type name or an object with a type", name)
+			}
+		}
+
+		field := Field{Name: name, Type: spec.Type, Enum: spec.Enum, Length: spec.Length}
+		if err := field.validate(); err != nil {
+			return nil, fmt.Errorf("invalid input format: field %q: %w", name, err)
+		}
+		format.Fields = append(format.Fields, field)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("invalid input format: %w", err)
+	}
+	if len(format.Fields) == 0 {
+		return nil, errors.New("invalid input format: no fields declared")
+	}
+	return &format, nil
+}
+
+func (f Field) validate() error {
+	switch f.Type {
+	case Number, Integer, Boolean, String, Array:
+	default:
+		return fmt.Errorf("unknown type %q (expected number|integer|boolean|string|array)", f.Type)
+	}
+	if len(f.Enum) > 0 && f.Type != String {
+		return errors.New("enum is only allowed for strings")
+	}
+	if f.Length != 0 && f.Type != Array {
+		return errors.New("length is only allowed for arrays")
+	}
+	if f.Length < 0 {
+		return errors.New("length must not be negative")
+	}
+	return nil
+}
+
+// FieldError describes why the value of one field was rejected.
+type FieldError struct {
+	Field       string
+	Description string
+}
+
+// ValidationError lists every rejected field of a request.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.Field + ": " + f.Description
+	}
+	return "invalid fields: " + strings.Join(parts, "; ")
+}
+
+// Encode validates named field values, as decoded from JSON, and lays them out
+// as one feature row. Every declared field is required and undeclared fields
+// are rejected. All problems are reported together in a *ValidationError.
+func (f *Format) Encode(values map[string]any) ([]float32, error) {
+	var row []float32
+	var errs []FieldError
+	fail := func(name, format string, args ...any) {
+		errs = append(errs, FieldError{Field: name, Description: fmt.Sprintf(format, args...)})
+	}
+
+	for _, field := range f.Fields {
+		v, ok := values[field.Name]
+		if !ok {
+			fail(field.Name, "required %s field is missing", field.Type)
+			continue
+		}
+		switch field.Type {
+		case Number, Integer:
+			n, ok := v.(float64)
+			if !ok {
+				fail(field.Name, "expected %s, got %s", field.Type, kindOf(v))
+			} else if field.Type == Integer && n != math.Trunc(n) {
+				fail(field.Name, "expected integer, got %v", n)
+			} else {
+				row = append(row, float32(n))
+			}
+		case Boolean:
+			b, ok := v.(bool)
+			if !ok {
+				fail(field.Name, "expected boolean, got %s", kindOf(v))
+			} else if b {
+				row = append(row, 1)
+			} else {
+				row = append(row, 0)
+			}
+		case String:
+			s, ok := v.(string)
+			if !ok {
+				fail(field.Name, "expected string, got %s", kindOf(v))
+			} else if len(field.Enum) > 0 {
+				i := slices.Index(field.Enum, s)
+				if i < 0 {
+					fail(field.Name, "%q is not one of %s", s, strings.Join(field.Enum, ", "))
+				} else {
+					row = append(row, float32(i))
+				}
+			}
+		case Array:
+			items, ok := v.([]any)
+			if !ok {
+				fail(field.Name, "expected array, got %s", kindOf(v))
+				continue
+			}
+			if field.Length > 0 && len(items) != field.Length {
+				fail(field.Name, "expected %d elements, got %d", field.Length, len(items))
+				continue
+			}
+			if len(items) == 0 {
+				fail(field.Name, "array must not be empty")
+				continue
+			}
+			for i, item := range items {
+				n, ok := item.(float64)
+				if !ok {
+					fail(fmt.Sprintf("%s[%d]", field.Name, i), "expected number, got %s", kindOf(item))
+					break
+				}
+				row = append(row, float32(n))
+			}
+		}
+	}
+
+	var unknown []string
+	for name := range values {
+		if !slices.ContainsFunc(f.Fields, func(field Field) bool { return field.Name == name }) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		fail(name, "unknown field")
+	}
+
+	if len(errs) > 0 {
+		return nil, &ValidationError{Fields: errs}
+	}
+	return row, nil
+}
+
+// kindOf names the JSON kind of a decoded value for error messages.
+func kindOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
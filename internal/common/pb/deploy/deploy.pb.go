@@ -35,6 +35,26 @@ type DeployModelRequest struct {
 	InstanceCount int32  `protobuf:"varint,7,opt,name=instance_count,json=instanceCount,proto3" json:"instance_count,omitempty"`
 	Namespace     string `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	Sha256Hash    string `protobuf:"bytes,9,opt,name=sha256_hash,json=sha256Hash,proto3" json:"sha256_hash,omitempty"`
+	// replica_id is assigned by the control-plane scheduler so that heartbeat
+	// reports can be matched against the stored ReplicaInfo. When empty the
+	// agent generates its own ID (manual `edgectl deploy`).
+	ReplicaId string `protobuf:"bytes,10,opt,name=replica_id,json=replicaId,proto3" json:"replica_id,omitempty"`
+	// max_batch_size enables dynamic batching when above 1: queued requests
+	// are stacked along the model's leading dimension up to this many rows.
+	MaxBatchSize int32 `protobuf:"varint,11,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	// max_batch_wait_ms is how long a worker waits for a batch to fill.
+	MaxBatchWaitMs int32 `protobuf:"varint,12,opt,name=max_batch_wait_ms,json=maxBatchWaitMs,proto3" json:"max_batch_wait_ms,omitempty"`
+	// preprocessing is the model's JSON-encoded preprocessing spec (feature
+	// transforms and target inverse-scaling), applied to requests made with
+	// scaling enabled. Empty means inputs and outputs are passed through.
+	Preprocessing string `protobuf:"bytes,13,opt,name=preprocessing,proto3" json:"preprocessing,omitempty"`
+	// input_format is the model's JSON input format, used to convert named
+	// request fields into the model's input.
+	InputFormat string `protobuf:"bytes,14,opt,name=input_format,json=inputFormat,proto3" json:"input_format,omitempty"`
+	// revision is the model revision whose artifact this replica runs. The
+	// agent passes it on when downloading the model from the control plane.
+	// Zero means the model's current revision.
+	Revision      int32 `protobuf:"varint,15,opt,name=revision,proto3" json:"revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -132,10 +152,53 @@ func (x *DeployModelRequest) GetSha256Hash() string {
 	return ""
 }
 
+func (x *DeployModelRequest) GetReplicaId() string {
+	if x != nil {
+		return x.ReplicaId
+	}
+	return ""
+}
+
+func (x *DeployModelRequest) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *DeployModelRequest) GetMaxBatchWaitMs() int32 {
+	if x != nil {
+		return x.MaxBatchWaitMs
+	}
+	return 0
+}
+
+func (x *DeployModelRequest) GetPreprocessing() string {
+	if x != nil {
+		return x.Preprocessing
+	}
+	return ""
+}
+
+func (x *DeployModelRequest) GetInputFormat() string {
+	if x != nil {
+		return x.InputFormat
+	}
+	return ""
+}
+
+func (x *DeployModelRequest) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 type DeployModelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ReplicaId     string                 `protobuf:"bytes,3,opt,name=replica_id,json=replicaId,proto3" json:"replica_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -184,17 +247,123 @@ func (x *DeployModelResponse) GetMessage() string {
 	return ""
 }
 
+func (x *DeployModelResponse) GetReplicaId() string {
+	if x != nil {
+		return x.ReplicaId
+	}
+	return ""
+}
+
+type UndeployModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplicaId     string                 `protobuf:"bytes,1,opt,name=replica_id,json=replicaId,proto3" json:"replica_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeployModelRequest) Reset() {
+	*x = UndeployModelRequest{}
+	mi := &file_api_proto_deploy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeployModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeployModelRequest) ProtoMessage() {}
+
+func (x *UndeployModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_deploy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeployModelRequest.ProtoReflect.Descriptor instead.
+func (*UndeployModelRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_deploy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UndeployModelRequest) GetReplicaId() string {
+	if x != nil {
+		return x.ReplicaId
+	}
+	return ""
+}
+
+type UndeployModelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeployModelResponse) Reset() {
+	*x = UndeployModelResponse{}
+	mi := &file_api_proto_deploy_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeployModelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeployModelResponse) ProtoMessage() {}
+
+func (x *UndeployModelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_deploy_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeployModelResponse.ProtoReflect.Descriptor instead.
+func (*UndeployModelResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_deploy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UndeployModelResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UndeployModelResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type ModelDownloadRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	ModelId          string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
 	ResumeByteOffset int64                  `protobuf:"varint,2,opt,name=resume_byte_offset,json=resumeByteOffset,proto3" json:"resume_byte_offset,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// revision selects the artifact of an earlier model revision, e.g. for a
+	// replica restarted during a rollout. Zero means the current revision.
+	Revision      int32 `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ModelDownloadRequest) Reset() {
 	*x = ModelDownloadRequest{}
-	mi := &file_api_proto_deploy_proto_msgTypes[2]
+	mi := &file_api_proto_deploy_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -206,7 +375,7 @@ func (x *ModelDownloadRequest) String() string {
 func (*ModelDownloadRequest) ProtoMessage() {}
 
 func (x *ModelDownloadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_deploy_proto_msgTypes[2]
+	mi := &file_api_proto_deploy_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,7 +388,7 @@ func (x *ModelDownloadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModelDownloadRequest.ProtoReflect.Descriptor instead.
 func (*ModelDownloadRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_deploy_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_deploy_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ModelDownloadRequest) GetModelId() string {
@@ -236,6 +405,13 @@ func (x *ModelDownloadRequest) GetResumeByteOffset() int64 {
 	return 0
 }
 
+func (x *ModelDownloadRequest) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 type ModelChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChunkData     []byte                 `protobuf:"bytes,1,opt,name=chunk_data,json=chunkData,proto3" json:"chunk_data,omitempty"`
@@ -246,7 +422,7 @@ type ModelChunk struct {
 
 func (x *ModelChunk) Reset() {
 	*x = ModelChunk{}
-	mi := &file_api_proto_deploy_proto_msgTypes[3]
+	mi := &file_api_proto_deploy_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -258,7 +434,7 @@ func (x *ModelChunk) String() string {
 func (*ModelChunk) ProtoMessage() {}
 
 func (x *ModelChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_deploy_proto_msgTypes[3]
+	mi := &file_api_proto_deploy_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -271,7 +447,7 @@ func (x *ModelChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModelChunk.ProtoReflect.Descriptor instead.
 func (*ModelChunk) Descriptor() ([]byte, []int) {
-	return file_api_proto_deploy_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_deploy_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ModelChunk) GetChunkData() []byte {
@@ -301,7 +477,7 @@ type ModelUploadMetadata struct {
 
 func (x *ModelUploadMetadata) Reset() {
 	*x = ModelUploadMetadata{}
-	mi := &file_api_proto_deploy_proto_msgTypes[4]
+	mi := &file_api_proto_deploy_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -313,7 +489,7 @@ func (x *ModelUploadMetadata) String() string {
 func (*ModelUploadMetadata) ProtoMessage() {}
 
 func (x *ModelUploadMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_deploy_proto_msgTypes[4]
+	mi := &file_api_proto_deploy_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,7 +502,7 @@ func (x *ModelUploadMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModelUploadMetadata.ProtoReflect.Descriptor instead.
 func (*ModelUploadMetadata) Descriptor() ([]byte, []int) {
-	return file_api_proto_deploy_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_deploy_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ModelUploadMetadata) GetFilename() string {
@@ -367,7 +543,7 @@ type ModelUploadChunk struct {
 
 func (x *ModelUploadChunk) Reset() {
 	*x = ModelUploadChunk{}
-	mi := &file_api_proto_deploy_proto_msgTypes[5]
+	mi := &file_api_proto_deploy_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -379,7 +555,7 @@ func (x *ModelUploadChunk) String() string {
 func (*ModelUploadChunk) ProtoMessage() {}
 
 func (x *ModelUploadChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_deploy_proto_msgTypes[5]
+	mi := &file_api_proto_deploy_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -392,7 +568,7 @@ func (x *ModelUploadChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModelUploadChunk.ProtoReflect.Descriptor instead.
 func (*ModelUploadChunk) Descriptor() ([]byte, []int) {
-	return file_api_proto_deploy_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_deploy_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ModelUploadChunk) GetContent() isModelUploadChunk_Content {
@@ -454,7 +630,7 @@ type ModelUploadResponse struct {
 
 func (x *ModelUploadResponse) Reset() {
 	*x = ModelUploadResponse{}
-	mi := &file_api_proto_deploy_proto_msgTypes[6]
+	mi := &file_api_proto_deploy_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -466,7 +642,7 @@ func (x *ModelUploadResponse) String() string {
 func (*ModelUploadResponse) ProtoMessage() {}
 
 func (x *ModelUploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_deploy_proto_msgTypes[6]
+	mi := &file_api_proto_deploy_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -479,7 +655,7 @@ func (x *ModelUploadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModelUploadResponse.ProtoReflect.Descriptor instead.
 func (*ModelUploadResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_deploy_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_deploy_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ModelUploadResponse) GetSuccess() bool {
@@ -507,7 +683,7 @@ var File_api_proto_deploy_proto protoreflect.FileDescriptor
 
 const file_api_proto_deploy_proto_rawDesc = "" +
 	"\n" +
-	"\x16api/proto/deploy.proto\x12\tdeployAPI\"\x9e\x02\n" +
+	"\x16api/proto/deploy.proto\x12\tdeployAPI\"\xf3\x03\n" +
 	"\x12DeployModelRequest\x12\x19\n" +
 	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
@@ -520,13 +696,30 @@ const file_api_proto_deploy_proto_rawDesc = "" +
 	"\x0einstance_count\x18\a \x01(\x05R\rinstanceCount\x12\x1c\n" +
 	"\tnamespace\x18\b \x01(\tR\tnamespace\x12\x1f\n" +
 	"\vsha256_hash\x18\t \x01(\tR\n" +
-	"sha256Hash\"I\n" +
+	"sha256Hash\x12\x1d\n" +
+	"\n" +
+	"replica_id\x18\n" +
+	" \x01(\tR\treplicaId\x12$\n" +
+	"\x0emax_batch_size\x18\v \x01(\x05R\fmaxBatchSize\x12)\n" +
+	"\x11max_batch_wait_ms\x18\f \x01(\x05R\x0emaxBatchWaitMs\x12$\n" +
+	"\rpreprocessing\x18\r \x01(\tR\rpreprocessing\x12!\n" +
+	"\finput_format\x18\x0e \x01(\tR\vinputFormat\x12\x1a\n" +
+	"\brevision\x18\x0f \x01(\x05R\brevision\"h\n" +
 	"\x13DeployModelResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"_\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"replica_id\x18\x03 \x01(\tR\treplicaId\"5\n" +
+	"\x14UndeployModelRequest\x12\x1d\n" +
+	"\n" +
+	"replica_id\x18\x01 \x01(\tR\treplicaId\"K\n" +
+	"\x15UndeployModelResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"{\n" +
 	"\x14ModelDownloadRequest\x12\x19\n" +
 	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12,\n" +
-	"\x12resume_byte_offset\x18\x02 \x01(\x03R\x10resumeByteOffset\"N\n" +
+	"\x12resume_byte_offset\x18\x02 \x01(\x03R\x10resumeByteOffset\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\x05R\brevision\"N\n" +
 	"\n" +
 	"ModelChunk\x12\x1d\n" +
 	"\n" +
@@ -547,9 +740,10 @@ const file_api_proto_deploy_proto_rawDesc = "" +
 	"\x13ModelUploadResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
 	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage2Y\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage2\xad\x01\n" +
 	"\tDeployAPI\x12L\n" +
-	"\vDeployModel\x12\x1d.deployAPI.DeployModelRequest\x1a\x1e.deployAPI.DeployModelResponse2\xaf\x01\n" +
+	"\vDeployModel\x12\x1d.deployAPI.DeployModelRequest\x1a\x1e.deployAPI.DeployModelResponse\x12R\n" +
+	"\rUndeployModel\x12\x1f.deployAPI.UndeployModelRequest\x1a .deployAPI.UndeployModelResponse2\xaf\x01\n" +
 	"\x14ModelTransferService\x12I\n" +
 	"\rDownloadModel\x12\x1f.deployAPI.ModelDownloadRequest\x1a\x15.deployAPI.ModelChunk0\x01\x12L\n" +
 	"\vUploadModel\x12\x1b.deployAPI.ModelUploadChunk\x1a\x1e.deployAPI.ModelUploadResponse(\x01B$Z\"internal/common/pb/deploy;deploypbb\x06proto3"
@@ -566,26 +760,30 @@ func file_api_proto_deploy_proto_rawDescGZIP() []byte {
 	return file_api_proto_deploy_proto_rawDescData
 }
 
-var file_api_proto_deploy_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_deploy_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_api_proto_deploy_proto_goTypes = []any{
-	(*DeployModelRequest)(nil),   // 0: deployAPI.DeployModelRequest
-	(*DeployModelResponse)(nil),  // 1: deployAPI.DeployModelResponse
-	(*ModelDownloadRequest)(nil), // 2: deployAPI.ModelDownloadRequest
-	(*ModelChunk)(nil),           // 3: deployAPI.ModelChunk
-	(*ModelUploadMetadata)(nil),  // 4: deployAPI.ModelUploadMetadata
-	(*ModelUploadChunk)(nil),     // 5: deployAPI.ModelUploadChunk
-	(*ModelUploadResponse)(nil),  // 6: deployAPI.ModelUploadResponse
+	(*DeployModelRequest)(nil),    // 0: deployAPI.DeployModelRequest
+	(*DeployModelResponse)(nil),   // 1: deployAPI.DeployModelResponse
+	(*UndeployModelRequest)(nil),  // 2: deployAPI.UndeployModelRequest
+	(*UndeployModelResponse)(nil), // 3: deployAPI.UndeployModelResponse
+	(*ModelDownloadRequest)(nil),  // 4: deployAPI.ModelDownloadRequest
+	(*ModelChunk)(nil),            // 5: deployAPI.ModelChunk
+	(*ModelUploadMetadata)(nil),   // 6: deployAPI.ModelUploadMetadata
+	(*ModelUploadChunk)(nil),      // 7: deployAPI.ModelUploadChunk
+	(*ModelUploadResponse)(nil),   // 8: deployAPI.ModelUploadResponse
 }
 var file_api_proto_deploy_proto_depIdxs = []int32{
-	4, // 0: deployAPI.ModelUploadChunk.metadata:type_name -> deployAPI.ModelUploadMetadata
+	6, // 0: deployAPI.ModelUploadChunk.metadata:type_name -> deployAPI.ModelUploadMetadata
 	0, // 1: deployAPI.DeployAPI.DeployModel:input_type -> deployAPI.DeployModelRequest
-	2, // 2: deployAPI.ModelTransferService.DownloadModel:input_type -> deployAPI.ModelDownloadRequest
-	5, // 3: deployAPI.ModelTransferService.UploadModel:input_type -> deployAPI.ModelUploadChunk
-	1, // 4: deployAPI.DeployAPI.DeployModel:output_type -> deployAPI.DeployModelResponse
-	3, // 5: deployAPI.ModelTransferService.DownloadModel:output_type -> deployAPI.ModelChunk
-	6, // 6: deployAPI.ModelTransferService.UploadModel:output_type -> deployAPI.ModelUploadResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
+	2, // 2: deployAPI.DeployAPI.UndeployModel:input_type -> deployAPI.UndeployModelRequest
+	4, // 3: deployAPI.ModelTransferService.DownloadModel:input_type -> deployAPI.ModelDownloadRequest
+	7, // 4: deployAPI.ModelTransferService.UploadModel:input_type -> deployAPI.ModelUploadChunk
+	1, // 5: deployAPI.DeployAPI.DeployModel:output_type -> deployAPI.DeployModelResponse
+	3, // 6: deployAPI.DeployAPI.UndeployModel:output_type -> deployAPI.UndeployModelResponse
+	5, // 7: deployAPI.ModelTransferService.DownloadModel:output_type -> deployAPI.ModelChunk
+	8, // 8: deployAPI.ModelTransferService.UploadModel:output_type -> deployAPI.ModelUploadResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
 	1, // [1:1] is the sub-list for extension extendee
 	0, // [0:1] is the sub-list for field type_name
@@ -596,7 +794,7 @@ func file_api_proto_deploy_proto_init() {
 	if File_api_proto_deploy_proto != nil {
 		return
 	}
-	file_api_proto_deploy_proto_msgTypes[5].OneofWrappers = []any{
+	file_api_proto_deploy_proto_msgTypes[7].OneofWrappers = []any{
 		(*ModelUploadChunk_Metadata)(nil),
 		(*ModelUploadChunk_ChunkData)(nil),
 	}
@@ -606,7 +804,7 @@ func file_api_proto_deploy_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_deploy_proto_rawDesc), len(file_api_proto_deploy_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
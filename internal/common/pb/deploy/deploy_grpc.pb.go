@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DeployAPI_DeployModel_FullMethodName = "/deployAPI.DeployAPI/DeployModel"
+	DeployAPI_DeployModel_FullMethodName   = "/deployAPI.DeployAPI/DeployModel"
+	DeployAPI_UndeployModel_FullMethodName = "/deployAPI.DeployAPI/UndeployModel"
 )
 
 // DeployAPIClient is the client API for DeployAPI service.
@@ -27,6 +28,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DeployAPIClient interface {
 	DeployModel(ctx context.Context, in *DeployModelRequest, opts ...grpc.CallOption) (*DeployModelResponse, error)
+	// UndeployModel stops a replica's workers, releases its inference session
+	// and removes the cached model file once no other replica uses it.
+	UndeployModel(ctx context.Context, in *UndeployModelRequest, opts ...grpc.CallOption) (*UndeployModelResponse, error)
 }
 
 type deployAPIClient struct {
@@ -47,11 +51,24 @@ func (c *deployAPIClient) DeployModel(ctx context.Context, in *DeployModelReques
 	return out, nil
 }
 
+func (c *deployAPIClient) UndeployModel(ctx context.Context, in *UndeployModelRequest, opts ...grpc.CallOption) (*UndeployModelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndeployModelResponse)
+	err := c.cc.Invoke(ctx, DeployAPI_UndeployModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeployAPIServer is the server API for DeployAPI service.
 // All implementations must embed UnimplementedDeployAPIServer
 // for forward compatibility.
 type DeployAPIServer interface {
 	DeployModel(context.Context, *DeployModelRequest) (*DeployModelResponse, error)
+	// UndeployModel stops a replica's workers, releases its inference session
+	// and removes the cached model file once no other replica uses it.
+	UndeployModel(context.Context, *UndeployModelRequest) (*UndeployModelResponse, error)
 	mustEmbedUnimplementedDeployAPIServer()
 }
 
@@ -65,6 +82,9 @@ type UnimplementedDeployAPIServer struct{}
 func (UnimplementedDeployAPIServer) DeployModel(context.Context, *DeployModelRequest) (*DeployModelResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeployModel not implemented")
 }
+func (UnimplementedDeployAPIServer) UndeployModel(context.Context, *UndeployModelRequest) (*UndeployModelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UndeployModel not implemented")
+}
 func (UnimplementedDeployAPIServer) mustEmbedUnimplementedDeployAPIServer() {}
 func (UnimplementedDeployAPIServer) testEmbeddedByValue()                   {}
 
@@ -104,6 +124,24 @@ func _DeployAPI_DeployModel_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeployAPI_UndeployModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeployModelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeployAPIServer).UndeployModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeployAPI_UndeployModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeployAPIServer).UndeployModel(ctx, req.(*UndeployModelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DeployAPI_ServiceDesc is the grpc.ServiceDesc for DeployAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +153,10 @@ var DeployAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeployModel",
 			Handler:    _DeployAPI_DeployModel_Handler,
 		},
+		{
+			MethodName: "UndeployModel",
+			Handler:    _DeployAPI_UndeployModel_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/deploy.proto",
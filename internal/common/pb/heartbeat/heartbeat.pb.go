@@ -25,8 +25,11 @@ type RequestHeartbeatRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	NodeID           string                 `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
 	ServiceEndpoints []*ServiceEndpoints    `protobuf:"bytes,2,rep,name=service_endpoints,json=serviceEndpoints,proto3" json:"service_endpoints,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// pipelines are every registered pipeline. They replace the ones the agent
+	// knows.
+	Pipelines     []*PipelineDefinition `protobuf:"bytes,3,rep,name=pipelines,proto3" json:"pipelines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RequestHeartbeatRequest) Reset() {
@@ -73,17 +76,97 @@ func (x *RequestHeartbeatRequest) GetServiceEndpoints() []*ServiceEndpoints {
 	return nil
 }
 
-type ServiceEndpoints struct {
+func (x *RequestHeartbeatRequest) GetPipelines() []*PipelineDefinition {
+	if x != nil {
+		return x.Pipelines
+	}
+	return nil
+}
+
+// PipelineDefinition is a registered pipeline. spec is the JSON pipeline
+// spec: its steps, with their model IDs resolved, and its outputs.
+type PipelineDefinition struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
-	Endpoints     []*EndpointDetail      `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Spec          string                 `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PipelineDefinition) Reset() {
+	*x = PipelineDefinition{}
+	mi := &file_api_proto_heartbeat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PipelineDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PipelineDefinition) ProtoMessage() {}
+
+func (x *PipelineDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_heartbeat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PipelineDefinition.ProtoReflect.Descriptor instead.
+func (*PipelineDefinition) Descriptor() ([]byte, []int) {
+	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PipelineDefinition) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PipelineDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PipelineDefinition) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PipelineDefinition) GetSpec() string {
+	if x != nil {
+		return x.Spec
+	}
+	return ""
+}
+
+type ServiceEndpoints struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ModelId   string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	Endpoints []*EndpointDetail      `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// load_balancer is the strategy agents pick endpoints of the model with,
+	// e.g. "least_request". Empty means weighted round robin.
+	LoadBalancer  string `protobuf:"bytes,3,opt,name=load_balancer,json=loadBalancer,proto3" json:"load_balancer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ServiceEndpoints) Reset() {
 	*x = ServiceEndpoints{}
-	mi := &file_api_proto_heartbeat_proto_msgTypes[1]
+	mi := &file_api_proto_heartbeat_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -95,7 +178,7 @@ func (x *ServiceEndpoints) String() string {
 func (*ServiceEndpoints) ProtoMessage() {}
 
 func (x *ServiceEndpoints) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_heartbeat_proto_msgTypes[1]
+	mi := &file_api_proto_heartbeat_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -108,7 +191,7 @@ func (x *ServiceEndpoints) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceEndpoints.ProtoReflect.Descriptor instead.
 func (*ServiceEndpoints) Descriptor() ([]byte, []int) {
-	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ServiceEndpoints) GetModelId() string {
@@ -125,6 +208,13 @@ func (x *ServiceEndpoints) GetEndpoints() []*EndpointDetail {
 	return nil
 }
 
+func (x *ServiceEndpoints) GetLoadBalancer() string {
+	if x != nil {
+		return x.LoadBalancer
+	}
+	return ""
+}
+
 type EndpointDetail struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
@@ -139,7 +229,7 @@ type EndpointDetail struct {
 
 func (x *EndpointDetail) Reset() {
 	*x = EndpointDetail{}
-	mi := &file_api_proto_heartbeat_proto_msgTypes[2]
+	mi := &file_api_proto_heartbeat_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -151,7 +241,7 @@ func (x *EndpointDetail) String() string {
 func (*EndpointDetail) ProtoMessage() {}
 
 func (x *EndpointDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_heartbeat_proto_msgTypes[2]
+	mi := &file_api_proto_heartbeat_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -164,7 +254,7 @@ func (x *EndpointDetail) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EndpointDetail.ProtoReflect.Descriptor instead.
 func (*EndpointDetail) Descriptor() ([]byte, []int) {
-	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *EndpointDetail) GetNodeId() string {
@@ -222,13 +312,22 @@ type ModelReplicaDetails struct {
 	ErrorCode     int32                  `protobuf:"varint,9,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
 	ErrorMessage  string                 `protobuf:"bytes,10,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	InstanceCount int32                  `protobuf:"varint,11,opt,name=instance_count,json=instanceCount,proto3" json:"instance_count,omitempty"`
+	// Load of the replica's worker pool, used by the control-plane autoscaler.
+	QueueDepth   int32   `protobuf:"varint,12,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	InFlight     int32   `protobuf:"varint,13,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	P95LatencyMs float64 `protobuf:"fixed64,14,opt,name=p95_latency_ms,json=p95LatencyMs,proto3" json:"p95_latency_ms,omitempty"`
+	// queue_classes is the queue of every priority class, highest first.
+	QueueClasses []*QueueClassStats `protobuf:"bytes,15,rep,name=queue_classes,json=queueClasses,proto3" json:"queue_classes,omitempty"`
+	// recent_jobs is the number of jobs finished in the last minute, which
+	// p95_latency_ms is taken over. 0 means the replica has been idle.
+	RecentJobs    int32 `protobuf:"varint,16,opt,name=recent_jobs,json=recentJobs,proto3" json:"recent_jobs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ModelReplicaDetails) Reset() {
 	*x = ModelReplicaDetails{}
-	mi := &file_api_proto_heartbeat_proto_msgTypes[3]
+	mi := &file_api_proto_heartbeat_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -240,7 +339,7 @@ func (x *ModelReplicaDetails) String() string {
 func (*ModelReplicaDetails) ProtoMessage() {}
 
 func (x *ModelReplicaDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_heartbeat_proto_msgTypes[3]
+	mi := &file_api_proto_heartbeat_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -253,7 +352,7 @@ func (x *ModelReplicaDetails) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModelReplicaDetails.ProtoReflect.Descriptor instead.
 func (*ModelReplicaDetails) Descriptor() ([]byte, []int) {
-	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ModelReplicaDetails) GetReplicaId() string {
@@ -333,18 +432,246 @@ func (x *ModelReplicaDetails) GetInstanceCount() int32 {
 	return 0
 }
 
+func (x *ModelReplicaDetails) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *ModelReplicaDetails) GetInFlight() int32 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *ModelReplicaDetails) GetP95LatencyMs() float64 {
+	if x != nil {
+		return x.P95LatencyMs
+	}
+	return 0
+}
+
+func (x *ModelReplicaDetails) GetQueueClasses() []*QueueClassStats {
+	if x != nil {
+		return x.QueueClasses
+	}
+	return nil
+}
+
+func (x *ModelReplicaDetails) GetRecentJobs() int32 {
+	if x != nil {
+		return x.RecentJobs
+	}
+	return 0
+}
+
+// QueueClassStats reports one priority class of a replica's queue.
+type QueueClassStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// class is the priority class: high, normal or low.
+	Class      string `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	QueueDepth int32  `protobuf:"varint,2,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	// enqueued and rejected count jobs since the replica started.
+	Enqueued int64 `protobuf:"varint,3,opt,name=enqueued,proto3" json:"enqueued,omitempty"`
+	Rejected int64 `protobuf:"varint,4,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	// p95_wait_ms is the 95th percentile of the time recent jobs waited.
+	P95WaitMs     float64 `protobuf:"fixed64,5,opt,name=p95_wait_ms,json=p95WaitMs,proto3" json:"p95_wait_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueClassStats) Reset() {
+	*x = QueueClassStats{}
+	mi := &file_api_proto_heartbeat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueClassStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueClassStats) ProtoMessage() {}
+
+func (x *QueueClassStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_heartbeat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueClassStats.ProtoReflect.Descriptor instead.
+func (*QueueClassStats) Descriptor() ([]byte, []int) {
+	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueueClassStats) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *QueueClassStats) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *QueueClassStats) GetEnqueued() int64 {
+	if x != nil {
+		return x.Enqueued
+	}
+	return 0
+}
+
+func (x *QueueClassStats) GetRejected() int64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *QueueClassStats) GetP95WaitMs() float64 {
+	if x != nil {
+		return x.P95WaitMs
+	}
+	return 0
+}
+
+// PeerStats reports how inference forwarded to one peer agent has fared.
+type PeerStats struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	NodeId  string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Address string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// breaker is the state of the peer's circuit breaker: closed, open or half-open.
+	Breaker             string `protobuf:"bytes,3,opt,name=breaker,proto3" json:"breaker,omitempty"`
+	ConsecutiveFailures int32  `protobuf:"varint,4,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// ejected_until is a Unix timestamp in seconds, 0 unless the peer is ejected.
+	EjectedUntil  int64 `protobuf:"varint,5,opt,name=ejected_until,json=ejectedUntil,proto3" json:"ejected_until,omitempty"`
+	Ejections     int32 `protobuf:"varint,6,opt,name=ejections,proto3" json:"ejections,omitempty"`
+	Requests      int64 `protobuf:"varint,7,opt,name=requests,proto3" json:"requests,omitempty"`
+	Failures      int64 `protobuf:"varint,8,opt,name=failures,proto3" json:"failures,omitempty"`
+	Retries       int64 `protobuf:"varint,9,opt,name=retries,proto3" json:"retries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerStats) Reset() {
+	*x = PeerStats{}
+	mi := &file_api_proto_heartbeat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStats) ProtoMessage() {}
+
+func (x *PeerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_heartbeat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStats.ProtoReflect.Descriptor instead.
+func (*PeerStats) Descriptor() ([]byte, []int) {
+	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PeerStats) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PeerStats) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerStats) GetBreaker() string {
+	if x != nil {
+		return x.Breaker
+	}
+	return ""
+}
+
+func (x *PeerStats) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *PeerStats) GetEjectedUntil() int64 {
+	if x != nil {
+		return x.EjectedUntil
+	}
+	return 0
+}
+
+func (x *PeerStats) GetEjections() int32 {
+	if x != nil {
+		return x.Ejections
+	}
+	return 0
+}
+
+func (x *PeerStats) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *PeerStats) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *PeerStats) GetRetries() int64 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
 type RequestHeartbeatResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NodeID        string                 `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
 	ModelReplicas []*ModelReplicaDetails `protobuf:"bytes,2,rep,name=ModelReplicas,proto3" json:"ModelReplicas,omitempty"`
 	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	// peers is the health of forwarding to every peer the agent forwarded to.
+	Peers         []*PeerStats `protobuf:"bytes,4,rep,name=peers,proto3" json:"peers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RequestHeartbeatResponse) Reset() {
 	*x = RequestHeartbeatResponse{}
-	mi := &file_api_proto_heartbeat_proto_msgTypes[4]
+	mi := &file_api_proto_heartbeat_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -356,7 +683,7 @@ func (x *RequestHeartbeatResponse) String() string {
 func (*RequestHeartbeatResponse) ProtoMessage() {}
 
 func (x *RequestHeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_heartbeat_proto_msgTypes[4]
+	mi := &file_api_proto_heartbeat_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +696,7 @@ func (x *RequestHeartbeatResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestHeartbeatResponse.ProtoReflect.Descriptor instead.
 func (*RequestHeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_heartbeat_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RequestHeartbeatResponse) GetNodeID() string {
@@ -393,17 +720,31 @@ func (x *RequestHeartbeatResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *RequestHeartbeatResponse) GetPeers() []*PeerStats {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
 var File_api_proto_heartbeat_proto protoreflect.FileDescriptor
 
 const file_api_proto_heartbeat_proto_rawDesc = "" +
 	"\n" +
-	"\x19api/proto/heartbeat.proto\x12\fheartbeatAPI\"~\n" +
+	"\x19api/proto/heartbeat.proto\x12\fheartbeatAPI\"\xbe\x01\n" +
 	"\x17RequestHeartbeatRequest\x12\x16\n" +
 	"\x06nodeID\x18\x01 \x01(\tR\x06nodeID\x12K\n" +
-	"\x11service_endpoints\x18\x02 \x03(\v2\x1e.heartbeatAPI.ServiceEndpointsR\x10serviceEndpoints\"i\n" +
+	"\x11service_endpoints\x18\x02 \x03(\v2\x1e.heartbeatAPI.ServiceEndpointsR\x10serviceEndpoints\x12>\n" +
+	"\tpipelines\x18\x03 \x03(\v2 .heartbeatAPI.PipelineDefinitionR\tpipelines\"j\n" +
+	"\x12PipelineDefinition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04spec\x18\x04 \x01(\tR\x04spec\"\x8e\x01\n" +
 	"\x10ServiceEndpoints\x12\x19\n" +
 	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12:\n" +
-	"\tendpoints\x18\x02 \x03(\v2\x1c.heartbeatAPI.EndpointDetailR\tendpoints\"\x9e\x01\n" +
+	"\tendpoints\x18\x02 \x03(\v2\x1c.heartbeatAPI.EndpointDetailR\tendpoints\x12#\n" +
+	"\rload_balancer\x18\x03 \x01(\tR\floadBalancer\"\x9e\x01\n" +
 	"\x0eEndpointDetail\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1d\n" +
 	"\n" +
@@ -411,7 +752,7 @@ const file_api_proto_heartbeat_proto_rawDesc = "" +
 	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x12\n" +
 	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x18\n" +
 	"\ahealthy\x18\x05 \x01(\bR\ahealthy\x12\x16\n" +
-	"\x06weight\x18\x06 \x01(\x01R\x06weight\"\xdb\x02\n" +
+	"\x06weight\x18\x06 \x01(\x01R\x06weight\"\xa4\x04\n" +
 	"\x13ModelReplicaDetails\x12\x1d\n" +
 	"\n" +
 	"replica_id\x18\x01 \x01(\tR\treplicaId\x12\x19\n" +
@@ -428,11 +769,36 @@ const file_api_proto_heartbeat_proto_rawDesc = "" +
 	"error_code\x18\t \x01(\x05R\terrorCode\x12#\n" +
 	"\rerror_message\x18\n" +
 	" \x01(\tR\ferrorMessage\x12%\n" +
-	"\x0einstance_count\x18\v \x01(\x05R\rinstanceCount\"\x95\x01\n" +
+	"\x0einstance_count\x18\v \x01(\x05R\rinstanceCount\x12\x1f\n" +
+	"\vqueue_depth\x18\f \x01(\x05R\n" +
+	"queueDepth\x12\x1b\n" +
+	"\tin_flight\x18\r \x01(\x05R\binFlight\x12$\n" +
+	"\x0ep95_latency_ms\x18\x0e \x01(\x01R\fp95LatencyMs\x12B\n" +
+	"\rqueue_classes\x18\x0f \x03(\v2\x1d.heartbeatAPI.QueueClassStatsR\fqueueClasses\x12\x1f\n" +
+	"\vrecent_jobs\x18\x10 \x01(\x05R\n" +
+	"recentJobs\"\xa0\x01\n" +
+	"\x0fQueueClassStats\x12\x14\n" +
+	"\x05class\x18\x01 \x01(\tR\x05class\x12\x1f\n" +
+	"\vqueue_depth\x18\x02 \x01(\x05R\n" +
+	"queueDepth\x12\x1a\n" +
+	"\benqueued\x18\x03 \x01(\x03R\benqueued\x12\x1a\n" +
+	"\brejected\x18\x04 \x01(\x03R\brejected\x12\x1e\n" +
+	"\vp95_wait_ms\x18\x05 \x01(\x01R\tp95WaitMs\"\xa0\x02\n" +
+	"\tPeerStats\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x18\n" +
+	"\abreaker\x18\x03 \x01(\tR\abreaker\x121\n" +
+	"\x14consecutive_failures\x18\x04 \x01(\x05R\x13consecutiveFailures\x12#\n" +
+	"\rejected_until\x18\x05 \x01(\x03R\fejectedUntil\x12\x1c\n" +
+	"\tejections\x18\x06 \x01(\x05R\tejections\x12\x1a\n" +
+	"\brequests\x18\a \x01(\x03R\brequests\x12\x1a\n" +
+	"\bfailures\x18\b \x01(\x03R\bfailures\x12\x18\n" +
+	"\aretries\x18\t \x01(\x03R\aretries\"\xc4\x01\n" +
 	"\x18RequestHeartbeatResponse\x12\x16\n" +
 	"\x06nodeID\x18\x01 \x01(\tR\x06nodeID\x12G\n" +
 	"\rModelReplicas\x18\x02 \x03(\v2!.heartbeatAPI.ModelReplicaDetailsR\rModelReplicas\x12\x18\n" +
-	"\asuccess\x18\x03 \x01(\bR\asuccess2q\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12-\n" +
+	"\x05peers\x18\x04 \x03(\v2\x17.heartbeatAPI.PeerStatsR\x05peers2q\n" +
 	"\fHeartbeatAPI\x12a\n" +
 	"\x10RequestHeartbeat\x12%.heartbeatAPI.RequestHeartbeatRequest\x1a&.heartbeatAPI.RequestHeartbeatResponseB*Z(internal/common/pb/heartbeat;heartbeatpbb\x06proto3"
 
@@ -448,25 +814,31 @@ func file_api_proto_heartbeat_proto_rawDescGZIP() []byte {
 	return file_api_proto_heartbeat_proto_rawDescData
 }
 
-var file_api_proto_heartbeat_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_proto_heartbeat_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_api_proto_heartbeat_proto_goTypes = []any{
 	(*RequestHeartbeatRequest)(nil),  // 0: heartbeatAPI.RequestHeartbeatRequest
-	(*ServiceEndpoints)(nil),         // 1: heartbeatAPI.ServiceEndpoints
-	(*EndpointDetail)(nil),           // 2: heartbeatAPI.EndpointDetail
-	(*ModelReplicaDetails)(nil),      // 3: heartbeatAPI.ModelReplicaDetails
-	(*RequestHeartbeatResponse)(nil), // 4: heartbeatAPI.RequestHeartbeatResponse
+	(*PipelineDefinition)(nil),       // 1: heartbeatAPI.PipelineDefinition
+	(*ServiceEndpoints)(nil),         // 2: heartbeatAPI.ServiceEndpoints
+	(*EndpointDetail)(nil),           // 3: heartbeatAPI.EndpointDetail
+	(*ModelReplicaDetails)(nil),      // 4: heartbeatAPI.ModelReplicaDetails
+	(*QueueClassStats)(nil),          // 5: heartbeatAPI.QueueClassStats
+	(*PeerStats)(nil),                // 6: heartbeatAPI.PeerStats
+	(*RequestHeartbeatResponse)(nil), // 7: heartbeatAPI.RequestHeartbeatResponse
 }
 var file_api_proto_heartbeat_proto_depIdxs = []int32{
-	1, // 0: heartbeatAPI.RequestHeartbeatRequest.service_endpoints:type_name -> heartbeatAPI.ServiceEndpoints
-	2, // 1: heartbeatAPI.ServiceEndpoints.endpoints:type_name -> heartbeatAPI.EndpointDetail
-	3, // 2: heartbeatAPI.RequestHeartbeatResponse.ModelReplicas:type_name -> heartbeatAPI.ModelReplicaDetails
-	0, // 3: heartbeatAPI.HeartbeatAPI.RequestHeartbeat:input_type -> heartbeatAPI.RequestHeartbeatRequest
-	4, // 4: heartbeatAPI.HeartbeatAPI.RequestHeartbeat:output_type -> heartbeatAPI.RequestHeartbeatResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2, // 0: heartbeatAPI.RequestHeartbeatRequest.service_endpoints:type_name -> heartbeatAPI.ServiceEndpoints
+	1, // 1: heartbeatAPI.RequestHeartbeatRequest.pipelines:type_name -> heartbeatAPI.PipelineDefinition
+	3, // 2: heartbeatAPI.ServiceEndpoints.endpoints:type_name -> heartbeatAPI.EndpointDetail
+	5, // 3: heartbeatAPI.ModelReplicaDetails.queue_classes:type_name -> heartbeatAPI.QueueClassStats
+	4, // 4: heartbeatAPI.RequestHeartbeatResponse.ModelReplicas:type_name -> heartbeatAPI.ModelReplicaDetails
+	6, // 5: heartbeatAPI.RequestHeartbeatResponse.peers:type_name -> heartbeatAPI.PeerStats
+	0, // 6: heartbeatAPI.HeartbeatAPI.RequestHeartbeat:input_type -> heartbeatAPI.RequestHeartbeatRequest
+	7, // 7: heartbeatAPI.HeartbeatAPI.RequestHeartbeat:output_type -> heartbeatAPI.RequestHeartbeatResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_heartbeat_proto_init() }
@@ -480,7 +852,7 @@ func file_api_proto_heartbeat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_heartbeat_proto_rawDesc), len(file_api_proto_heartbeat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
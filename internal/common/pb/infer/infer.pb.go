@@ -9,6 +9,7 @@ package inferpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,19 +22,236 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DataType is the element type of a tensor.
+type DataType int32
+
+const (
+	DataType_DATA_TYPE_UNSPECIFIED DataType = 0
+	DataType_FLOAT32               DataType = 1
+	DataType_FLOAT64               DataType = 2
+	DataType_INT32                 DataType = 3
+	DataType_INT64                 DataType = 4
+	DataType_UINT8                 DataType = 5
+	DataType_BOOL                  DataType = 6
+	DataType_STRING                DataType = 7
+)
+
+// Enum value maps for DataType.
+var (
+	DataType_name = map[int32]string{
+		0: "DATA_TYPE_UNSPECIFIED",
+		1: "FLOAT32",
+		2: "FLOAT64",
+		3: "INT32",
+		4: "INT64",
+		5: "UINT8",
+		6: "BOOL",
+		7: "STRING",
+	}
+	DataType_value = map[string]int32{
+		"DATA_TYPE_UNSPECIFIED": 0,
+		"FLOAT32":               1,
+		"FLOAT64":               2,
+		"INT32":                 3,
+		"INT64":                 4,
+		"UINT8":                 5,
+		"BOOL":                  6,
+		"STRING":                7,
+	}
+)
+
+func (x DataType) Enum() *DataType {
+	p := new(DataType)
+	*p = x
+	return p
+}
+
+func (x DataType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_infer_proto_enumTypes[0].Descriptor()
+}
+
+func (DataType) Type() protoreflect.EnumType {
+	return &file_api_proto_infer_proto_enumTypes[0]
+}
+
+func (x DataType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataType.Descriptor instead.
+func (DataType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_infer_proto_rawDescGZIP(), []int{0}
+}
+
+// Tensor is a named n-dimensional array. Elements are stored in row-major
+// order in the data field matching dtype: float_data for FLOAT32,
+// double_data for FLOAT64, int32_data for INT32 and UINT8, int64_data for
+// INT64, bool_data for BOOL and string_data for STRING.
+type Tensor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dtype         DataType               `protobuf:"varint,2,opt,name=dtype,proto3,enum=inferAPI.DataType" json:"dtype,omitempty"`
+	Shape         []int64                `protobuf:"varint,3,rep,packed,name=shape,proto3" json:"shape,omitempty"`
+	FloatData     []float32              `protobuf:"fixed32,4,rep,packed,name=float_data,json=floatData,proto3" json:"float_data,omitempty"`
+	DoubleData    []float64              `protobuf:"fixed64,5,rep,packed,name=double_data,json=doubleData,proto3" json:"double_data,omitempty"`
+	Int32Data     []int32                `protobuf:"varint,6,rep,packed,name=int32_data,json=int32Data,proto3" json:"int32_data,omitempty"`
+	Int64Data     []int64                `protobuf:"varint,7,rep,packed,name=int64_data,json=int64Data,proto3" json:"int64_data,omitempty"`
+	BoolData      []bool                 `protobuf:"varint,8,rep,packed,name=bool_data,json=boolData,proto3" json:"bool_data,omitempty"`
+	StringData    []string               `protobuf:"bytes,9,rep,name=string_data,json=stringData,proto3" json:"string_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tensor) Reset() {
+	*x = Tensor{}
+	mi := &file_api_proto_infer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tensor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tensor) ProtoMessage() {}
+
+func (x *Tensor) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_infer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tensor.ProtoReflect.Descriptor instead.
+func (*Tensor) Descriptor() ([]byte, []int) {
+	return file_api_proto_infer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tensor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tensor) GetDtype() DataType {
+	if x != nil {
+		return x.Dtype
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *Tensor) GetShape() []int64 {
+	if x != nil {
+		return x.Shape
+	}
+	return nil
+}
+
+func (x *Tensor) GetFloatData() []float32 {
+	if x != nil {
+		return x.FloatData
+	}
+	return nil
+}
+
+func (x *Tensor) GetDoubleData() []float64 {
+	if x != nil {
+		return x.DoubleData
+	}
+	return nil
+}
+
+func (x *Tensor) GetInt32Data() []int32 {
+	if x != nil {
+		return x.Int32Data
+	}
+	return nil
+}
+
+func (x *Tensor) GetInt64Data() []int64 {
+	if x != nil {
+		return x.Int64Data
+	}
+	return nil
+}
+
+func (x *Tensor) GetBoolData() []bool {
+	if x != nil {
+		return x.BoolData
+	}
+	return nil
+}
+
+func (x *Tensor) GetStringData() []string {
+	if x != nil {
+		return x.StringData
+	}
+	return nil
+}
+
 type InferRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ModelId        string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
-	InputData      []float32              `protobuf:"fixed32,2,rep,packed,name=input_data,json=inputData,proto3" json:"input_data,omitempty"`
-	ScalingEnabled bool                   `protobuf:"varint,3,opt,name=scaling_enabled,json=scalingEnabled,proto3" json:"scaling_enabled,omitempty"`
-	IsForwarded    bool                   `protobuf:"varint,4,opt,name=is_forwarded,json=isForwarded,proto3" json:"is_forwarded,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ModelId string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	// input_data is a single float input of shape (1, N) for models with one
+	// input. Ignored when inputs is set.
+	InputData []float32 `protobuf:"fixed32,2,rep,packed,name=input_data,json=inputData,proto3" json:"input_data,omitempty"`
+	// scaling_enabled applies the replica's preprocessing spec to the inputs
+	// and its target transform to the first output.
+	ScalingEnabled bool `protobuf:"varint,3,opt,name=scaling_enabled,json=scalingEnabled,proto3" json:"scaling_enabled,omitempty"`
+	// is_forwarded is set on requests forwarded by an agent. Agents read
+	// hop_count instead; it is kept for agents that predate it.
+	IsForwarded bool `protobuf:"varint,4,opt,name=is_forwarded,json=isForwarded,proto3" json:"is_forwarded,omitempty"`
+	// inputs are the model's input tensors. An unnamed input is bound to the
+	// model's only input.
+	Inputs []*Tensor `protobuf:"bytes,5,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// fields are named input values, e.g. {"price": 25000, "year": 2019}. They
+	// are validated against the model's input_format and laid out in its field
+	// order as a single float tensor of shape (1, N). Ignored when inputs is set.
+	Fields *structpb.Struct `protobuf:"bytes,6,opt,name=fields,proto3" json:"fields,omitempty"`
+	// routing_key sends requests with the same key to the same replica when the
+	// model uses the consistent_hash load balancer, e.g. a session ID.
+	RoutingKey string `protobuf:"bytes,7,opt,name=routing_key,json=routingKey,proto3" json:"routing_key,omitempty"`
+	// priority is the class the request waits in on the replica: high, normal
+	// (default) or low. Queued requests of a higher class always run first.
+	Priority string `protobuf:"bytes,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	// tenant is the client the replica's queue is shared fairly between.
+	// Defaults to the caller's address.
+	Tenant string `protobuf:"bytes,9,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// hop_count is how many times agents have forwarded the request. Clients
+	// leave it 0.
+	HopCount int32 `protobuf:"varint,10,opt,name=hop_count,json=hopCount,proto3" json:"hop_count,omitempty"`
+	// max_hops is how many times the request may be forwarded. The entry agent
+	// sets it from its forward policy unless the client did.
+	MaxHops int32 `protobuf:"varint,11,opt,name=max_hops,json=maxHops,proto3" json:"max_hops,omitempty"`
+	// visited lists the nodes that forwarded the request. It is not forwarded
+	// to them again.
+	Visited []string `protobuf:"bytes,12,rep,name=visited,proto3" json:"visited,omitempty"`
+	// request_id identifies a request on a StreamInfer stream.
+	RequestId string `protobuf:"bytes,13,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// timeout_ms bounds a request on a StreamInfer stream, which has no
+	// per-request deadline. Unary calls use the deadline of the call.
+	TimeoutMs int64 `protobuf:"varint,14,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// pipeline_id runs the pipeline instead of a single model; model_id is
+	// ignored. The receiving agent runs every step, forwarding those whose
+	// model it does not serve.
+	PipelineId    string `protobuf:"bytes,15,opt,name=pipeline_id,json=pipelineId,proto3" json:"pipeline_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InferRequest) Reset() {
 	*x = InferRequest{}
-	mi := &file_api_proto_infer_proto_msgTypes[0]
+	mi := &file_api_proto_infer_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -45,7 +263,7 @@ func (x *InferRequest) String() string {
 func (*InferRequest) ProtoMessage() {}
 
 func (x *InferRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_infer_proto_msgTypes[0]
+	mi := &file_api_proto_infer_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +276,7 @@ func (x *InferRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InferRequest.ProtoReflect.Descriptor instead.
 func (*InferRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_infer_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_infer_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *InferRequest) GetModelId() string {
@@ -89,18 +307,113 @@ func (x *InferRequest) GetIsForwarded() bool {
 	return false
 }
 
+func (x *InferRequest) GetInputs() []*Tensor {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *InferRequest) GetFields() *structpb.Struct {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *InferRequest) GetRoutingKey() string {
+	if x != nil {
+		return x.RoutingKey
+	}
+	return ""
+}
+
+func (x *InferRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *InferRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *InferRequest) GetHopCount() int32 {
+	if x != nil {
+		return x.HopCount
+	}
+	return 0
+}
+
+func (x *InferRequest) GetMaxHops() int32 {
+	if x != nil {
+		return x.MaxHops
+	}
+	return 0
+}
+
+func (x *InferRequest) GetVisited() []string {
+	if x != nil {
+		return x.Visited
+	}
+	return nil
+}
+
+func (x *InferRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *InferRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *InferRequest) GetPipelineId() string {
+	if x != nil {
+		return x.PipelineId
+	}
+	return ""
+}
+
 type InferResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Prediction    float32                `protobuf:"fixed32,2,opt,name=prediction,proto3" json:"prediction,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// prediction is the first element of the first output if it is numeric.
+	Prediction   float32   `protobuf:"fixed32,2,opt,name=prediction,proto3" json:"prediction,omitempty"`
+	ErrorMessage string    `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Outputs      []*Tensor `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// served_by is the node whose replica ran the model.
+	ServedBy string `protobuf:"bytes,5,opt,name=served_by,json=servedBy,proto3" json:"served_by,omitempty"`
+	// hop_count is how many times the request was forwarded to reach it.
+	HopCount int32 `protobuf:"varint,6,opt,name=hop_count,json=hopCount,proto3" json:"hop_count,omitempty"`
+	// request_id echoes the request_id of a StreamInfer request.
+	RequestId string `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// code is the gRPC status code the request would have failed Infer with,
+	// set on StreamInfer responses only. A response with success false and
+	// code OK is a failure of the model itself, as in unary calls.
+	Code int32 `protobuf:"varint,8,opt,name=code,proto3" json:"code,omitempty"`
+	// retry_after_ms is the suggested backoff of a RESOURCE_EXHAUSTED
+	// StreamInfer response.
+	RetryAfterMs int64 `protobuf:"varint,9,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"`
+	// steps report where each step of a pipeline request ran, in the order
+	// the steps were declared.
+	Steps         []*StepResult `protobuf:"bytes,10,rep,name=steps,proto3" json:"steps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InferResponse) Reset() {
 	*x = InferResponse{}
-	mi := &file_api_proto_infer_proto_msgTypes[1]
+	mi := &file_api_proto_infer_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -112,7 +425,7 @@ func (x *InferResponse) String() string {
 func (*InferResponse) ProtoMessage() {}
 
 func (x *InferResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_infer_proto_msgTypes[1]
+	mi := &file_api_proto_infer_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -125,7 +438,7 @@ func (x *InferResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InferResponse.ProtoReflect.Descriptor instead.
 func (*InferResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_infer_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_infer_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *InferResponse) GetSuccess() bool {
@@ -149,25 +462,210 @@ func (x *InferResponse) GetErrorMessage() string {
 	return ""
 }
 
+func (x *InferResponse) GetOutputs() []*Tensor {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *InferResponse) GetServedBy() string {
+	if x != nil {
+		return x.ServedBy
+	}
+	return ""
+}
+
+func (x *InferResponse) GetHopCount() int32 {
+	if x != nil {
+		return x.HopCount
+	}
+	return 0
+}
+
+func (x *InferResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *InferResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *InferResponse) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+func (x *InferResponse) GetSteps() []*StepResult {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+// StepResult reports one step of a pipeline request.
+type StepResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ModelId       string                 `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	ServedBy      string                 `protobuf:"bytes,3,opt,name=served_by,json=servedBy,proto3" json:"served_by,omitempty"`
+	HopCount      int32                  `protobuf:"varint,4,opt,name=hop_count,json=hopCount,proto3" json:"hop_count,omitempty"`
+	LatencyMs     float64                `protobuf:"fixed64,5,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepResult) Reset() {
+	*x = StepResult{}
+	mi := &file_api_proto_infer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepResult) ProtoMessage() {}
+
+func (x *StepResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_infer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepResult.ProtoReflect.Descriptor instead.
+func (*StepResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_infer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StepResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StepResult) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *StepResult) GetServedBy() string {
+	if x != nil {
+		return x.ServedBy
+	}
+	return ""
+}
+
+func (x *StepResult) GetHopCount() int32 {
+	if x != nil {
+		return x.HopCount
+	}
+	return 0
+}
+
+func (x *StepResult) GetLatencyMs() float64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
 var File_api_proto_infer_proto protoreflect.FileDescriptor
 
 const file_api_proto_infer_proto_rawDesc = "" +
 	"\n" +
-	"\x15api/proto/infer.proto\x12\binferAPI\"\x94\x01\n" +
+	"\x15api/proto/infer.proto\x12\binferAPI\x1a\x1cgoogle/protobuf/struct.proto\"\x98\x02\n" +
+	"\x06Tensor\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12(\n" +
+	"\x05dtype\x18\x02 \x01(\x0e2\x12.inferAPI.DataTypeR\x05dtype\x12\x14\n" +
+	"\x05shape\x18\x03 \x03(\x03R\x05shape\x12\x1d\n" +
+	"\n" +
+	"float_data\x18\x04 \x03(\x02R\tfloatData\x12\x1f\n" +
+	"\vdouble_data\x18\x05 \x03(\x01R\n" +
+	"doubleData\x12\x1d\n" +
+	"\n" +
+	"int32_data\x18\x06 \x03(\x05R\tint32Data\x12\x1d\n" +
+	"\n" +
+	"int64_data\x18\a \x03(\x03R\tint64Data\x12\x1b\n" +
+	"\tbool_data\x18\b \x03(\bR\bboolData\x12\x1f\n" +
+	"\vstring_data\x18\t \x03(\tR\n" +
+	"stringData\"\xf5\x03\n" +
 	"\fInferRequest\x12\x19\n" +
 	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x1d\n" +
 	"\n" +
 	"input_data\x18\x02 \x03(\x02R\tinputData\x12'\n" +
 	"\x0fscaling_enabled\x18\x03 \x01(\bR\x0escalingEnabled\x12!\n" +
-	"\fis_forwarded\x18\x04 \x01(\bR\visForwarded\"n\n" +
+	"\fis_forwarded\x18\x04 \x01(\bR\visForwarded\x12(\n" +
+	"\x06inputs\x18\x05 \x03(\v2\x10.inferAPI.TensorR\x06inputs\x12/\n" +
+	"\x06fields\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x06fields\x12\x1f\n" +
+	"\vrouting_key\x18\a \x01(\tR\n" +
+	"routingKey\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\tR\bpriority\x12\x16\n" +
+	"\x06tenant\x18\t \x01(\tR\x06tenant\x12\x1b\n" +
+	"\thop_count\x18\n" +
+	" \x01(\x05R\bhopCount\x12\x19\n" +
+	"\bmax_hops\x18\v \x01(\x05R\amaxHops\x12\x18\n" +
+	"\avisited\x18\f \x03(\tR\avisited\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\r \x01(\tR\trequestId\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x0e \x01(\x03R\ttimeoutMs\x12\x1f\n" +
+	"\vpipeline_id\x18\x0f \x01(\tR\n" +
+	"pipelineId\"\xd9\x02\n" +
 	"\rInferResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1e\n" +
 	"\n" +
 	"prediction\x18\x02 \x01(\x02R\n" +
 	"prediction\x12#\n" +
-	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage2D\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12*\n" +
+	"\aoutputs\x18\x04 \x03(\v2\x10.inferAPI.TensorR\aoutputs\x12\x1b\n" +
+	"\tserved_by\x18\x05 \x01(\tR\bservedBy\x12\x1b\n" +
+	"\thop_count\x18\x06 \x01(\x05R\bhopCount\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x12\n" +
+	"\x04code\x18\b \x01(\x05R\x04code\x12$\n" +
+	"\x0eretry_after_ms\x18\t \x01(\x03R\fretryAfterMs\x12*\n" +
+	"\x05steps\x18\n" +
+	" \x03(\v2\x14.inferAPI.StepResultR\x05steps\"\x94\x01\n" +
+	"\n" +
+	"StepResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bmodel_id\x18\x02 \x01(\tR\amodelId\x12\x1b\n" +
+	"\tserved_by\x18\x03 \x01(\tR\bservedBy\x12\x1b\n" +
+	"\thop_count\x18\x04 \x01(\x05R\bhopCount\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x05 \x01(\x01R\tlatencyMs*v\n" +
+	"\bDataType\x12\x19\n" +
+	"\x15DATA_TYPE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aFLOAT32\x10\x01\x12\v\n" +
+	"\aFLOAT64\x10\x02\x12\t\n" +
+	"\x05INT32\x10\x03\x12\t\n" +
+	"\x05INT64\x10\x04\x12\t\n" +
+	"\x05UINT8\x10\x05\x12\b\n" +
+	"\x04BOOL\x10\x06\x12\n" +
+	"\n" +
+	"\x06STRING\x10\a2\x88\x01\n" +
 	"\bInferAPI\x128\n" +
-	"\x05Infer\x12\x16.inferAPI.InferRequest\x1a\x17.inferAPI.InferResponseB\"Z internal/common/pb/infer;inferpbb\x06proto3"
+	"\x05Infer\x12\x16.inferAPI.InferRequest\x1a\x17.inferAPI.InferResponse\x12B\n" +
+	"\vStreamInfer\x12\x16.inferAPI.InferRequest\x1a\x17.inferAPI.InferResponse(\x010\x01B\"Z internal/common/pb/infer;inferpbb\x06proto3"
 
 var (
 	file_api_proto_infer_proto_rawDescOnce sync.Once
@@ -181,19 +679,31 @@ func file_api_proto_infer_proto_rawDescGZIP() []byte {
 	return file_api_proto_infer_proto_rawDescData
 }
 
-var file_api_proto_infer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_proto_infer_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_proto_infer_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_api_proto_infer_proto_goTypes = []any{
-	(*InferRequest)(nil),  // 0: inferAPI.InferRequest
-	(*InferResponse)(nil), // 1: inferAPI.InferResponse
+	(DataType)(0),           // 0: inferAPI.DataType
+	(*Tensor)(nil),          // 1: inferAPI.Tensor
+	(*InferRequest)(nil),    // 2: inferAPI.InferRequest
+	(*InferResponse)(nil),   // 3: inferAPI.InferResponse
+	(*StepResult)(nil),      // 4: inferAPI.StepResult
+	(*structpb.Struct)(nil), // 5: google.protobuf.Struct
 }
 var file_api_proto_infer_proto_depIdxs = []int32{
-	0, // 0: inferAPI.InferAPI.Infer:input_type -> inferAPI.InferRequest
-	1, // 1: inferAPI.InferAPI.Infer:output_type -> inferAPI.InferResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: inferAPI.Tensor.dtype:type_name -> inferAPI.DataType
+	1, // 1: inferAPI.InferRequest.inputs:type_name -> inferAPI.Tensor
+	5, // 2: inferAPI.InferRequest.fields:type_name -> google.protobuf.Struct
+	1, // 3: inferAPI.InferResponse.outputs:type_name -> inferAPI.Tensor
+	4, // 4: inferAPI.InferResponse.steps:type_name -> inferAPI.StepResult
+	2, // 5: inferAPI.InferAPI.Infer:input_type -> inferAPI.InferRequest
+	2, // 6: inferAPI.InferAPI.StreamInfer:input_type -> inferAPI.InferRequest
+	3, // 7: inferAPI.InferAPI.Infer:output_type -> inferAPI.InferResponse
+	3, // 8: inferAPI.InferAPI.StreamInfer:output_type -> inferAPI.InferResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_infer_proto_init() }
@@ -206,13 +716,14 @@ func file_api_proto_infer_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_infer_proto_rawDesc), len(file_api_proto_infer_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_proto_infer_proto_goTypes,
 		DependencyIndexes: file_api_proto_infer_proto_depIdxs,
+		EnumInfos:         file_api_proto_infer_proto_enumTypes,
 		MessageInfos:      file_api_proto_infer_proto_msgTypes,
 	}.Build()
 	File_api_proto_infer_proto = out.File
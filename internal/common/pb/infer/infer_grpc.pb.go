@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InferAPI_Infer_FullMethodName = "/inferAPI.InferAPI/Infer"
+	InferAPI_Infer_FullMethodName       = "/inferAPI.InferAPI/Infer"
+	InferAPI_StreamInfer_FullMethodName = "/inferAPI.InferAPI/StreamInfer"
 )
 
 // InferAPIClient is the client API for InferAPI service.
@@ -27,6 +28,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type InferAPIClient interface {
 	Infer(ctx context.Context, in *InferRequest, opts ...grpc.CallOption) (*InferResponse, error)
+	// StreamInfer runs many requests over one stream. Every request needs a
+	// request_id; its response carries the same ID and may arrive out of order.
+	// Failures are reported per request in code and error_message instead of
+	// ending the stream.
+	StreamInfer(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[InferRequest, InferResponse], error)
 }
 
 type inferAPIClient struct {
@@ -47,11 +53,29 @@ func (c *inferAPIClient) Infer(ctx context.Context, in *InferRequest, opts ...gr
 	return out, nil
 }
 
+func (c *inferAPIClient) StreamInfer(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[InferRequest, InferResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InferAPI_ServiceDesc.Streams[0], InferAPI_StreamInfer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[InferRequest, InferResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InferAPI_StreamInferClient = grpc.BidiStreamingClient[InferRequest, InferResponse]
+
 // InferAPIServer is the server API for InferAPI service.
 // All implementations must embed UnimplementedInferAPIServer
 // for forward compatibility.
 type InferAPIServer interface {
 	Infer(context.Context, *InferRequest) (*InferResponse, error)
+	// StreamInfer runs many requests over one stream. Every request needs a
+	// request_id; its response carries the same ID and may arrive out of order.
+	// Failures are reported per request in code and error_message instead of
+	// ending the stream.
+	StreamInfer(grpc.BidiStreamingServer[InferRequest, InferResponse]) error
 	mustEmbedUnimplementedInferAPIServer()
 }
 
@@ -65,6 +89,9 @@ type UnimplementedInferAPIServer struct{}
 func (UnimplementedInferAPIServer) Infer(context.Context, *InferRequest) (*InferResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Infer not implemented")
 }
+func (UnimplementedInferAPIServer) StreamInfer(grpc.BidiStreamingServer[InferRequest, InferResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamInfer not implemented")
+}
 func (UnimplementedInferAPIServer) mustEmbedUnimplementedInferAPIServer() {}
 func (UnimplementedInferAPIServer) testEmbeddedByValue()                  {}
 
@@ -104,6 +131,13 @@ func _InferAPI_Infer_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InferAPI_StreamInfer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InferAPIServer).StreamInfer(&grpc.GenericServerStream[InferRequest, InferResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InferAPI_StreamInferServer = grpc.BidiStreamingServer[InferRequest, InferResponse]
+
 // InferAPI_ServiceDesc is the grpc.ServiceDesc for InferAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -116,6 +150,13 @@ var InferAPI_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _InferAPI_Infer_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInfer",
+			Handler:       _InferAPI_StreamInfer_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "api/proto/infer.proto",
 }
@@ -102,16 +102,44 @@ func (x *BoolResponse) GetSuccess() bool {
 }
 
 type ModelInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
-	FilePath      string                 `protobuf:"bytes,4,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
-	ModelType     string                 `protobuf:"bytes,5,opt,name=model_type,json=modelType,proto3" json:"model_type,omitempty"`
-	ModelSize     int64                  `protobuf:"varint,6,opt,name=model_size,json=modelSize,proto3" json:"model_size,omitempty"`
-	Replicas      int32                  `protobuf:"varint,7,opt,name=replicas,proto3" json:"replicas,omitempty"`
-	InputFormat   string                 `protobuf:"bytes,8,opt,name=input_format,json=inputFormat,proto3" json:"input_format,omitempty"`
-	Namespace     string                 `protobuf:"bytes,9,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version     string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	FilePath    string                 `protobuf:"bytes,4,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	ModelType   string                 `protobuf:"bytes,5,opt,name=model_type,json=modelType,proto3" json:"model_type,omitempty"`
+	ModelSize   int64                  `protobuf:"varint,6,opt,name=model_size,json=modelSize,proto3" json:"model_size,omitempty"`
+	Replicas    int32                  `protobuf:"varint,7,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	InputFormat string                 `protobuf:"bytes,8,opt,name=input_format,json=inputFormat,proto3" json:"input_format,omitempty"`
+	Namespace   string                 `protobuf:"bytes,9,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// sha256_hash is the hex digest of the model file; agents verify downloads against it.
+	Sha256Hash string `protobuf:"bytes,10,opt,name=sha256_hash,json=sha256Hash,proto3" json:"sha256_hash,omitempty"`
+	// node_selector lists node labels a node must carry to host a replica.
+	NodeSelector map[string]string `protobuf:"bytes,11,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// affinity is a JSON object with node_affinity, model_affinity and model_anti_affinity rules.
+	Affinity string `protobuf:"bytes,12,opt,name=affinity,proto3" json:"affinity,omitempty"`
+	// tolerations allow replicas onto nodes with matching taints.
+	Tolerations []*Toleration `protobuf:"bytes,13,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	// min_available is the number of running replicas a node drain keeps available.
+	MinAvailable int32 `protobuf:"varint,14,opt,name=min_available,json=minAvailable,proto3" json:"min_available,omitempty"`
+	// autoscaling lets the autoscaler move the replica count between bounds.
+	Autoscaling *Autoscaling `protobuf:"bytes,15,opt,name=autoscaling,proto3" json:"autoscaling,omitempty"`
+	// desired_replicas is the replica count the scheduler currently maintains
+	// (replicas, or the autoscaler's choice). Output only.
+	DesiredReplicas int32 `protobuf:"varint,16,opt,name=desired_replicas,json=desiredReplicas,proto3" json:"desired_replicas,omitempty"`
+	// rollout limits how replicas are replaced when the model's artifact changes.
+	Rollout *RolloutStrategy `protobuf:"bytes,17,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	// revision is the revision of the current artifact. Output only.
+	Revision int32 `protobuf:"varint,18,opt,name=revision,proto3" json:"revision,omitempty"`
+	// batching stacks concurrent requests into one model run on each replica.
+	Batching *Batching `protobuf:"bytes,19,opt,name=batching,proto3" json:"batching,omitempty"`
+	// preprocessing is a JSON object with input, features and target transforms
+	// applied by agents to requests made with scaling enabled.
+	Preprocessing string `protobuf:"bytes,20,opt,name=preprocessing,proto3" json:"preprocessing,omitempty"`
+	// load_balancer is the strategy agents use to pick the peer that serves a
+	// forwarded request: round_robin (default), least_request, power_of_two,
+	// latency_ewma or consistent_hash.
+	LoadBalancer  string `protobuf:"bytes,21,opt,name=load_balancer,json=loadBalancer,proto3" json:"load_balancer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,6 +237,341 @@ func (x *ModelInfo) GetNamespace() string {
 	return ""
 }
 
+func (x *ModelInfo) GetSha256Hash() string {
+	if x != nil {
+		return x.Sha256Hash
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetNodeSelector() map[string]string {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetAffinity() string {
+	if x != nil {
+		return x.Affinity
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetTolerations() []*Toleration {
+	if x != nil {
+		return x.Tolerations
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetMinAvailable() int32 {
+	if x != nil {
+		return x.MinAvailable
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetAutoscaling() *Autoscaling {
+	if x != nil {
+		return x.Autoscaling
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetDesiredReplicas() int32 {
+	if x != nil {
+		return x.DesiredReplicas
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetRollout() *RolloutStrategy {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetBatching() *Batching {
+	if x != nil {
+		return x.Batching
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetPreprocessing() string {
+	if x != nil {
+		return x.Preprocessing
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetLoadBalancer() string {
+	if x != nil {
+		return x.LoadBalancer
+	}
+	return ""
+}
+
+// Batching lets agents run up to max_batch_size rows of queued requests as a
+// single inference, waiting at most max_batch_wait_ms for a batch to fill.
+type Batching struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MaxBatchSize   int32                  `protobuf:"varint,1,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	MaxBatchWaitMs int32                  `protobuf:"varint,2,opt,name=max_batch_wait_ms,json=maxBatchWaitMs,proto3" json:"max_batch_wait_ms,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Batching) Reset() {
+	*x = Batching{}
+	mi := &file_api_proto_model_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Batching) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Batching) ProtoMessage() {}
+
+func (x *Batching) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Batching.ProtoReflect.Descriptor instead.
+func (*Batching) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Batching) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *Batching) GetMaxBatchWaitMs() int32 {
+	if x != nil {
+		return x.MaxBatchWaitMs
+	}
+	return 0
+}
+
+// RolloutStrategy bounds a rollout: up to max_surge replicas above and up to
+// max_unavailable running replicas below the desired count. Both default to
+// 1 and 0 when the strategy is unset; they cannot both be 0.
+type RolloutStrategy struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MaxSurge       int32                  `protobuf:"varint,1,opt,name=max_surge,json=maxSurge,proto3" json:"max_surge,omitempty"`
+	MaxUnavailable int32                  `protobuf:"varint,2,opt,name=max_unavailable,json=maxUnavailable,proto3" json:"max_unavailable,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RolloutStrategy) Reset() {
+	*x = RolloutStrategy{}
+	mi := &file_api_proto_model_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RolloutStrategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolloutStrategy) ProtoMessage() {}
+
+func (x *RolloutStrategy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolloutStrategy.ProtoReflect.Descriptor instead.
+func (*RolloutStrategy) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RolloutStrategy) GetMaxSurge() int32 {
+	if x != nil {
+		return x.MaxSurge
+	}
+	return 0
+}
+
+func (x *RolloutStrategy) GetMaxUnavailable() int32 {
+	if x != nil {
+		return x.MaxUnavailable
+	}
+	return 0
+}
+
+// Autoscaling scales a model on the load its replicas report. At least one
+// target must be set.
+type Autoscaling struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MinReplicas int32                  `protobuf:"varint,1,opt,name=min_replicas,json=minReplicas,proto3" json:"min_replicas,omitempty"`
+	MaxReplicas int32                  `protobuf:"varint,2,opt,name=max_replicas,json=maxReplicas,proto3" json:"max_replicas,omitempty"`
+	// target_queue_depth is the desired queued plus in-flight jobs per replica.
+	TargetQueueDepth float64 `protobuf:"fixed64,3,opt,name=target_queue_depth,json=targetQueueDepth,proto3" json:"target_queue_depth,omitempty"`
+	// target_p95_latency_ms is the desired p95 job latency.
+	TargetP95LatencyMs float64 `protobuf:"fixed64,4,opt,name=target_p95_latency_ms,json=targetP95LatencyMs,proto3" json:"target_p95_latency_ms,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Autoscaling) Reset() {
+	*x = Autoscaling{}
+	mi := &file_api_proto_model_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Autoscaling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Autoscaling) ProtoMessage() {}
+
+func (x *Autoscaling) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Autoscaling.ProtoReflect.Descriptor instead.
+func (*Autoscaling) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Autoscaling) GetMinReplicas() int32 {
+	if x != nil {
+		return x.MinReplicas
+	}
+	return 0
+}
+
+func (x *Autoscaling) GetMaxReplicas() int32 {
+	if x != nil {
+		return x.MaxReplicas
+	}
+	return 0
+}
+
+func (x *Autoscaling) GetTargetQueueDepth() float64 {
+	if x != nil {
+		return x.TargetQueueDepth
+	}
+	return 0
+}
+
+func (x *Autoscaling) GetTargetP95LatencyMs() float64 {
+	if x != nil {
+		return x.TargetP95LatencyMs
+	}
+	return 0
+}
+
+// Toleration matches node taints. operator is Equal (default) or Exists; an
+// empty effect matches every effect.
+type Toleration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Effect        string                 `protobuf:"bytes,4,opt,name=effect,proto3" json:"effect,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Toleration) Reset() {
+	*x = Toleration{}
+	mi := &file_api_proto_model_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Toleration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Toleration) ProtoMessage() {}
+
+func (x *Toleration) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Toleration.ProtoReflect.Descriptor instead.
+func (*Toleration) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Toleration) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Toleration) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *Toleration) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Toleration) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
 type UpdateModelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -220,13 +583,23 @@ type UpdateModelRequest struct {
 	Replicas      int32                  `protobuf:"varint,7,opt,name=replicas,proto3" json:"replicas,omitempty"`
 	InputFormat   string                 `protobuf:"bytes,8,opt,name=input_format,json=inputFormat,proto3" json:"input_format,omitempty"`
 	Namespace     string                 `protobuf:"bytes,9,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Sha256Hash    string                 `protobuf:"bytes,10,opt,name=sha256_hash,json=sha256Hash,proto3" json:"sha256_hash,omitempty"`
+	NodeSelector  map[string]string      `protobuf:"bytes,11,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Affinity      string                 `protobuf:"bytes,12,opt,name=affinity,proto3" json:"affinity,omitempty"`
+	Tolerations   []*Toleration          `protobuf:"bytes,13,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	MinAvailable  int32                  `protobuf:"varint,14,opt,name=min_available,json=minAvailable,proto3" json:"min_available,omitempty"`
+	Autoscaling   *Autoscaling           `protobuf:"bytes,15,opt,name=autoscaling,proto3" json:"autoscaling,omitempty"`
+	Rollout       *RolloutStrategy       `protobuf:"bytes,16,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	Batching      *Batching              `protobuf:"bytes,17,opt,name=batching,proto3" json:"batching,omitempty"`
+	Preprocessing string                 `protobuf:"bytes,18,opt,name=preprocessing,proto3" json:"preprocessing,omitempty"`
+	LoadBalancer  string                 `protobuf:"bytes,19,opt,name=load_balancer,json=loadBalancer,proto3" json:"load_balancer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateModelRequest) Reset() {
 	*x = UpdateModelRequest{}
-	mi := &file_api_proto_model_proto_msgTypes[3]
+	mi := &file_api_proto_model_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -238,7 +611,7 @@ func (x *UpdateModelRequest) String() string {
 func (*UpdateModelRequest) ProtoMessage() {}
 
 func (x *UpdateModelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_model_proto_msgTypes[3]
+	mi := &file_api_proto_model_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,7 +624,7 @@ func (x *UpdateModelRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateModelRequest.ProtoReflect.Descriptor instead.
 func (*UpdateModelRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_model_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_model_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateModelRequest) GetId() string {
@@ -261,84 +634,773 @@ func (x *UpdateModelRequest) GetId() string {
 	return ""
 }
 
-func (x *UpdateModelRequest) GetName() string {
+func (x *UpdateModelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetModelType() string {
+	if x != nil {
+		return x.ModelType
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetModelSize() int64 {
+	if x != nil {
+		return x.ModelSize
+	}
+	return 0
+}
+
+func (x *UpdateModelRequest) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *UpdateModelRequest) GetInputFormat() string {
+	if x != nil {
+		return x.InputFormat
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetSha256Hash() string {
+	if x != nil {
+		return x.Sha256Hash
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetNodeSelector() map[string]string {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
+func (x *UpdateModelRequest) GetAffinity() string {
+	if x != nil {
+		return x.Affinity
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetTolerations() []*Toleration {
+	if x != nil {
+		return x.Tolerations
+	}
+	return nil
+}
+
+func (x *UpdateModelRequest) GetMinAvailable() int32 {
+	if x != nil {
+		return x.MinAvailable
+	}
+	return 0
+}
+
+func (x *UpdateModelRequest) GetAutoscaling() *Autoscaling {
+	if x != nil {
+		return x.Autoscaling
+	}
+	return nil
+}
+
+func (x *UpdateModelRequest) GetRollout() *RolloutStrategy {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+func (x *UpdateModelRequest) GetBatching() *Batching {
+	if x != nil {
+		return x.Batching
+	}
+	return nil
+}
+
+func (x *UpdateModelRequest) GetPreprocessing() string {
+	if x != nil {
+		return x.Preprocessing
+	}
+	return ""
+}
+
+func (x *UpdateModelRequest) GetLoadBalancer() string {
+	if x != nil {
+		return x.LoadBalancer
+	}
+	return ""
+}
+
+type ModelID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelID) Reset() {
+	*x = ModelID{}
+	mi := &file_api_proto_model_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelID) ProtoMessage() {}
+
+func (x *ModelID) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelID.ProtoReflect.Descriptor instead.
+func (*ModelID) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ModelID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListModelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Models        []*ModelInfo           `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_api_proto_model_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsResponse) ProtoMessage() {}
+
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListModelsResponse) GetModels() []*ModelInfo {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+type ModelName struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelName) Reset() {
+	*x = ModelName{}
+	mi := &file_api_proto_model_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelName) ProtoMessage() {}
+
+func (x *ModelName) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelName.ProtoReflect.Descriptor instead.
+func (*ModelName) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ModelName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModelName) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ReplicaStatusBreakdown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Running       int32                  `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Pending       int32                  `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Unknown       int32                  `protobuf:"varint,4,opt,name=unknown,proto3" json:"unknown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicaStatusBreakdown) Reset() {
+	*x = ReplicaStatusBreakdown{}
+	mi := &file_api_proto_model_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicaStatusBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaStatusBreakdown) ProtoMessage() {}
+
+func (x *ReplicaStatusBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaStatusBreakdown.ProtoReflect.Descriptor instead.
+func (*ReplicaStatusBreakdown) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReplicaStatusBreakdown) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *ReplicaStatusBreakdown) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *ReplicaStatusBreakdown) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ReplicaStatusBreakdown) GetUnknown() int32 {
+	if x != nil {
+		return x.Unknown
+	}
+	return 0
+}
+
+type ModelStatusResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	ModelName     string                  `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	ModelId       string                  `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	Status        string                  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	TotalReplicas int32                   `protobuf:"varint,4,opt,name=total_replicas,json=totalReplicas,proto3" json:"total_replicas,omitempty"`
+	Breakdown     *ReplicaStatusBreakdown `protobuf:"bytes,5,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelStatusResponse) Reset() {
+	*x = ModelStatusResponse{}
+	mi := &file_api_proto_model_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelStatusResponse) ProtoMessage() {}
+
+func (x *ModelStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelStatusResponse.ProtoReflect.Descriptor instead.
+func (*ModelStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ModelStatusResponse) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *ModelStatusResponse) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *ModelStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ModelStatusResponse) GetTotalReplicas() int32 {
+	if x != nil {
+		return x.TotalReplicas
+	}
+	return 0
+}
+
+func (x *ModelStatusResponse) GetBreakdown() *ReplicaStatusBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+type NodeAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeAddress) Reset() {
+	*x = NodeAddress{}
+	mi := &file_api_proto_model_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeAddress) ProtoMessage() {}
+
+func (x *NodeAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeAddress.ProtoReflect.Descriptor instead.
+func (*NodeAddress) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NodeAddress) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *NodeAddress) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *NodeAddress) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+type ModelNodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelName     string                 `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	ModelId       string                 `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	Nodes         []*NodeAddress         `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelNodesResponse) Reset() {
+	*x = ModelNodesResponse{}
+	mi := &file_api_proto_model_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelNodesResponse) ProtoMessage() {}
+
+func (x *ModelNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelNodesResponse.ProtoReflect.Descriptor instead.
+func (*ModelNodesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ModelNodesResponse) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *ModelNodesResponse) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *ModelNodesResponse) GetNodes() []*NodeAddress {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// ModelRevision is one entry of a model's revision history.
+type ModelRevision struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Revision   int32                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Version    string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	FilePath   string                 `protobuf:"bytes,3,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Sha256Hash string                 `protobuf:"bytes,4,opt,name=sha256_hash,json=sha256Hash,proto3" json:"sha256_hash,omitempty"`
+	ModelType  string                 `protobuf:"bytes,5,opt,name=model_type,json=modelType,proto3" json:"model_type,omitempty"`
+	ModelSize  int64                  `protobuf:"varint,6,opt,name=model_size,json=modelSize,proto3" json:"model_size,omitempty"`
+	// created_at is a Unix timestamp in seconds.
+	CreatedAt     int64  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ChangeCause   string `protobuf:"bytes,8,opt,name=change_cause,json=changeCause,proto3" json:"change_cause,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelRevision) Reset() {
+	*x = ModelRevision{}
+	mi := &file_api_proto_model_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelRevision) ProtoMessage() {}
+
+func (x *ModelRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelRevision.ProtoReflect.Descriptor instead.
+func (*ModelRevision) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ModelRevision) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ModelRevision) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ModelRevision) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *ModelRevision) GetSha256Hash() string {
+	if x != nil {
+		return x.Sha256Hash
+	}
+	return ""
+}
+
+func (x *ModelRevision) GetModelType() string {
+	if x != nil {
+		return x.ModelType
+	}
+	return ""
+}
+
+func (x *ModelRevision) GetModelSize() int64 {
+	if x != nil {
+		return x.ModelSize
+	}
+	return 0
+}
+
+func (x *ModelRevision) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ModelRevision) GetChangeCause() string {
+	if x != nil {
+		return x.ChangeCause
+	}
+	return ""
+}
+
+type RolloutStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ModelId         string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	Revision        int32                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	DesiredReplicas int32                  `protobuf:"varint,3,opt,name=desired_replicas,json=desiredReplicas,proto3" json:"desired_replicas,omitempty"`
+	// updated_replicas are bound replicas deployed with the current revision.
+	UpdatedReplicas int32 `protobuf:"varint,4,opt,name=updated_replicas,json=updatedReplicas,proto3" json:"updated_replicas,omitempty"`
+	UpdatedRunning  int32 `protobuf:"varint,5,opt,name=updated_running,json=updatedRunning,proto3" json:"updated_running,omitempty"`
+	// old_replicas are bound replicas still deployed with an older revision.
+	OldReplicas   int32            `protobuf:"varint,6,opt,name=old_replicas,json=oldReplicas,proto3" json:"old_replicas,omitempty"`
+	OldRunning    int32            `protobuf:"varint,7,opt,name=old_running,json=oldRunning,proto3" json:"old_running,omitempty"`
+	Complete      bool             `protobuf:"varint,8,opt,name=complete,proto3" json:"complete,omitempty"`
+	Revisions     []*ModelRevision `protobuf:"bytes,9,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RolloutStatusResponse) Reset() {
+	*x = RolloutStatusResponse{}
+	mi := &file_api_proto_model_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RolloutStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolloutStatusResponse) ProtoMessage() {}
+
+func (x *RolloutStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolloutStatusResponse.ProtoReflect.Descriptor instead.
+func (*RolloutStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RolloutStatusResponse) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *RolloutStatusResponse) GetRevision() int32 {
 	if x != nil {
-		return x.Name
+		return x.Revision
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateModelRequest) GetVersion() string {
+func (x *RolloutStatusResponse) GetDesiredReplicas() int32 {
 	if x != nil {
-		return x.Version
+		return x.DesiredReplicas
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateModelRequest) GetFilePath() string {
+func (x *RolloutStatusResponse) GetUpdatedReplicas() int32 {
 	if x != nil {
-		return x.FilePath
+		return x.UpdatedReplicas
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateModelRequest) GetModelType() string {
+func (x *RolloutStatusResponse) GetUpdatedRunning() int32 {
 	if x != nil {
-		return x.ModelType
+		return x.UpdatedRunning
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateModelRequest) GetModelSize() int64 {
+func (x *RolloutStatusResponse) GetOldReplicas() int32 {
 	if x != nil {
-		return x.ModelSize
+		return x.OldReplicas
 	}
 	return 0
 }
 
-func (x *UpdateModelRequest) GetReplicas() int32 {
+func (x *RolloutStatusResponse) GetOldRunning() int32 {
 	if x != nil {
-		return x.Replicas
+		return x.OldRunning
 	}
 	return 0
 }
 
-func (x *UpdateModelRequest) GetInputFormat() string {
+func (x *RolloutStatusResponse) GetComplete() bool {
 	if x != nil {
-		return x.InputFormat
+		return x.Complete
 	}
-	return ""
+	return false
 }
 
-func (x *UpdateModelRequest) GetNamespace() string {
+func (x *RolloutStatusResponse) GetRevisions() []*ModelRevision {
 	if x != nil {
-		return x.Namespace
+		return x.Revisions
 	}
-	return ""
+	return nil
 }
 
-type ModelID struct {
+// UndoRolloutRequest rolls a model back to to_revision, or to the revision
+// before the current one if to_revision is 0.
+type UndoRolloutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ToRevision    int32                  `protobuf:"varint,2,opt,name=to_revision,json=toRevision,proto3" json:"to_revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModelID) Reset() {
-	*x = ModelID{}
-	mi := &file_api_proto_model_proto_msgTypes[4]
+func (x *UndoRolloutRequest) Reset() {
+	*x = UndoRolloutRequest{}
+	mi := &file_api_proto_model_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModelID) String() string {
+func (x *UndoRolloutRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModelID) ProtoMessage() {}
+func (*UndoRolloutRequest) ProtoMessage() {}
 
-func (x *ModelID) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_model_proto_msgTypes[4]
+func (x *UndoRolloutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,40 +1411,49 @@ func (x *ModelID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModelID.ProtoReflect.Descriptor instead.
-func (*ModelID) Descriptor() ([]byte, []int) {
-	return file_api_proto_model_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use UndoRolloutRequest.ProtoReflect.Descriptor instead.
+func (*UndoRolloutRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ModelID) GetId() string {
+func (x *UndoRolloutRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type ListModelsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Models        []*ModelInfo           `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+func (x *UndoRolloutRequest) GetToRevision() int32 {
+	if x != nil {
+		return x.ToRevision
+	}
+	return 0
+}
+
+type UndoRolloutResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// revision is the new revision created by the rollback.
+	Revision      int32 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModelsResponse) Reset() {
-	*x = ListModelsResponse{}
-	mi := &file_api_proto_model_proto_msgTypes[5]
+func (x *UndoRolloutResponse) Reset() {
+	*x = UndoRolloutResponse{}
+	mi := &file_api_proto_model_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModelsResponse) String() string {
+func (x *UndoRolloutResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModelsResponse) ProtoMessage() {}
+func (*UndoRolloutResponse) ProtoMessage() {}
 
-func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_model_proto_msgTypes[5]
+func (x *UndoRolloutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -393,41 +1464,58 @@ func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
-func (*ListModelsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_model_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use UndoRolloutResponse.ProtoReflect.Descriptor instead.
+func (*UndoRolloutResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ListModelsResponse) GetModels() []*ModelInfo {
+func (x *UndoRolloutResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Models
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type ModelName struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+func (x *UndoRolloutResponse) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// PipelineInfo is a DAG of registered models run as a single inference call
+// by the agent that receives it. Steps whose model has a replica on that node
+// run in-process; the others are forwarded to peers.
+type PipelineInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is generated on registration. Output only.
+	Id        string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string          `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Steps     []*PipelineStep `protobuf:"bytes,4,rep,name=steps,proto3" json:"steps,omitempty"`
+	// outputs are the step outputs returned, as "<step>" for a step's first
+	// output or "<step>.<output>". Empty returns every output of the steps no
+	// other step reads from.
+	Outputs       []string `protobuf:"bytes,5,rep,name=outputs,proto3" json:"outputs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModelName) Reset() {
-	*x = ModelName{}
-	mi := &file_api_proto_model_proto_msgTypes[6]
+func (x *PipelineInfo) Reset() {
+	*x = PipelineInfo{}
+	mi := &file_api_proto_model_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModelName) String() string {
+func (x *PipelineInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModelName) ProtoMessage() {}
+func (*PipelineInfo) ProtoMessage() {}
 
-func (x *ModelName) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_model_proto_msgTypes[6]
+func (x *PipelineInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -438,50 +1526,75 @@ func (x *ModelName) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModelName.ProtoReflect.Descriptor instead.
-func (*ModelName) Descriptor() ([]byte, []int) {
-	return file_api_proto_model_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use PipelineInfo.ProtoReflect.Descriptor instead.
+func (*PipelineInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ModelName) GetName() string {
+func (x *PipelineInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PipelineInfo) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *ModelName) GetNamespace() string {
+func (x *PipelineInfo) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-type ReplicaStatusBreakdown struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Running       int32                  `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
-	Pending       int32                  `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
-	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
-	Unknown       int32                  `protobuf:"varint,4,opt,name=unknown,proto3" json:"unknown,omitempty"`
+func (x *PipelineInfo) GetSteps() []*PipelineStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *PipelineInfo) GetOutputs() []string {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+// PipelineStep runs one model of the pipeline's namespace, named by model or
+// model_id. The control plane fills in the other on registration.
+type PipelineStep struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Name    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Model   string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	ModelId string                 `protobuf:"bytes,3,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	// inputs bind the model's inputs. A step without bindings gets the inputs
+	// of the pipeline request as they are.
+	Inputs        []*PipelineBinding `protobuf:"bytes,4,rep,name=inputs,proto3" json:"inputs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReplicaStatusBreakdown) Reset() {
-	*x = ReplicaStatusBreakdown{}
-	mi := &file_api_proto_model_proto_msgTypes[7]
+func (x *PipelineStep) Reset() {
+	*x = PipelineStep{}
+	mi := &file_api_proto_model_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReplicaStatusBreakdown) String() string {
+func (x *PipelineStep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReplicaStatusBreakdown) ProtoMessage() {}
+func (*PipelineStep) ProtoMessage() {}
 
-func (x *ReplicaStatusBreakdown) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_model_proto_msgTypes[7]
+func (x *PipelineStep) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,65 +1605,66 @@ func (x *ReplicaStatusBreakdown) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReplicaStatusBreakdown.ProtoReflect.Descriptor instead.
-func (*ReplicaStatusBreakdown) Descriptor() ([]byte, []int) {
-	return file_api_proto_model_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use PipelineStep.ProtoReflect.Descriptor instead.
+func (*PipelineStep) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ReplicaStatusBreakdown) GetRunning() int32 {
+func (x *PipelineStep) GetName() string {
 	if x != nil {
-		return x.Running
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ReplicaStatusBreakdown) GetPending() int32 {
+func (x *PipelineStep) GetModel() string {
 	if x != nil {
-		return x.Pending
+		return x.Model
 	}
-	return 0
+	return ""
 }
 
-func (x *ReplicaStatusBreakdown) GetFailed() int32 {
+func (x *PipelineStep) GetModelId() string {
 	if x != nil {
-		return x.Failed
+		return x.ModelId
 	}
-	return 0
+	return ""
 }
 
-func (x *ReplicaStatusBreakdown) GetUnknown() int32 {
+func (x *PipelineStep) GetInputs() []*PipelineBinding {
 	if x != nil {
-		return x.Unknown
+		return x.Inputs
 	}
-	return 0
+	return nil
 }
 
-type ModelStatusResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	ModelName     string                  `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
-	ModelId       string                  `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
-	Status        string                  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	TotalReplicas int32                   `protobuf:"varint,4,opt,name=total_replicas,json=totalReplicas,proto3" json:"total_replicas,omitempty"`
-	Breakdown     *ReplicaStatusBreakdown `protobuf:"bytes,5,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+// PipelineBinding feeds one input of a step's model. from is "input" for the
+// first input of the pipeline request, "input.<name>" for a named one,
+// "<step>" for the first output of an earlier step or "<step>.<output>". An
+// empty input binds the model's only input.
+type PipelineBinding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Input         string                 `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModelStatusResponse) Reset() {
-	*x = ModelStatusResponse{}
-	mi := &file_api_proto_model_proto_msgTypes[8]
+func (x *PipelineBinding) Reset() {
+	*x = PipelineBinding{}
+	mi := &file_api_proto_model_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModelStatusResponse) String() string {
+func (x *PipelineBinding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModelStatusResponse) ProtoMessage() {}
+func (*PipelineBinding) ProtoMessage() {}
 
-func (x *ModelStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_model_proto_msgTypes[8]
+func (x *PipelineBinding) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -561,70 +1675,92 @@ func (x *ModelStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModelStatusResponse.ProtoReflect.Descriptor instead.
-func (*ModelStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_model_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use PipelineBinding.ProtoReflect.Descriptor instead.
+func (*PipelineBinding) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ModelStatusResponse) GetModelName() string {
+func (x *PipelineBinding) GetInput() string {
 	if x != nil {
-		return x.ModelName
+		return x.Input
 	}
 	return ""
 }
 
-func (x *ModelStatusResponse) GetModelId() string {
+func (x *PipelineBinding) GetFrom() string {
 	if x != nil {
-		return x.ModelId
+		return x.From
 	}
 	return ""
 }
 
-func (x *ModelStatusResponse) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+type PipelineID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModelStatusResponse) GetTotalReplicas() int32 {
+func (x *PipelineID) Reset() {
+	*x = PipelineID{}
+	mi := &file_api_proto_model_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PipelineID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PipelineID) ProtoMessage() {}
+
+func (x *PipelineID) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[22]
 	if x != nil {
-		return x.TotalReplicas
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ModelStatusResponse) GetBreakdown() *ReplicaStatusBreakdown {
+// Deprecated: Use PipelineID.ProtoReflect.Descriptor instead.
+func (*PipelineID) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PipelineID) GetId() string {
 	if x != nil {
-		return x.Breakdown
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type NodeAddress struct {
+type PipelineName struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
-	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NodeAddress) Reset() {
-	*x = NodeAddress{}
-	mi := &file_api_proto_model_proto_msgTypes[9]
+func (x *PipelineName) Reset() {
+	*x = PipelineName{}
+	mi := &file_api_proto_model_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NodeAddress) String() string {
+func (x *PipelineName) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodeAddress) ProtoMessage() {}
+func (*PipelineName) ProtoMessage() {}
 
-func (x *NodeAddress) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_model_proto_msgTypes[9]
+func (x *PipelineName) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,56 +1771,47 @@ func (x *NodeAddress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NodeAddress.ProtoReflect.Descriptor instead.
-func (*NodeAddress) Descriptor() ([]byte, []int) {
-	return file_api_proto_model_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use PipelineName.ProtoReflect.Descriptor instead.
+func (*PipelineName) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *NodeAddress) GetNodeId() string {
+func (x *PipelineName) GetName() string {
 	if x != nil {
-		return x.NodeId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *NodeAddress) GetIp() string {
+func (x *PipelineName) GetNamespace() string {
 	if x != nil {
-		return x.Ip
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *NodeAddress) GetPort() int32 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
-}
-
-type ModelNodesResponse struct {
+type ListPipelinesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ModelName     string                 `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
-	ModelId       string                 `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
-	Nodes         []*NodeAddress         `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Pipelines     []*PipelineInfo        `protobuf:"bytes,1,rep,name=pipelines,proto3" json:"pipelines,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModelNodesResponse) Reset() {
-	*x = ModelNodesResponse{}
-	mi := &file_api_proto_model_proto_msgTypes[10]
+func (x *ListPipelinesResponse) Reset() {
+	*x = ListPipelinesResponse{}
+	mi := &file_api_proto_model_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModelNodesResponse) String() string {
+func (x *ListPipelinesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModelNodesResponse) ProtoMessage() {}
+func (*ListPipelinesResponse) ProtoMessage() {}
 
-func (x *ModelNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_model_proto_msgTypes[10]
+func (x *ListPipelinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_model_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,28 +1822,14 @@ func (x *ModelNodesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModelNodesResponse.ProtoReflect.Descriptor instead.
-func (*ModelNodesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_model_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *ModelNodesResponse) GetModelName() string {
-	if x != nil {
-		return x.ModelName
-	}
-	return ""
-}
-
-func (x *ModelNodesResponse) GetModelId() string {
-	if x != nil {
-		return x.ModelId
-	}
-	return ""
+// Deprecated: Use ListPipelinesResponse.ProtoReflect.Descriptor instead.
+func (*ListPipelinesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_model_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *ModelNodesResponse) GetNodes() []*NodeAddress {
+func (x *ListPipelinesResponse) GetPipelines() []*PipelineInfo {
 	if x != nil {
-		return x.Nodes
+		return x.Pipelines
 	}
 	return nil
 }
@@ -728,7 +1841,7 @@ const file_api_proto_model_proto_rawDesc = "" +
 	"\x15api/proto/model.proto\x12\x10modelRegistryAPI\"\x06\n" +
 	"\x04None\"(\n" +
 	"\fBoolResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x81\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x80\a\n" +
 	"\tModelInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
@@ -740,7 +1853,41 @@ const file_api_proto_model_proto_rawDesc = "" +
 	"model_size\x18\x06 \x01(\x03R\tmodelSize\x12\x1a\n" +
 	"\breplicas\x18\a \x01(\x05R\breplicas\x12!\n" +
 	"\finput_format\x18\b \x01(\tR\vinputFormat\x12\x1c\n" +
-	"\tnamespace\x18\t \x01(\tR\tnamespace\"\x8a\x02\n" +
+	"\tnamespace\x18\t \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vsha256_hash\x18\n" +
+	" \x01(\tR\n" +
+	"sha256Hash\x12R\n" +
+	"\rnode_selector\x18\v \x03(\v2-.modelRegistryAPI.ModelInfo.NodeSelectorEntryR\fnodeSelector\x12\x1a\n" +
+	"\baffinity\x18\f \x01(\tR\baffinity\x12>\n" +
+	"\vtolerations\x18\r \x03(\v2\x1c.modelRegistryAPI.TolerationR\vtolerations\x12#\n" +
+	"\rmin_available\x18\x0e \x01(\x05R\fminAvailable\x12?\n" +
+	"\vautoscaling\x18\x0f \x01(\v2\x1d.modelRegistryAPI.AutoscalingR\vautoscaling\x12)\n" +
+	"\x10desired_replicas\x18\x10 \x01(\x05R\x0fdesiredReplicas\x12;\n" +
+	"\arollout\x18\x11 \x01(\v2!.modelRegistryAPI.RolloutStrategyR\arollout\x12\x1a\n" +
+	"\brevision\x18\x12 \x01(\x05R\brevision\x126\n" +
+	"\bbatching\x18\x13 \x01(\v2\x1a.modelRegistryAPI.BatchingR\bbatching\x12$\n" +
+	"\rpreprocessing\x18\x14 \x01(\tR\rpreprocessing\x12#\n" +
+	"\rload_balancer\x18\x15 \x01(\tR\floadBalancer\x1a?\n" +
+	"\x11NodeSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"[\n" +
+	"\bBatching\x12$\n" +
+	"\x0emax_batch_size\x18\x01 \x01(\x05R\fmaxBatchSize\x12)\n" +
+	"\x11max_batch_wait_ms\x18\x02 \x01(\x05R\x0emaxBatchWaitMs\"W\n" +
+	"\x0fRolloutStrategy\x12\x1b\n" +
+	"\tmax_surge\x18\x01 \x01(\x05R\bmaxSurge\x12'\n" +
+	"\x0fmax_unavailable\x18\x02 \x01(\x05R\x0emaxUnavailable\"\xb4\x01\n" +
+	"\vAutoscaling\x12!\n" +
+	"\fmin_replicas\x18\x01 \x01(\x05R\vminReplicas\x12!\n" +
+	"\fmax_replicas\x18\x02 \x01(\x05R\vmaxReplicas\x12,\n" +
+	"\x12target_queue_depth\x18\x03 \x01(\x01R\x10targetQueueDepth\x121\n" +
+	"\x15target_p95_latency_ms\x18\x04 \x01(\x01R\x12targetP95LatencyMs\"h\n" +
+	"\n" +
+	"Toleration\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x16\n" +
+	"\x06effect\x18\x04 \x01(\tR\x06effect\"\xcb\x06\n" +
 	"\x12UpdateModelRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
@@ -752,7 +1899,22 @@ const file_api_proto_model_proto_rawDesc = "" +
 	"model_size\x18\x06 \x01(\x03R\tmodelSize\x12\x1a\n" +
 	"\breplicas\x18\a \x01(\x05R\breplicas\x12!\n" +
 	"\finput_format\x18\b \x01(\tR\vinputFormat\x12\x1c\n" +
-	"\tnamespace\x18\t \x01(\tR\tnamespace\"\x19\n" +
+	"\tnamespace\x18\t \x01(\tR\tnamespace\x12\x1f\n" +
+	"\vsha256_hash\x18\n" +
+	" \x01(\tR\n" +
+	"sha256Hash\x12[\n" +
+	"\rnode_selector\x18\v \x03(\v26.modelRegistryAPI.UpdateModelRequest.NodeSelectorEntryR\fnodeSelector\x12\x1a\n" +
+	"\baffinity\x18\f \x01(\tR\baffinity\x12>\n" +
+	"\vtolerations\x18\r \x03(\v2\x1c.modelRegistryAPI.TolerationR\vtolerations\x12#\n" +
+	"\rmin_available\x18\x0e \x01(\x05R\fminAvailable\x12?\n" +
+	"\vautoscaling\x18\x0f \x01(\v2\x1d.modelRegistryAPI.AutoscalingR\vautoscaling\x12;\n" +
+	"\arollout\x18\x10 \x01(\v2!.modelRegistryAPI.RolloutStrategyR\arollout\x126\n" +
+	"\bbatching\x18\x11 \x01(\v2\x1a.modelRegistryAPI.BatchingR\bbatching\x12$\n" +
+	"\rpreprocessing\x18\x12 \x01(\tR\rpreprocessing\x12#\n" +
+	"\rload_balancer\x18\x13 \x01(\tR\floadBalancer\x1a?\n" +
+	"\x11NodeSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x19\n" +
 	"\aModelID\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
 	"\x12ListModelsResponse\x123\n" +
@@ -780,7 +1942,60 @@ const file_api_proto_model_proto_rawDesc = "" +
 	"\n" +
 	"model_name\x18\x01 \x01(\tR\tmodelName\x12\x19\n" +
 	"\bmodel_id\x18\x02 \x01(\tR\amodelId\x123\n" +
-	"\x05nodes\x18\x03 \x03(\v2\x1d.modelRegistryAPI.NodeAddressR\x05nodes2\xc3\x04\n" +
+	"\x05nodes\x18\x03 \x03(\v2\x1d.modelRegistryAPI.NodeAddressR\x05nodes\"\x83\x02\n" +
+	"\rModelRevision\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x05R\brevision\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1b\n" +
+	"\tfile_path\x18\x03 \x01(\tR\bfilePath\x12\x1f\n" +
+	"\vsha256_hash\x18\x04 \x01(\tR\n" +
+	"sha256Hash\x12\x1d\n" +
+	"\n" +
+	"model_type\x18\x05 \x01(\tR\tmodelType\x12\x1d\n" +
+	"\n" +
+	"model_size\x18\x06 \x01(\x03R\tmodelSize\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fchange_cause\x18\b \x01(\tR\vchangeCause\"\xec\x02\n" +
+	"\x15RolloutStatusResponse\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x05R\brevision\x12)\n" +
+	"\x10desired_replicas\x18\x03 \x01(\x05R\x0fdesiredReplicas\x12)\n" +
+	"\x10updated_replicas\x18\x04 \x01(\x05R\x0fupdatedReplicas\x12'\n" +
+	"\x0fupdated_running\x18\x05 \x01(\x05R\x0eupdatedRunning\x12!\n" +
+	"\fold_replicas\x18\x06 \x01(\x05R\voldReplicas\x12\x1f\n" +
+	"\vold_running\x18\a \x01(\x05R\n" +
+	"oldRunning\x12\x1a\n" +
+	"\bcomplete\x18\b \x01(\bR\bcomplete\x12=\n" +
+	"\trevisions\x18\t \x03(\v2\x1f.modelRegistryAPI.ModelRevisionR\trevisions\"E\n" +
+	"\x12UndoRolloutRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vto_revision\x18\x02 \x01(\x05R\n" +
+	"toRevision\"K\n" +
+	"\x13UndoRolloutResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x05R\brevision\"\xa0\x01\n" +
+	"\fPipelineInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x124\n" +
+	"\x05steps\x18\x04 \x03(\v2\x1e.modelRegistryAPI.PipelineStepR\x05steps\x12\x18\n" +
+	"\aoutputs\x18\x05 \x03(\tR\aoutputs\"\x8e\x01\n" +
+	"\fPipelineStep\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x19\n" +
+	"\bmodel_id\x18\x03 \x01(\tR\amodelId\x129\n" +
+	"\x06inputs\x18\x04 \x03(\v2!.modelRegistryAPI.PipelineBindingR\x06inputs\";\n" +
+	"\x0fPipelineBinding\x12\x14\n" +
+	"\x05input\x18\x01 \x01(\tR\x05input\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\"\x1c\n" +
+	"\n" +
+	"PipelineID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\fPipelineName\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"U\n" +
+	"\x15ListPipelinesResponse\x12<\n" +
+	"\tpipelines\x18\x01 \x03(\v2\x1e.modelRegistryAPI.PipelineInfoR\tpipelines2\x93\t\n" +
 	"\x10ModelRegistryAPI\x12L\n" +
 	"\rRegisterModel\x12\x1b.modelRegistryAPI.ModelInfo\x1a\x1e.modelRegistryAPI.BoolResponse\x12L\n" +
 	"\x0fDeRegisterModel\x12\x19.modelRegistryAPI.ModelID\x1a\x1e.modelRegistryAPI.BoolResponse\x12S\n" +
@@ -789,7 +2004,14 @@ const file_api_proto_model_proto_rawDesc = "" +
 	"\n" +
 	"ListModels\x12\x16.modelRegistryAPI.None\x1a$.modelRegistryAPI.ListModelsResponse\x12T\n" +
 	"\x0eGetModelStatus\x12\x1b.modelRegistryAPI.ModelName\x1a%.modelRegistryAPI.ModelStatusResponse\x12X\n" +
-	"\x13GetNodesByModelName\x12\x1b.modelRegistryAPI.ModelName\x1a$.modelRegistryAPI.ModelNodesResponseB\"Z internal/common/pb/model;modelpbb\x06proto3"
+	"\x13GetNodesByModelName\x12\x1b.modelRegistryAPI.ModelName\x1a$.modelRegistryAPI.ModelNodesResponse\x12V\n" +
+	"\x10GetRolloutStatus\x12\x19.modelRegistryAPI.ModelID\x1a'.modelRegistryAPI.RolloutStatusResponse\x12Z\n" +
+	"\vUndoRollout\x12$.modelRegistryAPI.UndoRolloutRequest\x1a%.modelRegistryAPI.UndoRolloutResponse\x12R\n" +
+	"\x10RegisterPipeline\x12\x1e.modelRegistryAPI.PipelineInfo\x1a\x1e.modelRegistryAPI.PipelineInfo\x12R\n" +
+	"\x12DeRegisterPipeline\x12\x1c.modelRegistryAPI.PipelineID\x1a\x1e.modelRegistryAPI.BoolResponse\x12K\n" +
+	"\vGetPipeline\x12\x1c.modelRegistryAPI.PipelineID\x1a\x1e.modelRegistryAPI.PipelineInfo\x12S\n" +
+	"\x11GetPipelineByName\x12\x1e.modelRegistryAPI.PipelineName\x1a\x1e.modelRegistryAPI.PipelineInfo\x12P\n" +
+	"\rListPipelines\x12\x16.modelRegistryAPI.None\x1a'.modelRegistryAPI.ListPipelinesResponseB\"Z internal/common/pb/model;modelpbb\x06proto3"
 
 var (
 	file_api_proto_model_proto_rawDescOnce sync.Once
@@ -803,43 +2025,87 @@ func file_api_proto_model_proto_rawDescGZIP() []byte {
 	return file_api_proto_model_proto_rawDescData
 }
 
-var file_api_proto_model_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_proto_model_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_api_proto_model_proto_goTypes = []any{
 	(*None)(nil),                   // 0: modelRegistryAPI.None
 	(*BoolResponse)(nil),           // 1: modelRegistryAPI.BoolResponse
 	(*ModelInfo)(nil),              // 2: modelRegistryAPI.ModelInfo
-	(*UpdateModelRequest)(nil),     // 3: modelRegistryAPI.UpdateModelRequest
-	(*ModelID)(nil),                // 4: modelRegistryAPI.ModelID
-	(*ListModelsResponse)(nil),     // 5: modelRegistryAPI.ListModelsResponse
-	(*ModelName)(nil),              // 6: modelRegistryAPI.ModelName
-	(*ReplicaStatusBreakdown)(nil), // 7: modelRegistryAPI.ReplicaStatusBreakdown
-	(*ModelStatusResponse)(nil),    // 8: modelRegistryAPI.ModelStatusResponse
-	(*NodeAddress)(nil),            // 9: modelRegistryAPI.NodeAddress
-	(*ModelNodesResponse)(nil),     // 10: modelRegistryAPI.ModelNodesResponse
+	(*Batching)(nil),               // 3: modelRegistryAPI.Batching
+	(*RolloutStrategy)(nil),        // 4: modelRegistryAPI.RolloutStrategy
+	(*Autoscaling)(nil),            // 5: modelRegistryAPI.Autoscaling
+	(*Toleration)(nil),             // 6: modelRegistryAPI.Toleration
+	(*UpdateModelRequest)(nil),     // 7: modelRegistryAPI.UpdateModelRequest
+	(*ModelID)(nil),                // 8: modelRegistryAPI.ModelID
+	(*ListModelsResponse)(nil),     // 9: modelRegistryAPI.ListModelsResponse
+	(*ModelName)(nil),              // 10: modelRegistryAPI.ModelName
+	(*ReplicaStatusBreakdown)(nil), // 11: modelRegistryAPI.ReplicaStatusBreakdown
+	(*ModelStatusResponse)(nil),    // 12: modelRegistryAPI.ModelStatusResponse
+	(*NodeAddress)(nil),            // 13: modelRegistryAPI.NodeAddress
+	(*ModelNodesResponse)(nil),     // 14: modelRegistryAPI.ModelNodesResponse
+	(*ModelRevision)(nil),          // 15: modelRegistryAPI.ModelRevision
+	(*RolloutStatusResponse)(nil),  // 16: modelRegistryAPI.RolloutStatusResponse
+	(*UndoRolloutRequest)(nil),     // 17: modelRegistryAPI.UndoRolloutRequest
+	(*UndoRolloutResponse)(nil),    // 18: modelRegistryAPI.UndoRolloutResponse
+	(*PipelineInfo)(nil),           // 19: modelRegistryAPI.PipelineInfo
+	(*PipelineStep)(nil),           // 20: modelRegistryAPI.PipelineStep
+	(*PipelineBinding)(nil),        // 21: modelRegistryAPI.PipelineBinding
+	(*PipelineID)(nil),             // 22: modelRegistryAPI.PipelineID
+	(*PipelineName)(nil),           // 23: modelRegistryAPI.PipelineName
+	(*ListPipelinesResponse)(nil),  // 24: modelRegistryAPI.ListPipelinesResponse
+	nil,                            // 25: modelRegistryAPI.ModelInfo.NodeSelectorEntry
+	nil,                            // 26: modelRegistryAPI.UpdateModelRequest.NodeSelectorEntry
 }
 var file_api_proto_model_proto_depIdxs = []int32{
-	2,  // 0: modelRegistryAPI.ListModelsResponse.models:type_name -> modelRegistryAPI.ModelInfo
-	7,  // 1: modelRegistryAPI.ModelStatusResponse.breakdown:type_name -> modelRegistryAPI.ReplicaStatusBreakdown
-	9,  // 2: modelRegistryAPI.ModelNodesResponse.nodes:type_name -> modelRegistryAPI.NodeAddress
-	2,  // 3: modelRegistryAPI.ModelRegistryAPI.RegisterModel:input_type -> modelRegistryAPI.ModelInfo
-	4,  // 4: modelRegistryAPI.ModelRegistryAPI.DeRegisterModel:input_type -> modelRegistryAPI.ModelID
-	3,  // 5: modelRegistryAPI.ModelRegistryAPI.UpdateModel:input_type -> modelRegistryAPI.UpdateModelRequest
-	4,  // 6: modelRegistryAPI.ModelRegistryAPI.GetModel:input_type -> modelRegistryAPI.ModelID
-	0,  // 7: modelRegistryAPI.ModelRegistryAPI.ListModels:input_type -> modelRegistryAPI.None
-	6,  // 8: modelRegistryAPI.ModelRegistryAPI.GetModelStatus:input_type -> modelRegistryAPI.ModelName
-	6,  // 9: modelRegistryAPI.ModelRegistryAPI.GetNodesByModelName:input_type -> modelRegistryAPI.ModelName
-	1,  // 10: modelRegistryAPI.ModelRegistryAPI.RegisterModel:output_type -> modelRegistryAPI.BoolResponse
-	1,  // 11: modelRegistryAPI.ModelRegistryAPI.DeRegisterModel:output_type -> modelRegistryAPI.BoolResponse
-	1,  // 12: modelRegistryAPI.ModelRegistryAPI.UpdateModel:output_type -> modelRegistryAPI.BoolResponse
-	2,  // 13: modelRegistryAPI.ModelRegistryAPI.GetModel:output_type -> modelRegistryAPI.ModelInfo
-	5,  // 14: modelRegistryAPI.ModelRegistryAPI.ListModels:output_type -> modelRegistryAPI.ListModelsResponse
-	8,  // 15: modelRegistryAPI.ModelRegistryAPI.GetModelStatus:output_type -> modelRegistryAPI.ModelStatusResponse
-	10, // 16: modelRegistryAPI.ModelRegistryAPI.GetNodesByModelName:output_type -> modelRegistryAPI.ModelNodesResponse
-	10, // [10:17] is the sub-list for method output_type
-	3,  // [3:10] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	25, // 0: modelRegistryAPI.ModelInfo.node_selector:type_name -> modelRegistryAPI.ModelInfo.NodeSelectorEntry
+	6,  // 1: modelRegistryAPI.ModelInfo.tolerations:type_name -> modelRegistryAPI.Toleration
+	5,  // 2: modelRegistryAPI.ModelInfo.autoscaling:type_name -> modelRegistryAPI.Autoscaling
+	4,  // 3: modelRegistryAPI.ModelInfo.rollout:type_name -> modelRegistryAPI.RolloutStrategy
+	3,  // 4: modelRegistryAPI.ModelInfo.batching:type_name -> modelRegistryAPI.Batching
+	26, // 5: modelRegistryAPI.UpdateModelRequest.node_selector:type_name -> modelRegistryAPI.UpdateModelRequest.NodeSelectorEntry
+	6,  // 6: modelRegistryAPI.UpdateModelRequest.tolerations:type_name -> modelRegistryAPI.Toleration
+	5,  // 7: modelRegistryAPI.UpdateModelRequest.autoscaling:type_name -> modelRegistryAPI.Autoscaling
+	4,  // 8: modelRegistryAPI.UpdateModelRequest.rollout:type_name -> modelRegistryAPI.RolloutStrategy
+	3,  // 9: modelRegistryAPI.UpdateModelRequest.batching:type_name -> modelRegistryAPI.Batching
+	2,  // 10: modelRegistryAPI.ListModelsResponse.models:type_name -> modelRegistryAPI.ModelInfo
+	11, // 11: modelRegistryAPI.ModelStatusResponse.breakdown:type_name -> modelRegistryAPI.ReplicaStatusBreakdown
+	13, // 12: modelRegistryAPI.ModelNodesResponse.nodes:type_name -> modelRegistryAPI.NodeAddress
+	15, // 13: modelRegistryAPI.RolloutStatusResponse.revisions:type_name -> modelRegistryAPI.ModelRevision
+	20, // 14: modelRegistryAPI.PipelineInfo.steps:type_name -> modelRegistryAPI.PipelineStep
+	21, // 15: modelRegistryAPI.PipelineStep.inputs:type_name -> modelRegistryAPI.PipelineBinding
+	19, // 16: modelRegistryAPI.ListPipelinesResponse.pipelines:type_name -> modelRegistryAPI.PipelineInfo
+	2,  // 17: modelRegistryAPI.ModelRegistryAPI.RegisterModel:input_type -> modelRegistryAPI.ModelInfo
+	8,  // 18: modelRegistryAPI.ModelRegistryAPI.DeRegisterModel:input_type -> modelRegistryAPI.ModelID
+	7,  // 19: modelRegistryAPI.ModelRegistryAPI.UpdateModel:input_type -> modelRegistryAPI.UpdateModelRequest
+	8,  // 20: modelRegistryAPI.ModelRegistryAPI.GetModel:input_type -> modelRegistryAPI.ModelID
+	0,  // 21: modelRegistryAPI.ModelRegistryAPI.ListModels:input_type -> modelRegistryAPI.None
+	10, // 22: modelRegistryAPI.ModelRegistryAPI.GetModelStatus:input_type -> modelRegistryAPI.ModelName
+	10, // 23: modelRegistryAPI.ModelRegistryAPI.GetNodesByModelName:input_type -> modelRegistryAPI.ModelName
+	8,  // 24: modelRegistryAPI.ModelRegistryAPI.GetRolloutStatus:input_type -> modelRegistryAPI.ModelID
+	17, // 25: modelRegistryAPI.ModelRegistryAPI.UndoRollout:input_type -> modelRegistryAPI.UndoRolloutRequest
+	19, // 26: modelRegistryAPI.ModelRegistryAPI.RegisterPipeline:input_type -> modelRegistryAPI.PipelineInfo
+	22, // 27: modelRegistryAPI.ModelRegistryAPI.DeRegisterPipeline:input_type -> modelRegistryAPI.PipelineID
+	22, // 28: modelRegistryAPI.ModelRegistryAPI.GetPipeline:input_type -> modelRegistryAPI.PipelineID
+	23, // 29: modelRegistryAPI.ModelRegistryAPI.GetPipelineByName:input_type -> modelRegistryAPI.PipelineName
+	0,  // 30: modelRegistryAPI.ModelRegistryAPI.ListPipelines:input_type -> modelRegistryAPI.None
+	1,  // 31: modelRegistryAPI.ModelRegistryAPI.RegisterModel:output_type -> modelRegistryAPI.BoolResponse
+	1,  // 32: modelRegistryAPI.ModelRegistryAPI.DeRegisterModel:output_type -> modelRegistryAPI.BoolResponse
+	1,  // 33: modelRegistryAPI.ModelRegistryAPI.UpdateModel:output_type -> modelRegistryAPI.BoolResponse
+	2,  // 34: modelRegistryAPI.ModelRegistryAPI.GetModel:output_type -> modelRegistryAPI.ModelInfo
+	9,  // 35: modelRegistryAPI.ModelRegistryAPI.ListModels:output_type -> modelRegistryAPI.ListModelsResponse
+	12, // 36: modelRegistryAPI.ModelRegistryAPI.GetModelStatus:output_type -> modelRegistryAPI.ModelStatusResponse
+	14, // 37: modelRegistryAPI.ModelRegistryAPI.GetNodesByModelName:output_type -> modelRegistryAPI.ModelNodesResponse
+	16, // 38: modelRegistryAPI.ModelRegistryAPI.GetRolloutStatus:output_type -> modelRegistryAPI.RolloutStatusResponse
+	18, // 39: modelRegistryAPI.ModelRegistryAPI.UndoRollout:output_type -> modelRegistryAPI.UndoRolloutResponse
+	19, // 40: modelRegistryAPI.ModelRegistryAPI.RegisterPipeline:output_type -> modelRegistryAPI.PipelineInfo
+	1,  // 41: modelRegistryAPI.ModelRegistryAPI.DeRegisterPipeline:output_type -> modelRegistryAPI.BoolResponse
+	19, // 42: modelRegistryAPI.ModelRegistryAPI.GetPipeline:output_type -> modelRegistryAPI.PipelineInfo
+	19, // 43: modelRegistryAPI.ModelRegistryAPI.GetPipelineByName:output_type -> modelRegistryAPI.PipelineInfo
+	24, // 44: modelRegistryAPI.ModelRegistryAPI.ListPipelines:output_type -> modelRegistryAPI.ListPipelinesResponse
+	31, // [31:45] is the sub-list for method output_type
+	17, // [17:31] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_model_proto_init() }
@@ -853,7 +2119,7 @@ func file_api_proto_model_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_model_proto_rawDesc), len(file_api_proto_model_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
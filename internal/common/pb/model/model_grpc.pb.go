@@ -26,6 +26,13 @@ const (
 	ModelRegistryAPI_ListModels_FullMethodName          = "/modelRegistryAPI.ModelRegistryAPI/ListModels"
 	ModelRegistryAPI_GetModelStatus_FullMethodName      = "/modelRegistryAPI.ModelRegistryAPI/GetModelStatus"
 	ModelRegistryAPI_GetNodesByModelName_FullMethodName = "/modelRegistryAPI.ModelRegistryAPI/GetNodesByModelName"
+	ModelRegistryAPI_GetRolloutStatus_FullMethodName    = "/modelRegistryAPI.ModelRegistryAPI/GetRolloutStatus"
+	ModelRegistryAPI_UndoRollout_FullMethodName         = "/modelRegistryAPI.ModelRegistryAPI/UndoRollout"
+	ModelRegistryAPI_RegisterPipeline_FullMethodName    = "/modelRegistryAPI.ModelRegistryAPI/RegisterPipeline"
+	ModelRegistryAPI_DeRegisterPipeline_FullMethodName  = "/modelRegistryAPI.ModelRegistryAPI/DeRegisterPipeline"
+	ModelRegistryAPI_GetPipeline_FullMethodName         = "/modelRegistryAPI.ModelRegistryAPI/GetPipeline"
+	ModelRegistryAPI_GetPipelineByName_FullMethodName   = "/modelRegistryAPI.ModelRegistryAPI/GetPipelineByName"
+	ModelRegistryAPI_ListPipelines_FullMethodName       = "/modelRegistryAPI.ModelRegistryAPI/ListPipelines"
 )
 
 // ModelRegistryAPIClient is the client API for ModelRegistryAPI service.
@@ -39,6 +46,15 @@ type ModelRegistryAPIClient interface {
 	ListModels(ctx context.Context, in *None, opts ...grpc.CallOption) (*ListModelsResponse, error)
 	GetModelStatus(ctx context.Context, in *ModelName, opts ...grpc.CallOption) (*ModelStatusResponse, error)
 	GetNodesByModelName(ctx context.Context, in *ModelName, opts ...grpc.CallOption) (*ModelNodesResponse, error)
+	GetRolloutStatus(ctx context.Context, in *ModelID, opts ...grpc.CallOption) (*RolloutStatusResponse, error)
+	UndoRollout(ctx context.Context, in *UndoRolloutRequest, opts ...grpc.CallOption) (*UndoRolloutResponse, error)
+	// RegisterPipeline registers a DAG of models and returns it with its ID
+	// and the IDs of its steps' models.
+	RegisterPipeline(ctx context.Context, in *PipelineInfo, opts ...grpc.CallOption) (*PipelineInfo, error)
+	DeRegisterPipeline(ctx context.Context, in *PipelineID, opts ...grpc.CallOption) (*BoolResponse, error)
+	GetPipeline(ctx context.Context, in *PipelineID, opts ...grpc.CallOption) (*PipelineInfo, error)
+	GetPipelineByName(ctx context.Context, in *PipelineName, opts ...grpc.CallOption) (*PipelineInfo, error)
+	ListPipelines(ctx context.Context, in *None, opts ...grpc.CallOption) (*ListPipelinesResponse, error)
 }
 
 type modelRegistryAPIClient struct {
@@ -119,6 +135,76 @@ func (c *modelRegistryAPIClient) GetNodesByModelName(ctx context.Context, in *Mo
 	return out, nil
 }
 
+func (c *modelRegistryAPIClient) GetRolloutStatus(ctx context.Context, in *ModelID, opts ...grpc.CallOption) (*RolloutStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RolloutStatusResponse)
+	err := c.cc.Invoke(ctx, ModelRegistryAPI_GetRolloutStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelRegistryAPIClient) UndoRollout(ctx context.Context, in *UndoRolloutRequest, opts ...grpc.CallOption) (*UndoRolloutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndoRolloutResponse)
+	err := c.cc.Invoke(ctx, ModelRegistryAPI_UndoRollout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelRegistryAPIClient) RegisterPipeline(ctx context.Context, in *PipelineInfo, opts ...grpc.CallOption) (*PipelineInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PipelineInfo)
+	err := c.cc.Invoke(ctx, ModelRegistryAPI_RegisterPipeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelRegistryAPIClient) DeRegisterPipeline(ctx context.Context, in *PipelineID, opts ...grpc.CallOption) (*BoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, ModelRegistryAPI_DeRegisterPipeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelRegistryAPIClient) GetPipeline(ctx context.Context, in *PipelineID, opts ...grpc.CallOption) (*PipelineInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PipelineInfo)
+	err := c.cc.Invoke(ctx, ModelRegistryAPI_GetPipeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelRegistryAPIClient) GetPipelineByName(ctx context.Context, in *PipelineName, opts ...grpc.CallOption) (*PipelineInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PipelineInfo)
+	err := c.cc.Invoke(ctx, ModelRegistryAPI_GetPipelineByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelRegistryAPIClient) ListPipelines(ctx context.Context, in *None, opts ...grpc.CallOption) (*ListPipelinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPipelinesResponse)
+	err := c.cc.Invoke(ctx, ModelRegistryAPI_ListPipelines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ModelRegistryAPIServer is the server API for ModelRegistryAPI service.
 // All implementations must embed UnimplementedModelRegistryAPIServer
 // for forward compatibility.
@@ -130,6 +216,15 @@ type ModelRegistryAPIServer interface {
 	ListModels(context.Context, *None) (*ListModelsResponse, error)
 	GetModelStatus(context.Context, *ModelName) (*ModelStatusResponse, error)
 	GetNodesByModelName(context.Context, *ModelName) (*ModelNodesResponse, error)
+	GetRolloutStatus(context.Context, *ModelID) (*RolloutStatusResponse, error)
+	UndoRollout(context.Context, *UndoRolloutRequest) (*UndoRolloutResponse, error)
+	// RegisterPipeline registers a DAG of models and returns it with its ID
+	// and the IDs of its steps' models.
+	RegisterPipeline(context.Context, *PipelineInfo) (*PipelineInfo, error)
+	DeRegisterPipeline(context.Context, *PipelineID) (*BoolResponse, error)
+	GetPipeline(context.Context, *PipelineID) (*PipelineInfo, error)
+	GetPipelineByName(context.Context, *PipelineName) (*PipelineInfo, error)
+	ListPipelines(context.Context, *None) (*ListPipelinesResponse, error)
 	mustEmbedUnimplementedModelRegistryAPIServer()
 }
 
@@ -161,6 +256,27 @@ func (UnimplementedModelRegistryAPIServer) GetModelStatus(context.Context, *Mode
 func (UnimplementedModelRegistryAPIServer) GetNodesByModelName(context.Context, *ModelName) (*ModelNodesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetNodesByModelName not implemented")
 }
+func (UnimplementedModelRegistryAPIServer) GetRolloutStatus(context.Context, *ModelID) (*RolloutStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRolloutStatus not implemented")
+}
+func (UnimplementedModelRegistryAPIServer) UndoRollout(context.Context, *UndoRolloutRequest) (*UndoRolloutResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UndoRollout not implemented")
+}
+func (UnimplementedModelRegistryAPIServer) RegisterPipeline(context.Context, *PipelineInfo) (*PipelineInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterPipeline not implemented")
+}
+func (UnimplementedModelRegistryAPIServer) DeRegisterPipeline(context.Context, *PipelineID) (*BoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeRegisterPipeline not implemented")
+}
+func (UnimplementedModelRegistryAPIServer) GetPipeline(context.Context, *PipelineID) (*PipelineInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPipeline not implemented")
+}
+func (UnimplementedModelRegistryAPIServer) GetPipelineByName(context.Context, *PipelineName) (*PipelineInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPipelineByName not implemented")
+}
+func (UnimplementedModelRegistryAPIServer) ListPipelines(context.Context, *None) (*ListPipelinesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPipelines not implemented")
+}
 func (UnimplementedModelRegistryAPIServer) mustEmbedUnimplementedModelRegistryAPIServer() {}
 func (UnimplementedModelRegistryAPIServer) testEmbeddedByValue()                          {}
 
@@ -308,6 +424,132 @@ func _ModelRegistryAPI_GetNodesByModelName_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ModelRegistryAPI_GetRolloutStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModelID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryAPIServer).GetRolloutStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelRegistryAPI_GetRolloutStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryAPIServer).GetRolloutStatus(ctx, req.(*ModelID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelRegistryAPI_UndoRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryAPIServer).UndoRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelRegistryAPI_UndoRollout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryAPIServer).UndoRollout(ctx, req.(*UndoRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelRegistryAPI_RegisterPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PipelineInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryAPIServer).RegisterPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelRegistryAPI_RegisterPipeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryAPIServer).RegisterPipeline(ctx, req.(*PipelineInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelRegistryAPI_DeRegisterPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PipelineID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryAPIServer).DeRegisterPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelRegistryAPI_DeRegisterPipeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryAPIServer).DeRegisterPipeline(ctx, req.(*PipelineID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelRegistryAPI_GetPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PipelineID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryAPIServer).GetPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelRegistryAPI_GetPipeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryAPIServer).GetPipeline(ctx, req.(*PipelineID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelRegistryAPI_GetPipelineByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PipelineName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryAPIServer).GetPipelineByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelRegistryAPI_GetPipelineByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryAPIServer).GetPipelineByName(ctx, req.(*PipelineName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelRegistryAPI_ListPipelines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(None)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryAPIServer).ListPipelines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelRegistryAPI_ListPipelines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryAPIServer).ListPipelines(ctx, req.(*None))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ModelRegistryAPI_ServiceDesc is the grpc.ServiceDesc for ModelRegistryAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -343,6 +585,34 @@ var ModelRegistryAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNodesByModelName",
 			Handler:    _ModelRegistryAPI_GetNodesByModelName_Handler,
 		},
+		{
+			MethodName: "GetRolloutStatus",
+			Handler:    _ModelRegistryAPI_GetRolloutStatus_Handler,
+		},
+		{
+			MethodName: "UndoRollout",
+			Handler:    _ModelRegistryAPI_UndoRollout_Handler,
+		},
+		{
+			MethodName: "RegisterPipeline",
+			Handler:    _ModelRegistryAPI_RegisterPipeline_Handler,
+		},
+		{
+			MethodName: "DeRegisterPipeline",
+			Handler:    _ModelRegistryAPI_DeRegisterPipeline_Handler,
+		},
+		{
+			MethodName: "GetPipeline",
+			Handler:    _ModelRegistryAPI_GetPipeline_Handler,
+		},
+		{
+			MethodName: "GetPipelineByName",
+			Handler:    _ModelRegistryAPI_GetPipelineByName_Handler,
+		},
+		{
+			MethodName: "ListPipelines",
+			Handler:    _ModelRegistryAPI_ListPipelines_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/model.proto",
@@ -405,8 +405,19 @@ type NodeInfo struct {
 	Port                 int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
 	Metadata             *NodeMetadata          `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	ResourceCapabilities *ResourceCapabilities  `protobuf:"bytes,6,opt,name=resource_capabilities,json=resourceCapabilities,proto3" json:"resource_capabilities,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	Labels               map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Taints               []*Taint               `protobuf:"bytes,8,rep,name=taints,proto3" json:"taints,omitempty"`
+	// unschedulable is set by CordonNode and DrainNode; no new replicas are placed on the node.
+	Unschedulable bool `protobuf:"varint,9,opt,name=unschedulable,proto3" json:"unschedulable,omitempty"`
+	// draining is set by DrainNode until every replica has been migrated off the node.
+	Draining bool `protobuf:"varint,10,opt,name=draining,proto3" json:"draining,omitempty"`
+	// replica_count is the number of replicas bound to the node (output only).
+	ReplicaCount int32 `protobuf:"varint,11,opt,name=replica_count,json=replicaCount,proto3" json:"replica_count,omitempty"`
+	// peers is the health of inference the node forwarded to other nodes, as of
+	// its last heartbeat (output only).
+	Peers         []*PeerStats `protobuf:"bytes,12,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *NodeInfo) Reset() {
@@ -481,6 +492,220 @@ func (x *NodeInfo) GetResourceCapabilities() *ResourceCapabilities {
 	return nil
 }
 
+func (x *NodeInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *NodeInfo) GetTaints() []*Taint {
+	if x != nil {
+		return x.Taints
+	}
+	return nil
+}
+
+func (x *NodeInfo) GetUnschedulable() bool {
+	if x != nil {
+		return x.Unschedulable
+	}
+	return false
+}
+
+func (x *NodeInfo) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *NodeInfo) GetReplicaCount() int32 {
+	if x != nil {
+		return x.ReplicaCount
+	}
+	return 0
+}
+
+func (x *NodeInfo) GetPeers() []*PeerStats {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+// PeerStats reports how inference forwarded to one peer has fared.
+type PeerStats struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	NodeId              string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Address             string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Breaker             string                 `protobuf:"bytes,3,opt,name=breaker,proto3" json:"breaker,omitempty"`
+	ConsecutiveFailures int32                  `protobuf:"varint,4,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// ejected_until is a Unix timestamp in seconds, 0 unless the peer is ejected.
+	EjectedUntil  int64 `protobuf:"varint,5,opt,name=ejected_until,json=ejectedUntil,proto3" json:"ejected_until,omitempty"`
+	Ejections     int32 `protobuf:"varint,6,opt,name=ejections,proto3" json:"ejections,omitempty"`
+	Requests      int64 `protobuf:"varint,7,opt,name=requests,proto3" json:"requests,omitempty"`
+	Failures      int64 `protobuf:"varint,8,opt,name=failures,proto3" json:"failures,omitempty"`
+	Retries       int64 `protobuf:"varint,9,opt,name=retries,proto3" json:"retries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerStats) Reset() {
+	*x = PeerStats{}
+	mi := &file_api_proto_node_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStats) ProtoMessage() {}
+
+func (x *PeerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_node_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStats.ProtoReflect.Descriptor instead.
+func (*PeerStats) Descriptor() ([]byte, []int) {
+	return file_api_proto_node_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PeerStats) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PeerStats) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerStats) GetBreaker() string {
+	if x != nil {
+		return x.Breaker
+	}
+	return ""
+}
+
+func (x *PeerStats) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *PeerStats) GetEjectedUntil() int64 {
+	if x != nil {
+		return x.EjectedUntil
+	}
+	return 0
+}
+
+func (x *PeerStats) GetEjections() int32 {
+	if x != nil {
+		return x.Ejections
+	}
+	return 0
+}
+
+func (x *PeerStats) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *PeerStats) GetFailures() int64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *PeerStats) GetRetries() int64 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+// Taint keeps replicas of models without a matching toleration off a node.
+// effect is one of NoSchedule, PreferNoSchedule or NoExecute.
+type Taint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Effect        string                 `protobuf:"bytes,3,opt,name=effect,proto3" json:"effect,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Taint) Reset() {
+	*x = Taint{}
+	mi := &file_api_proto_node_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Taint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Taint) ProtoMessage() {}
+
+func (x *Taint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_node_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Taint.ProtoReflect.Descriptor instead.
+func (*Taint) Descriptor() ([]byte, []int) {
+	return file_api_proto_node_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Taint) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Taint) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Taint) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
 type RegisterNodeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
@@ -490,7 +715,7 @@ type RegisterNodeResponse struct {
 
 func (x *RegisterNodeResponse) Reset() {
 	*x = RegisterNodeResponse{}
-	mi := &file_api_proto_node_proto_msgTypes[7]
+	mi := &file_api_proto_node_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -502,7 +727,7 @@ func (x *RegisterNodeResponse) String() string {
 func (*RegisterNodeResponse) ProtoMessage() {}
 
 func (x *RegisterNodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_node_proto_msgTypes[7]
+	mi := &file_api_proto_node_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +740,7 @@ func (x *RegisterNodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterNodeResponse.ProtoReflect.Descriptor instead.
 func (*RegisterNodeResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_node_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_node_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RegisterNodeResponse) GetNodeId() string {
@@ -536,7 +761,7 @@ type UpdateNodeRequest struct {
 
 func (x *UpdateNodeRequest) Reset() {
 	*x = UpdateNodeRequest{}
-	mi := &file_api_proto_node_proto_msgTypes[8]
+	mi := &file_api_proto_node_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -548,7 +773,7 @@ func (x *UpdateNodeRequest) String() string {
 func (*UpdateNodeRequest) ProtoMessage() {}
 
 func (x *UpdateNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_node_proto_msgTypes[8]
+	mi := &file_api_proto_node_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -561,7 +786,7 @@ func (x *UpdateNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateNodeRequest.ProtoReflect.Descriptor instead.
 func (*UpdateNodeRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_node_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_node_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateNodeRequest) GetNodeId() string {
@@ -585,6 +810,130 @@ func (x *UpdateNodeRequest) GetResourceCapabilities() *ResourceCapabilities {
 	return nil
 }
 
+// LabelNodeRequest sets the given labels on a node and removes the labels listed in remove.
+type LabelNodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Remove        []string               `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelNodeRequest) Reset() {
+	*x = LabelNodeRequest{}
+	mi := &file_api_proto_node_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelNodeRequest) ProtoMessage() {}
+
+func (x *LabelNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_node_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelNodeRequest.ProtoReflect.Descriptor instead.
+func (*LabelNodeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_node_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LabelNodeRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *LabelNodeRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *LabelNodeRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+// TaintNodeRequest adds taints to a node (replacing taints with the same key and
+// effect) and removes the taints in remove. A removed taint without an effect
+// removes every taint with that key.
+type TaintNodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NodeId        string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Add           []*Taint               `protobuf:"bytes,2,rep,name=add,proto3" json:"add,omitempty"`
+	Remove        []*Taint               `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaintNodeRequest) Reset() {
+	*x = TaintNodeRequest{}
+	mi := &file_api_proto_node_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaintNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaintNodeRequest) ProtoMessage() {}
+
+func (x *TaintNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_node_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaintNodeRequest.ProtoReflect.Descriptor instead.
+func (*TaintNodeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_node_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TaintNodeRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *TaintNodeRequest) GetAdd() []*Taint {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *TaintNodeRequest) GetRemove() []*Taint {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
 type BoolResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -594,7 +943,7 @@ type BoolResponse struct {
 
 func (x *BoolResponse) Reset() {
 	*x = BoolResponse{}
-	mi := &file_api_proto_node_proto_msgTypes[9]
+	mi := &file_api_proto_node_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -606,7 +955,7 @@ func (x *BoolResponse) String() string {
 func (*BoolResponse) ProtoMessage() {}
 
 func (x *BoolResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_node_proto_msgTypes[9]
+	mi := &file_api_proto_node_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -619,7 +968,7 @@ func (x *BoolResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BoolResponse.ProtoReflect.Descriptor instead.
 func (*BoolResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_node_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_node_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BoolResponse) GetSuccess() bool {
@@ -638,7 +987,7 @@ type NodeID struct {
 
 func (x *NodeID) Reset() {
 	*x = NodeID{}
-	mi := &file_api_proto_node_proto_msgTypes[10]
+	mi := &file_api_proto_node_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -650,7 +999,7 @@ func (x *NodeID) String() string {
 func (*NodeID) ProtoMessage() {}
 
 func (x *NodeID) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_node_proto_msgTypes[10]
+	mi := &file_api_proto_node_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -663,7 +1012,7 @@ func (x *NodeID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeID.ProtoReflect.Descriptor instead.
 func (*NodeID) Descriptor() ([]byte, []int) {
-	return file_api_proto_node_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_node_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NodeID) GetNodeId() string {
@@ -682,7 +1031,7 @@ type ListNodesResponse struct {
 
 func (x *ListNodesResponse) Reset() {
 	*x = ListNodesResponse{}
-	mi := &file_api_proto_node_proto_msgTypes[11]
+	mi := &file_api_proto_node_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -694,7 +1043,7 @@ func (x *ListNodesResponse) String() string {
 func (*ListNodesResponse) ProtoMessage() {}
 
 func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_node_proto_msgTypes[11]
+	mi := &file_api_proto_node_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,7 +1056,7 @@ func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
 func (*ListNodesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_node_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_node_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListNodesResponse) GetNodes() []*NodeInfo {
@@ -748,33 +1097,74 @@ const file_api_proto_node_proto_rawDesc = "" +
 	"\fNodeMetadata\x12\x17\n" +
 	"\aos_type\x18\x01 \x01(\tR\x06osType\x12#\n" +
 	"\ragent_version\x18\x02 \x01(\tR\fagentVersion\x12\x1a\n" +
-	"\bhostname\x18\x03 \x01(\tR\bhostname\"\xf2\x01\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\"\xb5\x04\n" +
 	"\bNodeInfo\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x0e\n" +
 	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x12\n" +
 	"\x04port\x18\x04 \x01(\x05R\x04port\x129\n" +
 	"\bmetadata\x18\x05 \x01(\v2\x1d.nodeRegistryAPI.NodeMetadataR\bmetadata\x12Z\n" +
-	"\x15resource_capabilities\x18\x06 \x01(\v2%.nodeRegistryAPI.ResourceCapabilitiesR\x14resourceCapabilities\"/\n" +
+	"\x15resource_capabilities\x18\x06 \x01(\v2%.nodeRegistryAPI.ResourceCapabilitiesR\x14resourceCapabilities\x12=\n" +
+	"\x06labels\x18\a \x03(\v2%.nodeRegistryAPI.NodeInfo.LabelsEntryR\x06labels\x12.\n" +
+	"\x06taints\x18\b \x03(\v2\x16.nodeRegistryAPI.TaintR\x06taints\x12$\n" +
+	"\runschedulable\x18\t \x01(\bR\runschedulable\x12\x1a\n" +
+	"\bdraining\x18\n" +
+	" \x01(\bR\bdraining\x12#\n" +
+	"\rreplica_count\x18\v \x01(\x05R\freplicaCount\x120\n" +
+	"\x05peers\x18\f \x03(\v2\x1a.nodeRegistryAPI.PeerStatsR\x05peers\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa0\x02\n" +
+	"\tPeerStats\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x18\n" +
+	"\abreaker\x18\x03 \x01(\tR\abreaker\x121\n" +
+	"\x14consecutive_failures\x18\x04 \x01(\x05R\x13consecutiveFailures\x12#\n" +
+	"\rejected_until\x18\x05 \x01(\x03R\fejectedUntil\x12\x1c\n" +
+	"\tejections\x18\x06 \x01(\x05R\tejections\x12\x1a\n" +
+	"\brequests\x18\a \x01(\x03R\brequests\x12\x1a\n" +
+	"\bfailures\x18\b \x01(\x03R\bfailures\x12\x18\n" +
+	"\aretries\x18\t \x01(\x03R\aretries\"G\n" +
+	"\x05Taint\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06effect\x18\x03 \x01(\tR\x06effect\"/\n" +
 	"\x14RegisterNodeResponse\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\"\xc3\x01\n" +
 	"\x11UpdateNodeRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x129\n" +
 	"\bmetadata\x18\x02 \x01(\v2\x1d.nodeRegistryAPI.NodeMetadataR\bmetadata\x12Z\n" +
-	"\x15resource_capabilities\x18\x03 \x01(\v2%.nodeRegistryAPI.ResourceCapabilitiesR\x14resourceCapabilities\"(\n" +
+	"\x15resource_capabilities\x18\x03 \x01(\v2%.nodeRegistryAPI.ResourceCapabilitiesR\x14resourceCapabilities\"\xc5\x01\n" +
+	"\x10LabelNodeRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12E\n" +
+	"\x06labels\x18\x02 \x03(\v2-.nodeRegistryAPI.LabelNodeRequest.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06remove\x18\x03 \x03(\tR\x06remove\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x01\n" +
+	"\x10TaintNodeRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12(\n" +
+	"\x03add\x18\x02 \x03(\v2\x16.nodeRegistryAPI.TaintR\x03add\x12.\n" +
+	"\x06remove\x18\x03 \x03(\v2\x16.nodeRegistryAPI.TaintR\x06remove\"(\n" +
 	"\fBoolResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"!\n" +
 	"\x06NodeID\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\"D\n" +
 	"\x11ListNodesResponse\x12/\n" +
-	"\x05nodes\x18\x01 \x03(\v2\x19.nodeRegistryAPI.NodeInfoR\x05nodes2\x85\x03\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x19.nodeRegistryAPI.NodeInfoR\x05nodes2\xf6\x05\n" +
 	"\x0fNodeRegistryAPI\x12P\n" +
 	"\fRegisterNode\x12\x19.nodeRegistryAPI.NodeInfo\x1a%.nodeRegistryAPI.RegisterNodeResponse\x12H\n" +
 	"\x0eDeRegisterNode\x12\x17.nodeRegistryAPI.NodeID\x1a\x1d.nodeRegistryAPI.BoolResponse\x12O\n" +
 	"\n" +
 	"UpdateNode\x12\".nodeRegistryAPI.UpdateNodeRequest\x1a\x1d.nodeRegistryAPI.BoolResponse\x12=\n" +
 	"\aGetNode\x12\x17.nodeRegistryAPI.NodeID\x1a\x19.nodeRegistryAPI.NodeInfo\x12F\n" +
-	"\tListNodes\x12\x15.nodeRegistryAPI.None\x1a\".nodeRegistryAPI.ListNodesResponseB Z\x1einternal/common/pb/node;nodepbb\x06proto3"
+	"\tListNodes\x12\x15.nodeRegistryAPI.None\x1a\".nodeRegistryAPI.ListNodesResponse\x12M\n" +
+	"\tLabelNode\x12!.nodeRegistryAPI.LabelNodeRequest\x1a\x1d.nodeRegistryAPI.BoolResponse\x12M\n" +
+	"\tTaintNode\x12!.nodeRegistryAPI.TaintNodeRequest\x1a\x1d.nodeRegistryAPI.BoolResponse\x12D\n" +
+	"\n" +
+	"CordonNode\x12\x17.nodeRegistryAPI.NodeID\x1a\x1d.nodeRegistryAPI.BoolResponse\x12F\n" +
+	"\fUncordonNode\x12\x17.nodeRegistryAPI.NodeID\x1a\x1d.nodeRegistryAPI.BoolResponse\x12C\n" +
+	"\tDrainNode\x12\x17.nodeRegistryAPI.NodeID\x1a\x1d.nodeRegistryAPI.BoolResponseB Z\x1einternal/common/pb/node;nodepbb\x06proto3"
 
 var (
 	file_api_proto_node_proto_rawDescOnce sync.Once
@@ -788,7 +1178,7 @@ func file_api_proto_node_proto_rawDescGZIP() []byte {
 	return file_api_proto_node_proto_rawDescData
 }
 
-var file_api_proto_node_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_proto_node_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_api_proto_node_proto_goTypes = []any{
 	(*None)(nil),                 // 0: nodeRegistryAPI.None
 	(*MemoryInfo)(nil),           // 1: nodeRegistryAPI.MemoryInfo
@@ -797,11 +1187,17 @@ var file_api_proto_node_proto_goTypes = []any{
 	(*ResourceCapabilities)(nil), // 4: nodeRegistryAPI.ResourceCapabilities
 	(*NodeMetadata)(nil),         // 5: nodeRegistryAPI.NodeMetadata
 	(*NodeInfo)(nil),             // 6: nodeRegistryAPI.NodeInfo
-	(*RegisterNodeResponse)(nil), // 7: nodeRegistryAPI.RegisterNodeResponse
-	(*UpdateNodeRequest)(nil),    // 8: nodeRegistryAPI.UpdateNodeRequest
-	(*BoolResponse)(nil),         // 9: nodeRegistryAPI.BoolResponse
-	(*NodeID)(nil),               // 10: nodeRegistryAPI.NodeID
-	(*ListNodesResponse)(nil),    // 11: nodeRegistryAPI.ListNodesResponse
+	(*PeerStats)(nil),            // 7: nodeRegistryAPI.PeerStats
+	(*Taint)(nil),                // 8: nodeRegistryAPI.Taint
+	(*RegisterNodeResponse)(nil), // 9: nodeRegistryAPI.RegisterNodeResponse
+	(*UpdateNodeRequest)(nil),    // 10: nodeRegistryAPI.UpdateNodeRequest
+	(*LabelNodeRequest)(nil),     // 11: nodeRegistryAPI.LabelNodeRequest
+	(*TaintNodeRequest)(nil),     // 12: nodeRegistryAPI.TaintNodeRequest
+	(*BoolResponse)(nil),         // 13: nodeRegistryAPI.BoolResponse
+	(*NodeID)(nil),               // 14: nodeRegistryAPI.NodeID
+	(*ListNodesResponse)(nil),    // 15: nodeRegistryAPI.ListNodesResponse
+	nil,                          // 16: nodeRegistryAPI.NodeInfo.LabelsEntry
+	nil,                          // 17: nodeRegistryAPI.LabelNodeRequest.LabelsEntry
 }
 var file_api_proto_node_proto_depIdxs = []int32{
 	1,  // 0: nodeRegistryAPI.ResourceCapabilities.memory:type_name -> nodeRegistryAPI.MemoryInfo
@@ -809,24 +1205,40 @@ var file_api_proto_node_proto_depIdxs = []int32{
 	3,  // 2: nodeRegistryAPI.ResourceCapabilities.compute_devices:type_name -> nodeRegistryAPI.ComputeDevice
 	5,  // 3: nodeRegistryAPI.NodeInfo.metadata:type_name -> nodeRegistryAPI.NodeMetadata
 	4,  // 4: nodeRegistryAPI.NodeInfo.resource_capabilities:type_name -> nodeRegistryAPI.ResourceCapabilities
-	5,  // 5: nodeRegistryAPI.UpdateNodeRequest.metadata:type_name -> nodeRegistryAPI.NodeMetadata
-	4,  // 6: nodeRegistryAPI.UpdateNodeRequest.resource_capabilities:type_name -> nodeRegistryAPI.ResourceCapabilities
-	6,  // 7: nodeRegistryAPI.ListNodesResponse.nodes:type_name -> nodeRegistryAPI.NodeInfo
-	6,  // 8: nodeRegistryAPI.NodeRegistryAPI.RegisterNode:input_type -> nodeRegistryAPI.NodeInfo
-	10, // 9: nodeRegistryAPI.NodeRegistryAPI.DeRegisterNode:input_type -> nodeRegistryAPI.NodeID
-	8,  // 10: nodeRegistryAPI.NodeRegistryAPI.UpdateNode:input_type -> nodeRegistryAPI.UpdateNodeRequest
-	10, // 11: nodeRegistryAPI.NodeRegistryAPI.GetNode:input_type -> nodeRegistryAPI.NodeID
-	0,  // 12: nodeRegistryAPI.NodeRegistryAPI.ListNodes:input_type -> nodeRegistryAPI.None
-	7,  // 13: nodeRegistryAPI.NodeRegistryAPI.RegisterNode:output_type -> nodeRegistryAPI.RegisterNodeResponse
-	9,  // 14: nodeRegistryAPI.NodeRegistryAPI.DeRegisterNode:output_type -> nodeRegistryAPI.BoolResponse
-	9,  // 15: nodeRegistryAPI.NodeRegistryAPI.UpdateNode:output_type -> nodeRegistryAPI.BoolResponse
-	6,  // 16: nodeRegistryAPI.NodeRegistryAPI.GetNode:output_type -> nodeRegistryAPI.NodeInfo
-	11, // 17: nodeRegistryAPI.NodeRegistryAPI.ListNodes:output_type -> nodeRegistryAPI.ListNodesResponse
-	13, // [13:18] is the sub-list for method output_type
-	8,  // [8:13] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	16, // 5: nodeRegistryAPI.NodeInfo.labels:type_name -> nodeRegistryAPI.NodeInfo.LabelsEntry
+	8,  // 6: nodeRegistryAPI.NodeInfo.taints:type_name -> nodeRegistryAPI.Taint
+	7,  // 7: nodeRegistryAPI.NodeInfo.peers:type_name -> nodeRegistryAPI.PeerStats
+	5,  // 8: nodeRegistryAPI.UpdateNodeRequest.metadata:type_name -> nodeRegistryAPI.NodeMetadata
+	4,  // 9: nodeRegistryAPI.UpdateNodeRequest.resource_capabilities:type_name -> nodeRegistryAPI.ResourceCapabilities
+	17, // 10: nodeRegistryAPI.LabelNodeRequest.labels:type_name -> nodeRegistryAPI.LabelNodeRequest.LabelsEntry
+	8,  // 11: nodeRegistryAPI.TaintNodeRequest.add:type_name -> nodeRegistryAPI.Taint
+	8,  // 12: nodeRegistryAPI.TaintNodeRequest.remove:type_name -> nodeRegistryAPI.Taint
+	6,  // 13: nodeRegistryAPI.ListNodesResponse.nodes:type_name -> nodeRegistryAPI.NodeInfo
+	6,  // 14: nodeRegistryAPI.NodeRegistryAPI.RegisterNode:input_type -> nodeRegistryAPI.NodeInfo
+	14, // 15: nodeRegistryAPI.NodeRegistryAPI.DeRegisterNode:input_type -> nodeRegistryAPI.NodeID
+	10, // 16: nodeRegistryAPI.NodeRegistryAPI.UpdateNode:input_type -> nodeRegistryAPI.UpdateNodeRequest
+	14, // 17: nodeRegistryAPI.NodeRegistryAPI.GetNode:input_type -> nodeRegistryAPI.NodeID
+	0,  // 18: nodeRegistryAPI.NodeRegistryAPI.ListNodes:input_type -> nodeRegistryAPI.None
+	11, // 19: nodeRegistryAPI.NodeRegistryAPI.LabelNode:input_type -> nodeRegistryAPI.LabelNodeRequest
+	12, // 20: nodeRegistryAPI.NodeRegistryAPI.TaintNode:input_type -> nodeRegistryAPI.TaintNodeRequest
+	14, // 21: nodeRegistryAPI.NodeRegistryAPI.CordonNode:input_type -> nodeRegistryAPI.NodeID
+	14, // 22: nodeRegistryAPI.NodeRegistryAPI.UncordonNode:input_type -> nodeRegistryAPI.NodeID
+	14, // 23: nodeRegistryAPI.NodeRegistryAPI.DrainNode:input_type -> nodeRegistryAPI.NodeID
+	9,  // 24: nodeRegistryAPI.NodeRegistryAPI.RegisterNode:output_type -> nodeRegistryAPI.RegisterNodeResponse
+	13, // 25: nodeRegistryAPI.NodeRegistryAPI.DeRegisterNode:output_type -> nodeRegistryAPI.BoolResponse
+	13, // 26: nodeRegistryAPI.NodeRegistryAPI.UpdateNode:output_type -> nodeRegistryAPI.BoolResponse
+	6,  // 27: nodeRegistryAPI.NodeRegistryAPI.GetNode:output_type -> nodeRegistryAPI.NodeInfo
+	15, // 28: nodeRegistryAPI.NodeRegistryAPI.ListNodes:output_type -> nodeRegistryAPI.ListNodesResponse
+	13, // 29: nodeRegistryAPI.NodeRegistryAPI.LabelNode:output_type -> nodeRegistryAPI.BoolResponse
+	13, // 30: nodeRegistryAPI.NodeRegistryAPI.TaintNode:output_type -> nodeRegistryAPI.BoolResponse
+	13, // 31: nodeRegistryAPI.NodeRegistryAPI.CordonNode:output_type -> nodeRegistryAPI.BoolResponse
+	13, // 32: nodeRegistryAPI.NodeRegistryAPI.UncordonNode:output_type -> nodeRegistryAPI.BoolResponse
+	13, // 33: nodeRegistryAPI.NodeRegistryAPI.DrainNode:output_type -> nodeRegistryAPI.BoolResponse
+	24, // [24:34] is the sub-list for method output_type
+	14, // [14:24] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_node_proto_init() }
@@ -840,7 +1252,7 @@ func file_api_proto_node_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_node_proto_rawDesc), len(file_api_proto_node_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
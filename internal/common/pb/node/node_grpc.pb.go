@@ -24,6 +24,11 @@ const (
 	NodeRegistryAPI_UpdateNode_FullMethodName     = "/nodeRegistryAPI.NodeRegistryAPI/UpdateNode"
 	NodeRegistryAPI_GetNode_FullMethodName        = "/nodeRegistryAPI.NodeRegistryAPI/GetNode"
 	NodeRegistryAPI_ListNodes_FullMethodName      = "/nodeRegistryAPI.NodeRegistryAPI/ListNodes"
+	NodeRegistryAPI_LabelNode_FullMethodName      = "/nodeRegistryAPI.NodeRegistryAPI/LabelNode"
+	NodeRegistryAPI_TaintNode_FullMethodName      = "/nodeRegistryAPI.NodeRegistryAPI/TaintNode"
+	NodeRegistryAPI_CordonNode_FullMethodName     = "/nodeRegistryAPI.NodeRegistryAPI/CordonNode"
+	NodeRegistryAPI_UncordonNode_FullMethodName   = "/nodeRegistryAPI.NodeRegistryAPI/UncordonNode"
+	NodeRegistryAPI_DrainNode_FullMethodName      = "/nodeRegistryAPI.NodeRegistryAPI/DrainNode"
 )
 
 // NodeRegistryAPIClient is the client API for NodeRegistryAPI service.
@@ -35,6 +40,11 @@ type NodeRegistryAPIClient interface {
 	UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*BoolResponse, error)
 	GetNode(ctx context.Context, in *NodeID, opts ...grpc.CallOption) (*NodeInfo, error)
 	ListNodes(ctx context.Context, in *None, opts ...grpc.CallOption) (*ListNodesResponse, error)
+	LabelNode(ctx context.Context, in *LabelNodeRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	TaintNode(ctx context.Context, in *TaintNodeRequest, opts ...grpc.CallOption) (*BoolResponse, error)
+	CordonNode(ctx context.Context, in *NodeID, opts ...grpc.CallOption) (*BoolResponse, error)
+	UncordonNode(ctx context.Context, in *NodeID, opts ...grpc.CallOption) (*BoolResponse, error)
+	DrainNode(ctx context.Context, in *NodeID, opts ...grpc.CallOption) (*BoolResponse, error)
 }
 
 type nodeRegistryAPIClient struct {
@@ -95,6 +105,56 @@ func (c *nodeRegistryAPIClient) ListNodes(ctx context.Context, in *None, opts ..
 	return out, nil
 }
 
+func (c *nodeRegistryAPIClient) LabelNode(ctx context.Context, in *LabelNodeRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, NodeRegistryAPI_LabelNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeRegistryAPIClient) TaintNode(ctx context.Context, in *TaintNodeRequest, opts ...grpc.CallOption) (*BoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, NodeRegistryAPI_TaintNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeRegistryAPIClient) CordonNode(ctx context.Context, in *NodeID, opts ...grpc.CallOption) (*BoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, NodeRegistryAPI_CordonNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeRegistryAPIClient) UncordonNode(ctx context.Context, in *NodeID, opts ...grpc.CallOption) (*BoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, NodeRegistryAPI_UncordonNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeRegistryAPIClient) DrainNode(ctx context.Context, in *NodeID, opts ...grpc.CallOption) (*BoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, NodeRegistryAPI_DrainNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeRegistryAPIServer is the server API for NodeRegistryAPI service.
 // All implementations must embed UnimplementedNodeRegistryAPIServer
 // for forward compatibility.
@@ -104,6 +164,11 @@ type NodeRegistryAPIServer interface {
 	UpdateNode(context.Context, *UpdateNodeRequest) (*BoolResponse, error)
 	GetNode(context.Context, *NodeID) (*NodeInfo, error)
 	ListNodes(context.Context, *None) (*ListNodesResponse, error)
+	LabelNode(context.Context, *LabelNodeRequest) (*BoolResponse, error)
+	TaintNode(context.Context, *TaintNodeRequest) (*BoolResponse, error)
+	CordonNode(context.Context, *NodeID) (*BoolResponse, error)
+	UncordonNode(context.Context, *NodeID) (*BoolResponse, error)
+	DrainNode(context.Context, *NodeID) (*BoolResponse, error)
 	mustEmbedUnimplementedNodeRegistryAPIServer()
 }
 
@@ -129,6 +194,21 @@ func (UnimplementedNodeRegistryAPIServer) GetNode(context.Context, *NodeID) (*No
 func (UnimplementedNodeRegistryAPIServer) ListNodes(context.Context, *None) (*ListNodesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListNodes not implemented")
 }
+func (UnimplementedNodeRegistryAPIServer) LabelNode(context.Context, *LabelNodeRequest) (*BoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LabelNode not implemented")
+}
+func (UnimplementedNodeRegistryAPIServer) TaintNode(context.Context, *TaintNodeRequest) (*BoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TaintNode not implemented")
+}
+func (UnimplementedNodeRegistryAPIServer) CordonNode(context.Context, *NodeID) (*BoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CordonNode not implemented")
+}
+func (UnimplementedNodeRegistryAPIServer) UncordonNode(context.Context, *NodeID) (*BoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UncordonNode not implemented")
+}
+func (UnimplementedNodeRegistryAPIServer) DrainNode(context.Context, *NodeID) (*BoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DrainNode not implemented")
+}
 func (UnimplementedNodeRegistryAPIServer) mustEmbedUnimplementedNodeRegistryAPIServer() {}
 func (UnimplementedNodeRegistryAPIServer) testEmbeddedByValue()                         {}
 
@@ -240,6 +320,96 @@ func _NodeRegistryAPI_ListNodes_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeRegistryAPI_LabelNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeRegistryAPIServer).LabelNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeRegistryAPI_LabelNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeRegistryAPIServer).LabelNode(ctx, req.(*LabelNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeRegistryAPI_TaintNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeRegistryAPIServer).TaintNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeRegistryAPI_TaintNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeRegistryAPIServer).TaintNode(ctx, req.(*TaintNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeRegistryAPI_CordonNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeRegistryAPIServer).CordonNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeRegistryAPI_CordonNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeRegistryAPIServer).CordonNode(ctx, req.(*NodeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeRegistryAPI_UncordonNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeRegistryAPIServer).UncordonNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeRegistryAPI_UncordonNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeRegistryAPIServer).UncordonNode(ctx, req.(*NodeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeRegistryAPI_DrainNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeRegistryAPIServer).DrainNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeRegistryAPI_DrainNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeRegistryAPIServer).DrainNode(ctx, req.(*NodeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NodeRegistryAPI_ServiceDesc is the grpc.ServiceDesc for NodeRegistryAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +437,26 @@ var NodeRegistryAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListNodes",
 			Handler:    _NodeRegistryAPI_ListNodes_Handler,
 		},
+		{
+			MethodName: "LabelNode",
+			Handler:    _NodeRegistryAPI_LabelNode_Handler,
+		},
+		{
+			MethodName: "TaintNode",
+			Handler:    _NodeRegistryAPI_TaintNode_Handler,
+		},
+		{
+			MethodName: "CordonNode",
+			Handler:    _NodeRegistryAPI_CordonNode_Handler,
+		},
+		{
+			MethodName: "UncordonNode",
+			Handler:    _NodeRegistryAPI_UncordonNode_Handler,
+		},
+		{
+			MethodName: "DrainNode",
+			Handler:    _NodeRegistryAPI_DrainNode_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/node.proto",
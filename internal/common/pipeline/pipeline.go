@@ -0,0 +1,216 @@
+// Package pipeline describes a DAG of registered models that is run as one
+// inference call, such as a detector feeding a classifier.
+//
+// The control plane stores a Spec with each pipeline and ships it to agents as
+// JSON in every heartbeat. The agent that receives an inference request for a
+// pipeline runs its steps in dependency order: steps whose model has a replica
+// on the node run in-process, the others are forwarded to peers.
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Input is the source name of the pipeline's own inputs. A step cannot be
+// named after it.
+const Input = "input"
+
+// Spec is the DAG of a pipeline.
+type Spec struct {
+	Steps []Step `json:"steps" yaml:"steps"`
+	// Outputs are the step outputs returned by the pipeline, as "<step>" for
+	// a step's first output or "<step>.<output>". Empty returns every output of
+	// the steps no other step reads from.
+	Outputs []string `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// Step runs one model.
+type Step struct {
+	Name string `json:"name" yaml:"name"`
+	// Model is the name of the model in the pipeline's namespace. The
+	// control plane resolves it to ModelID when the pipeline is registered.
+	Model   string `json:"model,omitempty" yaml:"model,omitempty"`
+	ModelID string `json:"model_id,omitempty" yaml:"model_id,omitempty"`
+	// Inputs bind the model's inputs. A step without bindings gets the
+	// inputs of the pipeline request as they are.
+	Inputs []Binding `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+}
+
+// Binding feeds one input of a step's model.
+type Binding struct {
+	// Input is the name of the model input. Empty binds the model's only
+	// input.
+	Input string `json:"input,omitempty" yaml:"input,omitempty"`
+	// From is "input" for the first input of the pipeline request,
+	// "input.<name>" for a named one, "<step>" for the first output of an
+	// earlier step or "<step>.<output>" for a named one.
+	From string `json:"from" yaml:"from"`
+}
+
+// Ref is a parsed source or output reference.
+type Ref struct {
+	Step   string // Input for the pipeline's inputs
+	Output string // Empty for the first tensor
+}
+
+// ParseRef splits a reference at its first dot.
+func ParseRef(s string) Ref {
+	step, output, _ := strings.Cut(s, ".")
+	return Ref{Step: step, Output: output}
+}
+
+func (r Ref) String() string {
+	if r.Output == "" {
+		return r.Step
+	}
+	return r.Step + "." + r.Output
+}
+
+// Validate checks that step names are unique, every step has a model, every
+// reference names the pipeline input or a step, and the steps do not form a
+// cycle.
+func (s *Spec) Validate() error {
+	if s == nil || len(s.Steps) == 0 {
+		return errors.New("pipeline must have at least one step")
+	}
+
+	names := make(map[string]bool, len(s.Steps))
+	for i, st := range s.Steps {
+		switch {
+		case st.Name == "":
+			return fmt.Errorf("steps[%d]: name is required", i)
+		case st.Name == Input:
+			return fmt.Errorf("steps[%d]: name %q is reserved for the pipeline's inputs", i, Input)
+		case strings.Contains(st.Name, "."):
+			return fmt.Errorf("steps[%d]: name %q cannot contain '.'", i, st.Name)
+		case names[st.Name]:
+			return fmt.Errorf("steps[%d]: duplicate step name %q", i, st.Name)
+		case st.Model == "" && st.ModelID == "":
+			return fmt.Errorf("step %q: model is required", st.Name)
+		}
+		names[st.Name] = true
+	}
+
+	for _, st := range s.Steps {
+		inputs := make(map[string]bool, len(st.Inputs))
+		for j, b := range st.Inputs {
+			if inputs[b.Input] {
+				return fmt.Errorf("step %q: input %q is bound twice", st.Name, b.Input)
+			}
+			inputs[b.Input] = true
+			if b.Input == "" && len(st.Inputs) > 1 {
+				return fmt.Errorf("step %q: inputs[%d]: input name is required when a step binds several inputs", st.Name, j)
+			}
+
+			ref := ParseRef(b.From)
+			switch {
+			case b.From == "":
+				return fmt.Errorf("step %q: inputs[%d]: from is required", st.Name, j)
+			case ref.Step == st.Name:
+				return fmt.Errorf("step %q: inputs[%d]: step cannot read its own output", st.Name, j)
+			case ref.Step != Input && !names[ref.Step]:
+				return fmt.Errorf("step %q: inputs[%d]: unknown step %q in %q", st.Name, j, ref.Step, b.From)
+			}
+		}
+	}
+
+	for i, out := range s.Outputs {
+		ref := ParseRef(out)
+		if ref.Step == Input || !names[ref.Step] {
+			return fmt.Errorf("outputs[%d]: %q does not name a step", i, out)
+		}
+	}
+
+	_, err := s.Levels()
+	return err
+}
+
+// Levels groups the indexes of the steps by dependency: every step comes
+// after the steps it reads from, and the steps of a level do not depend on
+// each other, so they can run concurrently. Steps keep their declared order
+// within a level.
+func (s *Spec) Levels() ([][]int, error) {
+	index := make(map[string]int, len(s.Steps))
+	for i, st := range s.Steps {
+		index[st.Name] = i
+	}
+
+	waiting := make([]int, len(s.Steps)) // Unfinished steps each step reads from
+	readers := make([][]int, len(s.Steps))
+	for i, dep := range s.dependencies(index) {
+		waiting[i] = len(dep)
+		for _, d := range dep {
+			readers[d] = append(readers[d], i)
+		}
+	}
+
+	var levels [][]int
+	var level []int
+	for i := range s.Steps {
+		if waiting[i] == 0 {
+			level = append(level, i)
+		}
+	}
+	done := 0
+	for len(level) > 0 {
+		levels = append(levels, level)
+		done += len(level)
+		var next []int
+		for _, i := range level {
+			for _, r := range readers[i] {
+				if waiting[r]--; waiting[r] == 0 {
+					next = append(next, r)
+				}
+			}
+		}
+		slices.Sort(next)
+		level = next
+	}
+
+	if done < len(s.Steps) {
+		var cycle []string
+		for i, st := range s.Steps {
+			if waiting[i] > 0 {
+				cycle = append(cycle, st.Name)
+			}
+		}
+		return nil, fmt.Errorf("steps %s form a cycle", strings.Join(cycle, ", "))
+	}
+	return levels, nil
+}
+
+// dependencies returns the indexes of the steps each step reads from, once
+// each.
+func (s *Spec) dependencies(index map[string]int) [][]int {
+	deps := make([][]int, len(s.Steps))
+	for i, st := range s.Steps {
+		for _, b := range st.Inputs {
+			d, ok := index[ParseRef(b.From).Step]
+			if ok && !slices.Contains(deps[i], d) {
+				deps[i] = append(deps[i], d)
+			}
+		}
+	}
+	return deps
+}
+
+// Sinks returns the names of the steps no other step reads from, in declared
+// order.
+func (s *Spec) Sinks() []string {
+	read := make(map[string]bool)
+	for _, st := range s.Steps {
+		for _, b := range st.Inputs {
+			read[ParseRef(b.From).Step] = true
+		}
+	}
+	var out []string
+	for _, st := range s.Steps {
+		if !read[st.Name] {
+			out = append(out, st.Name)
+		}
+	}
+	return out
+}
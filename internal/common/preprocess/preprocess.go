@@ -0,0 +1,205 @@
+// Package preprocess describes how a model's raw features are transformed
+// before inference and how its prediction is mapped back afterwards.
+//
+// The control plane stores a Spec with each model and ships it to agents as
+// JSON in the DeployModelRequest; agents apply it to every request made with
+// scaling enabled.
+package preprocess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Kind selects the transform applied to a feature or the target.
+type Kind string
+
+const (
+	// None passes the value through unchanged.
+	None Kind = "none"
+	// Standard scales a value to (x - mean) / scale.
+	Standard Kind = "standard"
+	// MinMax scales a value to (x - min) / (max - min).
+	MinMax Kind = "minmax"
+	// OneHot expands a categorical value into one column per category,
+	// set to 1 for the matching category. Unknown values encode as all zeros.
+	OneHot Kind = "onehot"
+)
+
+// Feature is the transform of one column of the raw input.
+type Feature struct {
+	Name       string    `json:"name,omitempty" yaml:"name,omitempty"`
+	Type       Kind      `json:"type" yaml:"type"`
+	Mean       float64   `json:"mean,omitempty" yaml:"mean,omitempty"`
+	Scale      float64   `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Min        float64   `json:"min,omitempty" yaml:"min,omitempty"`
+	Max        float64   `json:"max,omitempty" yaml:"max,omitempty"`
+	Categories []float64 `json:"categories,omitempty" yaml:"categories,omitempty"` // OneHot only
+}
+
+// Target maps the model's prediction back to the scale of the training target.
+// It is the inverse of a Standard or MinMax transform.
+type Target struct {
+	Type  Kind    `json:"type" yaml:"type"`
+	Mean  float64 `json:"mean,omitempty" yaml:"mean,omitempty"`
+	Scale float64 `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Min   float64 `json:"min,omitempty" yaml:"min,omitempty"`
+	Max   float64 `json:"max,omitempty" yaml:"max,omitempty"`
+}
+
+// Spec is the preprocessing and postprocessing of a model. Features lists one
+// transform per column of the raw input rows; Target, if set, is applied to the
+// model's first output.
+type Spec struct {
+	// Input is the model input the features feed. Empty means the only input.
+	Input    string    `json:"input,omitempty" yaml:"input,omitempty"`
+	Features []Feature `json:"features,omitempty" yaml:"features,omitempty"`
+	Target   *Target   `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+// Parse decodes and validates a JSON-encoded Spec. An empty string means the
+// model has no preprocessing and returns nil.
+func Parse(raw string) (*Spec, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var spec Spec
+	if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+		return nil, fmt.Errorf("invalid preprocessing: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid preprocessing: %w", err)
+	}
+	return &spec, nil
+}
+
+// Encode returns the JSON form of the Spec, or "" for a nil Spec.
+func (s *Spec) Encode() (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("encode preprocessing: %w", err)
+	}
+	return string(b), nil
+}
+
+// Validate checks every transform. A nil Spec is valid.
+func (s *Spec) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if len(s.Features) == 0 && s.Target == nil {
+		return errors.New("preprocessing: features or target is required")
+	}
+	for i, f := range s.Features {
+		if err := validate(f.Type, f.Scale, f.Min, f.Max); err != nil {
+			return fmt.Errorf("preprocessing: features[%d]: %w", i, err)
+		}
+		if f.Type == OneHot {
+			if len(f.Categories) == 0 {
+				return fmt.Errorf("preprocessing: features[%d]: onehot needs categories", i)
+			}
+			sorted := slices.Clone(f.Categories)
+			slices.Sort(sorted)
+			if len(slices.Compact(sorted)) != len(f.Categories) {
+				return fmt.Errorf("preprocessing: features[%d]: duplicate categories", i)
+			}
+		}
+	}
+	if t := s.Target; t != nil {
+		if t.Type == OneHot {
+			return errors.New("preprocessing: target: onehot cannot be inverted")
+		}
+		if err := validate(t.Type, t.Scale, t.Min, t.Max); err != nil {
+			return fmt.Errorf("preprocessing: target: %w", err)
+		}
+	}
+	return nil
+}
+
+func validate(kind Kind, scale, lo, hi float64) error {
+	switch kind {
+	case None, OneHot:
+	case Standard:
+		if scale == 0 {
+			return errors.New("standard needs a non-zero scale")
+		}
+	case MinMax:
+		if hi <= lo {
+			return fmt.Errorf("minmax needs max above min, got min %v max %v", lo, hi)
+		}
+	default:
+		return fmt.Errorf("unknown type %q (expected none|standard|minmax|onehot)", kind)
+	}
+	return nil
+}
+
+// Width returns the number of columns a raw row has after the transforms.
+func (s *Spec) Width() int {
+	n := 0
+	for _, f := range s.Features {
+		if f.Type == OneHot {
+			n += len(f.Categories)
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// Transform applies the feature transforms to raw rows laid out one after the
+// other, each holding one value per feature. It returns the transformed rows,
+// each Width values long. Without features the data is returned as is.
+func (s *Spec) Transform(data []float32) ([]float32, error) {
+	if len(s.Features) == 0 {
+		return data, nil
+	}
+	if len(data)%len(s.Features) != 0 {
+		return nil, fmt.Errorf("rows must hold %d features, got %d values", len(s.Features), len(data))
+	}
+
+	out := make([]float32, 0, len(data)/len(s.Features)*s.Width())
+	for row := 0; row < len(data); row += len(s.Features) {
+		for i, f := range s.Features {
+			x := float64(data[row+i])
+			switch f.Type {
+			case Standard:
+				out = append(out, float32((x-f.Mean)/f.Scale))
+			case MinMax:
+				out = append(out, float32((x-f.Min)/(f.Max-f.Min)))
+			case OneHot:
+				for _, c := range f.Categories {
+					if x == c {
+						out = append(out, 1)
+					} else {
+						out = append(out, 0)
+					}
+				}
+			default:
+				out = append(out, float32(x))
+			}
+		}
+	}
+	return out, nil
+}
+
+// InverseTarget maps predictions back to the target's scale in place.
+func (s *Spec) InverseTarget(data []float32) {
+	t := s.Target
+	if t == nil {
+		return
+	}
+	for i, y := range data {
+		switch t.Type {
+		case Standard:
+			data[i] = float32(float64(y)*t.Scale + t.Mean)
+		case MinMax:
+			data[i] = float32(float64(y)*(t.Max-t.Min) + t.Min)
+		}
+	}
+}
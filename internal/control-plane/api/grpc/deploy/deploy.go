@@ -0,0 +1,46 @@
+package deploycaller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/connpool"
+	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// CallDeployModel calls the agent's DeployAPI to deploy a model replica on the given node.
+func CallDeployModel(ctx context.Context, node store.NodeInfo, req *deploypb.DeployModelRequest) (*deploypb.DeployModelResponse, error) {
+	nodeAddr := fmt.Sprintf("%s:%d", node.IP, node.Port)
+
+	conn, release, err := connpool.Default().Get(nodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	client := deploypb.NewDeployAPIClient(conn)
+	resp, err := client.DeployModel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CallUndeployModel calls the agent's DeployAPI to remove a model replica from the given node.
+func CallUndeployModel(ctx context.Context, node store.NodeInfo, req *deploypb.UndeployModelRequest) (*deploypb.UndeployModelResponse, error) {
+	nodeAddr := fmt.Sprintf("%s:%d", node.IP, node.Port)
+
+	conn, release, err := connpool.Default().Get(nodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	client := deploypb.NewDeployAPIClient(conn)
+	resp, err := client.UndeployModel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
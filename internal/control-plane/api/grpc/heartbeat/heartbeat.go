@@ -4,26 +4,27 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/kennethnrk/edgernetes-ai/internal/common/connpool"
 	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
 	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
-// CallHeartbeat calls the heartbeat API to get the heartbeat response.
-func CallHeartbeat(node store.NodeInfo, endpoints []*heartbeatpb.ServiceEndpoints) (*heartbeatpb.RequestHeartbeatResponse, error) {
+// CallHeartbeat calls the heartbeat API to get the heartbeat response. The
+// endpoints and pipelines are pushed to the node with it.
+func CallHeartbeat(node store.NodeInfo, endpoints []*heartbeatpb.ServiceEndpoints, pipelines []*heartbeatpb.PipelineDefinition) (*heartbeatpb.RequestHeartbeatResponse, error) {
 	nodeAddr := fmt.Sprintf("%s:%d", node.IP, node.Port)
 
-	conn, err := grpc.NewClient(nodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, release, err := connpool.Default().Get(nodeAddr)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
+	defer release()
 
 	client := heartbeatpb.NewHeartbeatAPIClient(conn)
 	resp, err := client.RequestHeartbeat(context.Background(), &heartbeatpb.RequestHeartbeatRequest{
 		NodeID:           node.ID,
 		ServiceEndpoints: endpoints,
+		Pipelines:        pipelines,
 	})
 	if err != nil {
 		return nil, err
@@ -3,11 +3,14 @@ package grpcregistry
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/inputformat"
 	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/preprocess"
 	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
 	statuscontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/status"
 	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
@@ -29,7 +32,9 @@ func NewModelRegistryServer(s *store.Store) modelpb.ModelRegistryAPIServer {
 }
 
 // RegisterModel registers a new model.
-// Returns codes.InvalidArgument if the request is nil or the model name is empty.
+// Returns codes.InvalidArgument if the request is nil, the model name is empty
+// or the input format, affinity rules, tolerations, autoscaling, rollout,
+// batching or preprocessing settings are malformed.
 // Returns codes.AlreadyExists if a model with the same name is already registered.
 func (s *modelRegistryServer) RegisterModel(ctx context.Context, req *modelpb.ModelInfo) (*modelpb.BoolResponse, error) {
 	if req == nil {
@@ -42,7 +47,10 @@ func (s *modelRegistryServer) RegisterModel(ctx context.Context, req *modelpb.Mo
 	// Generate a new UUID for the model, ignoring any ID in the request
 	modelID := uuid.New().String()
 
-	modelInfo := protoToStoreModelInfo(req)
+	modelInfo, err := protoToStoreModelInfo(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	// Override the ID with the generated one
 	modelInfo.ID = modelID
 
@@ -69,13 +77,22 @@ func (s *modelRegistryServer) DeRegisterModel(ctx context.Context, req *modelpb.
 	return &modelpb.BoolResponse{Success: true}, nil
 }
 
-// UpdateModel updates an existing model.
+// UpdateModel updates an existing model. Fields the request leaves unset keep
+// their stored values, so a request that only sets file_path changes the
+// artifact alone; that records a new revision, which the rollout controller
+// then rolls out.
+// Returns codes.InvalidArgument if the input format, affinity rules,
+// tolerations, autoscaling, rollout, batching or preprocessing settings are
+// malformed.
 func (s *modelRegistryServer) UpdateModel(ctx context.Context, req *modelpb.UpdateModelRequest) (*modelpb.BoolResponse, error) {
 	if req == nil || req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "model ID cannot be empty")
 	}
 
-	modelInfo := updateRequestToStoreModelInfo(req)
+	modelInfo, err := updateRequestToStoreModelInfo(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err := registrycontroller.UpdateModelInfo(s.store, req.Id, modelInfo); err != nil {
 		return &modelpb.BoolResponse{Success: false}, status.Error(codes.Internal, err.Error())
 	}
@@ -173,61 +190,356 @@ func (s *modelRegistryServer) GetNodesByModelName(ctx context.Context, req *mode
 	}, nil
 }
 
+// GetRolloutStatus reports how far a model's replicas have been replaced with
+// its current revision, along with its revision history.
+// Returns codes.NotFound if the model does not exist.
+func (s *modelRegistryServer) GetRolloutStatus(ctx context.Context, req *modelpb.ModelID) (*modelpb.RolloutStatusResponse, error) {
+	if req == nil || req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "model ID cannot be empty")
+	}
+
+	result, err := statuscontroller.GetRolloutStatus(s.store, req.GetId())
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &modelpb.RolloutStatusResponse{
+		ModelId:         result.ModelID,
+		Revision:        int32(result.Revision),
+		DesiredReplicas: int32(result.DesiredReplicas),
+		UpdatedReplicas: int32(result.UpdatedReplicas),
+		UpdatedRunning:  int32(result.UpdatedRunning),
+		OldReplicas:     int32(result.OldReplicas),
+		OldRunning:      int32(result.OldRunning),
+		Complete:        result.Complete(),
+	}
+	for _, r := range result.Revisions {
+		resp.Revisions = append(resp.Revisions, &modelpb.ModelRevision{
+			Revision:    int32(r.Revision),
+			Version:     r.Version,
+			FilePath:    r.FilePath,
+			Sha256Hash:  r.SHA256Hash,
+			ModelType:   string(r.ModelType),
+			ModelSize:   r.ModelSize,
+			CreatedAt:   r.CreatedAt.Unix(),
+			ChangeCause: r.ChangeCause,
+		})
+	}
+	return resp, nil
+}
+
+// UndoRollout rolls a model back to an earlier revision. The rollback is
+// recorded as a new revision and rolled out like an update.
+// Returns codes.NotFound if the model or revision does not exist and
+// codes.FailedPrecondition if there is no earlier revision to return to.
+func (s *modelRegistryServer) UndoRollout(ctx context.Context, req *modelpb.UndoRolloutRequest) (*modelpb.UndoRolloutResponse, error) {
+	if req == nil || req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "model ID cannot be empty")
+	}
+	if req.GetToRevision() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "to_revision cannot be negative")
+	}
+
+	revision, err := registrycontroller.RollbackModel(s.store, req.GetId(), int(req.GetToRevision()))
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case strings.Contains(err.Error(), "no previous revision"), strings.Contains(err.Error(), "already current"):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &modelpb.UndoRolloutResponse{Success: true, Revision: int32(revision)}, nil
+}
+
 // protoToStoreModelInfo converts a proto ModelInfo to a store ModelInfo.
-func protoToStoreModelInfo(pb *modelpb.ModelInfo) store.ModelInfo {
+func protoToStoreModelInfo(pb *modelpb.ModelInfo) (store.ModelInfo, error) {
 	info := store.ModelInfo{
-		ID:        pb.GetId(),
-		Name:      pb.GetName(),
-		Namespace: pb.GetNamespace(),
-		Version:   pb.GetVersion(),
-		FilePath:  pb.GetFilePath(),
-		ModelType: constants.ModelType(pb.GetModelType()),
-		ModelSize: pb.GetModelSize(),
-		Replicas:  int(pb.GetReplicas()),
+		ID:           pb.GetId(),
+		Name:         pb.GetName(),
+		Namespace:    pb.GetNamespace(),
+		Version:      pb.GetVersion(),
+		FilePath:     pb.GetFilePath(),
+		SHA256Hash:   pb.GetSha256Hash(),
+		ModelType:    constants.ModelType(pb.GetModelType()),
+		ModelSize:    pb.GetModelSize(),
+		Replicas:     int(pb.GetReplicas()),
+		MinAvailable: int(pb.GetMinAvailable()),
+		NodeSelector: pb.GetNodeSelector(),
 	}
 
 	// Convert input_format string to json.RawMessage
 	if inputFormat := pb.GetInputFormat(); inputFormat != "" {
+		if _, err := inputformat.Parse([]byte(inputFormat)); err != nil {
+			return store.ModelInfo{}, err
+		}
 		info.InputFormat = json.RawMessage(inputFormat)
 	}
 
-	return info
+	affinity, err := decodeAffinity(pb.GetAffinity())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Affinity = affinity
+
+	tolerations, err := protoToStoreTolerations(pb.GetTolerations())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Tolerations = tolerations
+
+	autoscaling, err := protoToStoreAutoscaling(pb.GetAutoscaling())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Autoscaling = autoscaling
+
+	rollout, err := protoToStoreRollout(pb.GetRollout())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Rollout = rollout
+
+	batching, err := protoToStoreBatching(pb.GetBatching())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Batching = batching
+
+	prep, err := preprocess.Parse(pb.GetPreprocessing())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Preprocessing = prep
+
+	info.LoadBalancer = constants.LoadBalancer(pb.GetLoadBalancer())
+	if err := store.ValidateLoadBalancer(info.LoadBalancer); err != nil {
+		return store.ModelInfo{}, err
+	}
+
+	return info, nil
 }
 
 // updateRequestToStoreModelInfo converts an UpdateModelRequest to a store ModelInfo.
-func updateRequestToStoreModelInfo(req *modelpb.UpdateModelRequest) store.ModelInfo {
+func updateRequestToStoreModelInfo(req *modelpb.UpdateModelRequest) (store.ModelInfo, error) {
 	info := store.ModelInfo{
-		ID:        req.GetId(),
-		Name:      req.GetName(),
-		Namespace: req.GetNamespace(),
-		Version:   req.GetVersion(),
-		FilePath:  req.GetFilePath(),
-		ModelType: constants.ModelType(req.GetModelType()),
-		ModelSize: req.GetModelSize(),
-		Replicas:  int(req.GetReplicas()),
+		ID:           req.GetId(),
+		Name:         req.GetName(),
+		Namespace:    req.GetNamespace(),
+		Version:      req.GetVersion(),
+		FilePath:     req.GetFilePath(),
+		SHA256Hash:   req.GetSha256Hash(),
+		ModelType:    constants.ModelType(req.GetModelType()),
+		ModelSize:    req.GetModelSize(),
+		Replicas:     int(req.GetReplicas()),
+		MinAvailable: int(req.GetMinAvailable()),
+		NodeSelector: req.GetNodeSelector(),
 	}
 
 	// Convert input_format string to json.RawMessage
 	if inputFormat := req.GetInputFormat(); inputFormat != "" {
+		if _, err := inputformat.Parse([]byte(inputFormat)); err != nil {
+			return store.ModelInfo{}, err
+		}
 		info.InputFormat = json.RawMessage(inputFormat)
 	}
 
-	return info
+	affinity, err := decodeAffinity(req.GetAffinity())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Affinity = affinity
+
+	tolerations, err := protoToStoreTolerations(req.GetTolerations())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Tolerations = tolerations
+
+	autoscaling, err := protoToStoreAutoscaling(req.GetAutoscaling())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Autoscaling = autoscaling
+
+	rollout, err := protoToStoreRollout(req.GetRollout())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Rollout = rollout
+
+	batching, err := protoToStoreBatching(req.GetBatching())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Batching = batching
+
+	prep, err := preprocess.Parse(req.GetPreprocessing())
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	info.Preprocessing = prep
+
+	info.LoadBalancer = constants.LoadBalancer(req.GetLoadBalancer())
+	if err := store.ValidateLoadBalancer(info.LoadBalancer); err != nil {
+		return store.ModelInfo{}, err
+	}
+
+	return info, nil
 }
 
 // storeModelInfoToProto converts a store ModelInfo to a proto ModelInfo.
 func storeModelInfoToProto(info *store.ModelInfo) *modelpb.ModelInfo {
 	pb := &modelpb.ModelInfo{
-		Id:          info.ID,
-		Name:        info.Name,
-		Namespace:   info.Namespace,
-		Version:     info.Version,
-		FilePath:    info.FilePath,
-		ModelType:   string(info.ModelType),
-		ModelSize:   info.ModelSize,
-		Replicas:    int32(info.Replicas),
-		InputFormat: string(info.InputFormat),
+		Id:              info.ID,
+		Name:            info.Name,
+		Namespace:       info.Namespace,
+		Version:         info.Version,
+		FilePath:        info.FilePath,
+		ModelType:       string(info.ModelType),
+		ModelSize:       info.ModelSize,
+		Replicas:        int32(info.Replicas),
+		DesiredReplicas: int32(info.DesiredReplicas()),
+		Revision:        int32(info.Revision),
+		MinAvailable:    int32(info.MinAvailable),
+		InputFormat:     string(info.InputFormat),
+		Sha256Hash:      info.SHA256Hash,
+		NodeSelector:    info.NodeSelector,
+		LoadBalancer:    string(info.LoadBalancer),
+	}
+
+	if a := info.Autoscaling; a != nil {
+		pb.Autoscaling = &modelpb.Autoscaling{
+			MinReplicas:        int32(a.MinReplicas),
+			MaxReplicas:        int32(a.MaxReplicas),
+			TargetQueueDepth:   a.TargetQueueDepth,
+			TargetP95LatencyMs: a.TargetP95LatencyMs,
+		}
+	}
+
+	if r := info.Rollout; r != nil {
+		pb.Rollout = &modelpb.RolloutStrategy{
+			MaxSurge:       int32(r.MaxSurge),
+			MaxUnavailable: int32(r.MaxUnavailable),
+		}
+	}
+
+	if b := info.Batching; b != nil {
+		pb.Batching = &modelpb.Batching{
+			MaxBatchSize:   int32(b.MaxBatchSize),
+			MaxBatchWaitMs: int32(b.MaxBatchWaitMs),
+		}
+	}
+
+	for _, t := range info.Tolerations {
+		pb.Tolerations = append(pb.Tolerations, &modelpb.Toleration{
+			Key:      t.Key,
+			Operator: t.Operator,
+			Value:    t.Value,
+			Effect:   t.Effect,
+		})
+	}
+
+	if info.Affinity != nil {
+		if b, err := json.Marshal(info.Affinity); err == nil {
+			pb.Affinity = string(b)
+		}
+	}
+
+	if raw, err := info.Preprocessing.Encode(); err == nil {
+		pb.Preprocessing = raw
 	}
 
 	return pb
 }
+
+// protoToStoreAutoscaling converts and validates the autoscaling settings of a
+// model. A nil message means the model is not autoscaled.
+func protoToStoreAutoscaling(pb *modelpb.Autoscaling) (*store.Autoscaling, error) {
+	if pb == nil {
+		return nil, nil
+	}
+	autoscaling := &store.Autoscaling{
+		MinReplicas:        int(pb.GetMinReplicas()),
+		MaxReplicas:        int(pb.GetMaxReplicas()),
+		TargetQueueDepth:   pb.GetTargetQueueDepth(),
+		TargetP95LatencyMs: pb.GetTargetP95LatencyMs(),
+	}
+	if err := autoscaling.Validate(); err != nil {
+		return nil, err
+	}
+	return autoscaling, nil
+}
+
+// protoToStoreRollout converts and validates the rollout strategy of a model.
+// A nil message means the default limits apply.
+func protoToStoreRollout(pb *modelpb.RolloutStrategy) (*store.RolloutStrategy, error) {
+	if pb == nil {
+		return nil, nil
+	}
+	rollout := &store.RolloutStrategy{
+		MaxSurge:       int(pb.GetMaxSurge()),
+		MaxUnavailable: int(pb.GetMaxUnavailable()),
+	}
+	if err := rollout.Validate(); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+// protoToStoreBatching converts and validates the batching settings of a model.
+// A nil message means requests are not batched.
+func protoToStoreBatching(pb *modelpb.Batching) (*store.Batching, error) {
+	if pb == nil {
+		return nil, nil
+	}
+	batching := &store.Batching{
+		MaxBatchSize:   int(pb.GetMaxBatchSize()),
+		MaxBatchWaitMs: int(pb.GetMaxBatchWaitMs()),
+	}
+	if err := batching.Validate(); err != nil {
+		return nil, err
+	}
+	return batching, nil
+}
+
+// decodeAffinity parses and validates the JSON-encoded affinity of a model.
+// An empty string means the model has no affinity rules.
+func decodeAffinity(raw string) (*store.Affinity, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var affinity store.Affinity
+	if err := json.Unmarshal([]byte(raw), &affinity); err != nil {
+		return nil, fmt.Errorf("invalid affinity: %w", err)
+	}
+	if err := affinity.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid affinity: %w", err)
+	}
+	return &affinity, nil
+}
+
+// protoToStoreTolerations converts and validates the tolerations of a model.
+func protoToStoreTolerations(tolerations []*modelpb.Toleration) ([]store.Toleration, error) {
+	if len(tolerations) == 0 {
+		return nil, nil
+	}
+	out := make([]store.Toleration, len(tolerations))
+	for i, t := range tolerations {
+		out[i] = store.Toleration{
+			Key:      t.GetKey(),
+			Operator: t.GetOperator(),
+			Value:    t.GetValue(),
+			Effect:   t.GetEffect(),
+		}
+		if err := out[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid toleration: %w", err)
+		}
+	}
+	return out, nil
+}
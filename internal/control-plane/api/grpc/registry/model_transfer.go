@@ -45,8 +45,9 @@ func NewModelTransferServer(s *store.Store, modelDir string) deploypb.ModelTrans
 // DownloadModel streams a model file to the requesting agent in sequential 2MB chunks.
 //
 // The model's file path is resolved from the model registry using the provided
-// model_id. The agent may specify a resume_byte_offset to restart a broken
-// download without starting from zero.
+// model_id, from the requested revision when one is given. The agent may
+// specify a resume_byte_offset to restart a broken download without starting
+// from zero.
 //
 // The stream runs synchronously within the goroutine spawned by the gRPC server
 // for each incoming RPC, so transfer concurrency is naturally managed by the
@@ -71,6 +72,15 @@ func (s *modelTransferServer) DownloadModel(req *deploypb.ModelDownloadRequest,
 		return status.Errorf(codes.NotFound, "model %q not found in registry", modelID)
 	}
 
+	// Replicas of an earlier revision, e.g. during a rollout or after a
+	// rollback, must get the artifact they were deployed with.
+	if revision := int(req.GetRevision()); revision > 0 && revision != modelInfo.Revision {
+		if _, ok := modelInfo.FindRevision(revision); !ok {
+			return status.Errorf(codes.NotFound, "revision %d of model %q not found", revision, modelID)
+		}
+		modelInfo = modelInfo.AtRevision(revision)
+	}
+
 	// Resolve the file path. FilePath in the registry may be relative or absolute.
 	filePath := filepath.Clean(modelInfo.FilePath)
 	file, err := os.Open(filePath)
@@ -114,13 +114,113 @@ func (s *nodeRegistryServer) ListNodes(ctx context.Context, req *nodepb.None) (*
 	return &nodepb.ListNodesResponse{Nodes: protoNodes}, nil
 }
 
+// LabelNode sets and removes labels on a node.
+func (s *nodeRegistryServer) LabelNode(ctx context.Context, req *nodepb.LabelNodeRequest) (*nodepb.BoolResponse, error) {
+	if req == nil || req.GetNodeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "node ID cannot be empty")
+	}
+	for key := range req.GetLabels() {
+		if key == "" {
+			return nil, status.Error(codes.InvalidArgument, "label key cannot be empty")
+		}
+	}
+
+	_, found, err := registrycontroller.GetNodeByID(s.store, req.GetNodeId())
+	if err != nil {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.NotFound, "node not found")
+	}
+
+	if err := registrycontroller.SetNodeLabels(s.store, req.GetNodeId(), req.GetLabels(), req.GetRemove()); err != nil {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &nodepb.BoolResponse{Success: true}, nil
+}
+
+// TaintNode adds and removes taints on a node.
+// Returns codes.InvalidArgument if a taint to add has no key or an unknown effect.
+func (s *nodeRegistryServer) TaintNode(ctx context.Context, req *nodepb.TaintNodeRequest) (*nodepb.BoolResponse, error) {
+	if req == nil || req.GetNodeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "node ID cannot be empty")
+	}
+	add := protoToStoreTaints(req.GetAdd())
+	for _, t := range add {
+		if err := t.Validate(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	remove := protoToStoreTaints(req.GetRemove())
+	for _, t := range remove {
+		if t.Key == "" {
+			return nil, status.Error(codes.InvalidArgument, "taint key cannot be empty")
+		}
+	}
+
+	_, found, err := registrycontroller.GetNodeByID(s.store, req.GetNodeId())
+	if err != nil {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.NotFound, "node not found")
+	}
+
+	if err := registrycontroller.SetNodeTaints(s.store, req.GetNodeId(), add, remove); err != nil {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &nodepb.BoolResponse{Success: true}, nil
+}
+
+// CordonNode marks a node unschedulable.
+func (s *nodeRegistryServer) CordonNode(ctx context.Context, req *nodepb.NodeID) (*nodepb.BoolResponse, error) {
+	return s.setScheduling(req, registrycontroller.CordonNode)
+}
+
+// UncordonNode makes a node schedulable again and cancels a drain in progress.
+func (s *nodeRegistryServer) UncordonNode(ctx context.Context, req *nodepb.NodeID) (*nodepb.BoolResponse, error) {
+	return s.setScheduling(req, registrycontroller.UncordonNode)
+}
+
+// DrainNode cordons a node and marks it for draining. It returns immediately;
+// replicas are migrated by the drain controller and the node reports
+// draining=false once it is empty.
+func (s *nodeRegistryServer) DrainNode(ctx context.Context, req *nodepb.NodeID) (*nodepb.BoolResponse, error) {
+	return s.setScheduling(req, registrycontroller.DrainNode)
+}
+
+// setScheduling applies a cordon/uncordon/drain update to an existing node.
+func (s *nodeRegistryServer) setScheduling(req *nodepb.NodeID, update func(*store.Store, string) error) (*nodepb.BoolResponse, error) {
+	if req == nil || req.GetNodeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "node ID cannot be empty")
+	}
+
+	_, found, err := registrycontroller.GetNodeByID(s.store, req.GetNodeId())
+	if err != nil {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.NotFound, "node not found")
+	}
+
+	if err := update(s.store, req.GetNodeId()); err != nil {
+		return &nodepb.BoolResponse{Success: false}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &nodepb.BoolResponse{Success: true}, nil
+}
+
 // protoToStoreNodeInfo converts a proto NodeInfo to a store NodeInfo.
 func protoToStoreNodeInfo(pb *nodepb.NodeInfo) store.NodeInfo {
 	info := store.NodeInfo{
-		ID:   pb.GetNodeId(),
-		Name: pb.GetName(),
-		IP:   pb.GetIp(),
-		Port: int(pb.GetPort()),
+		ID:     pb.GetNodeId(),
+		Name:   pb.GetName(),
+		IP:     pb.GetIp(),
+		Port:   int(pb.GetPort()),
+		Labels: pb.GetLabels(),
+		Taints: protoToStoreTaints(pb.GetTaints()),
 	}
 
 	if pb.GetMetadata() != nil {
@@ -231,6 +331,29 @@ func storeNodeInfoToProto(info *store.NodeInfo) *nodepb.NodeInfo {
 		Name:   info.Name,
 		Ip:     info.IP,
 		Port:   int32(info.Port),
+		Labels: info.Labels,
+		Taints: storeTaintsToProto(info.Taints),
+
+		Unschedulable: info.Unschedulable,
+		Draining:      info.Draining,
+		ReplicaCount:  int32(len(info.AssignedModels)),
+	}
+
+	for _, p := range info.Peers {
+		peer := &nodepb.PeerStats{
+			NodeId:              p.NodeID,
+			Address:             p.Address,
+			Breaker:             p.Breaker,
+			ConsecutiveFailures: int32(p.ConsecutiveFailures),
+			Ejections:           int32(p.Ejections),
+			Requests:            p.Requests,
+			Failures:            p.Failures,
+			Retries:             p.Retries,
+		}
+		if !p.EjectedUntil.IsZero() {
+			peer.EjectedUntil = p.EjectedUntil.Unix()
+		}
+		pb.Peers = append(pb.Peers, peer)
 	}
 
 	// Convert Metadata
@@ -276,3 +399,27 @@ func storeNodeInfoToProto(info *store.NodeInfo) *nodepb.NodeInfo {
 
 	return pb
 }
+
+// protoToStoreTaints converts proto taints to store taints.
+func protoToStoreTaints(taints []*nodepb.Taint) []store.Taint {
+	if len(taints) == 0 {
+		return nil
+	}
+	out := make([]store.Taint, len(taints))
+	for i, t := range taints {
+		out[i] = store.Taint{Key: t.GetKey(), Value: t.GetValue(), Effect: t.GetEffect()}
+	}
+	return out
+}
+
+// storeTaintsToProto converts store taints to proto taints.
+func storeTaintsToProto(taints []store.Taint) []*nodepb.Taint {
+	if len(taints) == 0 {
+		return nil
+	}
+	out := make([]*nodepb.Taint, len(taints))
+	for i, t := range taints {
+		out[i] = &nodepb.Taint{Key: t.Key, Value: t.Value, Effect: t.Effect}
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package grpcregistry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/pipeline"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterPipeline registers a new pipeline and returns it as stored.
+// Returns codes.InvalidArgument if the request is nil, the pipeline name is
+// empty, its steps do not form a valid DAG or a step names a model that is not
+// registered in the pipeline's namespace.
+// Returns codes.AlreadyExists if a pipeline with the same name is already registered.
+func (s *modelRegistryServer) RegisterPipeline(ctx context.Context, req *modelpb.PipelineInfo) (*modelpb.PipelineInfo, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "pipeline name cannot be empty")
+	}
+
+	info := protoToStorePipelineInfo(req)
+	if err := info.Spec.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Generate a new UUID for the pipeline, ignoring any ID in the request
+	info.ID = uuid.New().String()
+
+	if err := registrycontroller.RegisterPipeline(s.store, info.ID, info); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "already registered"):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case strings.Contains(err.Error(), "is not registered"):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	stored, _, err := registrycontroller.GetPipelineByID(s.store, info.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return storePipelineInfoToProto(&stored), nil
+}
+
+// DeRegisterPipeline removes a pipeline from the registry.
+func (s *modelRegistryServer) DeRegisterPipeline(ctx context.Context, req *modelpb.PipelineID) (*modelpb.BoolResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "pipeline ID cannot be empty")
+	}
+
+	if err := registrycontroller.DeRegisterPipeline(s.store, req.Id); err != nil {
+		return &modelpb.BoolResponse{Success: false}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &modelpb.BoolResponse{Success: true}, nil
+}
+
+// GetPipeline retrieves a pipeline by ID.
+func (s *modelRegistryServer) GetPipeline(ctx context.Context, req *modelpb.PipelineID) (*modelpb.PipelineInfo, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "pipeline ID cannot be empty")
+	}
+
+	info, found, err := registrycontroller.GetPipelineByID(s.store, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		return nil, status.Error(codes.NotFound, "pipeline not found")
+	}
+
+	return storePipelineInfoToProto(&info), nil
+}
+
+// GetPipelineByName retrieves a pipeline by namespace and name.
+func (s *modelRegistryServer) GetPipelineByName(ctx context.Context, req *modelpb.PipelineName) (*modelpb.PipelineInfo, error) {
+	if req == nil || req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "pipeline name cannot be empty")
+	}
+
+	info, found, err := registrycontroller.GetPipelineByNamespaceAndName(s.store, req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "pipeline %s not found in namespace %s", req.GetName(), req.GetNamespace())
+	}
+
+	return storePipelineInfoToProto(&info), nil
+}
+
+// ListPipelines returns all registered pipelines.
+func (s *modelRegistryServer) ListPipelines(ctx context.Context, req *modelpb.None) (*modelpb.ListPipelinesResponse, error) {
+	pipelines, err := registrycontroller.ListPipelines(s.store)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoPipelines := make([]*modelpb.PipelineInfo, len(pipelines))
+	for i := range pipelines {
+		protoPipelines[i] = storePipelineInfoToProto(&pipelines[i])
+	}
+
+	return &modelpb.ListPipelinesResponse{Pipelines: protoPipelines}, nil
+}
+
+// protoToStorePipelineInfo converts a protobuf PipelineInfo to a store.PipelineInfo.
+func protoToStorePipelineInfo(pb *modelpb.PipelineInfo) store.PipelineInfo {
+	info := store.PipelineInfo{
+		ID:        pb.GetId(),
+		Name:      pb.GetName(),
+		Namespace: pb.GetNamespace(),
+		Spec:      pipeline.Spec{Outputs: pb.GetOutputs()},
+	}
+	for _, st := range pb.GetSteps() {
+		step := pipeline.Step{Name: st.GetName(), Model: st.GetModel(), ModelID: st.GetModelId()}
+		for _, b := range st.GetInputs() {
+			step.Inputs = append(step.Inputs, pipeline.Binding{Input: b.GetInput(), From: b.GetFrom()})
+		}
+		info.Spec.Steps = append(info.Spec.Steps, step)
+	}
+	return info
+}
+
+// storePipelineInfoToProto converts a store.PipelineInfo to a protobuf PipelineInfo.
+func storePipelineInfoToProto(info *store.PipelineInfo) *modelpb.PipelineInfo {
+	pb := &modelpb.PipelineInfo{
+		Id:        info.ID,
+		Name:      info.Name,
+		Namespace: info.Namespace,
+		Outputs:   info.Spec.Outputs,
+	}
+	for _, st := range info.Spec.Steps {
+		step := &modelpb.PipelineStep{Name: st.Name, Model: st.Model, ModelId: st.ModelID}
+		for _, b := range st.Inputs {
+			step.Inputs = append(step.Inputs, &modelpb.PipelineBinding{Input: b.Input, From: b.From})
+		}
+		pb.Steps = append(pb.Steps, step)
+	}
+	return pb
+}
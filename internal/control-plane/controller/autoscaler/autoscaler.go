@@ -0,0 +1,227 @@
+package autoscalercontroller
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// Config controls how quickly the autoscaler reacts to load changes.
+type Config struct {
+	// MetricsMaxAge ignores replicas whose last heartbeat is older than this.
+	MetricsMaxAge time.Duration
+	// Tolerance is the relative deviation from the target load that does not
+	// trigger scaling, e.g. 0.1 for ±10%.
+	Tolerance float64
+	// A scale up uses the lowest recommendation seen during
+	// ScaleUpStabilization, a scale down the highest one seen during
+	// ScaleDownStabilization, so short spikes and dips are smoothed out.
+	ScaleUpStabilization   time.Duration
+	ScaleDownStabilization time.Duration
+	// After any scaling event, further scale ups wait ScaleUpCooldown and
+	// scale downs wait ScaleDownCooldown.
+	ScaleUpCooldown   time.Duration
+	ScaleDownCooldown time.Duration
+}
+
+// DefaultConfig returns the configuration used when no overrides are set.
+func DefaultConfig() Config {
+	return Config{
+		MetricsMaxAge:          30 * time.Second,
+		Tolerance:              0.1,
+		ScaleUpStabilization:   0,
+		ScaleDownStabilization: 5 * time.Minute,
+		ScaleUpCooldown:        30 * time.Second,
+		ScaleDownCooldown:      2 * time.Minute,
+	}
+}
+
+// recommendation is a replica count computed from the load at a point in time.
+type recommendation struct {
+	at       time.Time
+	replicas int
+}
+
+// Autoscaler adjusts the replica count of models with ModelInfo.Autoscaling
+// set, based on the queue depth, in-flight jobs and p95 latency their replicas
+// report through heartbeats. The chosen count is stored in
+// ModelInfo.ScaledReplicas and applied through the placement scheduler.
+type Autoscaler struct {
+	store     *store.Store
+	scheduler *placementscheduler.Scheduler
+	cfg       Config
+
+	mu      sync.Mutex
+	history map[string][]recommendation // by model ID
+}
+
+// New creates an Autoscaler that scales models through sch.
+func New(s *store.Store, sch *placementscheduler.Scheduler, cfg Config) *Autoscaler {
+	return &Autoscaler{
+		store:     s,
+		scheduler: sch,
+		cfg:       cfg,
+		history:   make(map[string][]recommendation),
+	}
+}
+
+// HandleAutoscaling runs a single autoscaling pass at time now.
+// Failures for an individual model are logged and do not stop the pass.
+func (a *Autoscaler) HandleAutoscaling(now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	models, err := registrycontroller.ListModels(a.store)
+	if err != nil {
+		return fmt.Errorf("list models: %w", err)
+	}
+
+	seen := make(map[string]bool, len(models))
+	for _, model := range models {
+		if model.Autoscaling == nil {
+			continue
+		}
+		seen[model.ID] = true
+		if err := a.scaleModel(model, now); err != nil {
+			log.Printf("[autoscaler] model %s (%s): %v", model.Name, model.ID, err)
+		}
+	}
+
+	// Forget models that were deregistered or stopped autoscaling.
+	for id := range a.history {
+		if !seen[id] {
+			delete(a.history, id)
+		}
+	}
+	return nil
+}
+
+// scaleModel records a new recommendation for model and applies the
+// stabilized replica count once its cooldown has passed.
+func (a *Autoscaler) scaleModel(model store.ModelInfo, now time.Time) error {
+	rec, ok, err := a.recommend(model, now)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	window := max(a.cfg.ScaleUpStabilization, a.cfg.ScaleDownStabilization)
+	history := append(a.history[model.ID], recommendation{at: now, replicas: rec})
+	for len(history) > 0 && now.Sub(history[0].at) > window {
+		history = history[1:]
+	}
+	a.history[model.ID] = history
+
+	current := model.DesiredReplicas()
+	target := current
+	switch {
+	case rec > current:
+		target = lowestWithin(history, now, a.cfg.ScaleUpStabilization)
+		if target <= current || now.Sub(model.LastScaleTime) < a.cfg.ScaleUpCooldown {
+			return nil
+		}
+	case rec < current:
+		target = highestWithin(history, now, a.cfg.ScaleDownStabilization)
+		if target >= current || now.Sub(model.LastScaleTime) < a.cfg.ScaleDownCooldown {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if err := registrycontroller.SetModelScale(a.store, model.ID, target, now); err != nil {
+		return fmt.Errorf("set replica count: %w", err)
+	}
+	log.Printf("[autoscaler] scaled model %s from %d to %d replica(s)", model.Name, current, target)
+	return a.scheduler.ReconcileModel(model.ID)
+}
+
+// recommend computes the replica count that would bring the load of model's
+// running replicas back to its targets. It returns false if no running
+// replica has reported recently. The latency of replicas that finished no
+// jobs recently is ignored, so an idle model is not scaled on old traffic.
+func (a *Autoscaler) recommend(model store.ModelInfo, now time.Time) (int, bool, error) {
+	replicas, err := replicascheduler.ListReplicasByModelID(a.store, model.ID)
+	if err != nil {
+		return 0, false, fmt.Errorf("list replicas: %w", err)
+	}
+
+	ready, jobs := 0, 0
+	var p95 float64
+	for _, r := range replicas {
+		if r.NodeID == "" || r.Status != constants.ModelReplicaStatusRunning || now.Sub(r.LastHeartbeat) > a.cfg.MetricsMaxAge {
+			continue
+		}
+		ready++
+		jobs += r.QueueDepth + r.InFlight
+		// The p95 of a replica without recent jobs describes past traffic.
+		if r.RecentJobs > 0 {
+			p95 = max(p95, r.P95LatencyMs)
+		}
+	}
+	if ready == 0 {
+		return 0, false, nil
+	}
+
+	spec := model.Autoscaling
+	ratio := 0.0
+	if spec.TargetQueueDepth > 0 {
+		ratio = max(ratio, float64(jobs)/float64(ready)/spec.TargetQueueDepth)
+	}
+	if spec.TargetP95LatencyMs > 0 {
+		ratio = max(ratio, p95/spec.TargetP95LatencyMs)
+	}
+
+	rec := model.DesiredReplicas()
+	if math.Abs(ratio-1) > a.cfg.Tolerance {
+		rec = int(math.Ceil(float64(ready) * ratio))
+	}
+	return min(max(rec, spec.MinReplicas), spec.MaxReplicas), true, nil
+}
+
+// lowestWithin returns the lowest recommendation made within window before now.
+func lowestWithin(history []recommendation, now time.Time, window time.Duration) int {
+	result := history[len(history)-1].replicas
+	for _, r := range history {
+		if now.Sub(r.at) <= window {
+			result = min(result, r.replicas)
+		}
+	}
+	return result
+}
+
+// highestWithin returns the highest recommendation made within window before now.
+func highestWithin(history []recommendation, now time.Time, window time.Duration) int {
+	result := history[len(history)-1].replicas
+	for _, r := range history {
+		if now.Sub(r.at) <= window {
+			result = max(result, r.replicas)
+		}
+	}
+	return result
+}
+
+// Start runs HandleAutoscaling periodically. It blocks forever and is meant to
+// be run in its own goroutine.
+func (a *Autoscaler) Start(interval time.Duration) {
+	log.Printf("Starting autoscaler with interval: %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if err := a.HandleAutoscaling(now); err != nil {
+			log.Printf("Error in autoscaler: %v", err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package draincontroller
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// HandleDrain runs a single drain pass over every node marked draining.
+//
+// For each model with replicas on a draining node, replacements are placed on
+// other nodes first. A replica is removed from the draining node only once a
+// replacement is running elsewhere, or, if no replacement can be placed, when
+// removing it keeps at least ModelInfo.MinAvailable replicas running. At most
+// one replica per model is removed per pass. A node without replicas left
+// stops draining and stays cordoned.
+func HandleDrain(s *store.Store, sch *placementscheduler.Scheduler) error {
+	nodes, err := registrycontroller.ListNodes(s)
+	if err != nil {
+		return fmt.Errorf("list nodes: %w", err)
+	}
+
+	draining := make(map[string]bool)
+	for _, n := range nodes {
+		if n.Draining {
+			draining[n.ID] = true
+		}
+	}
+
+	for _, node := range nodes {
+		if !node.Draining {
+			continue
+		}
+		if len(node.AssignedModels) == 0 {
+			if err := registrycontroller.FinishDrain(s, node.ID); err != nil {
+				log.Printf("[drain] failed to finish drain of node %s: %v", node.ID, err)
+				continue
+			}
+			log.Printf("[drain] node %s drained", node.ID)
+			continue
+		}
+		drainNode(s, sch, node, draining)
+	}
+	return nil
+}
+
+// drainNode migrates the replicas bound to node, one per model and pass.
+func drainNode(s *store.Store, sch *placementscheduler.Scheduler, node store.NodeInfo, draining map[string]bool) {
+	byModel := make(map[string][]store.ReplicaInfo)
+	for _, replicaID := range node.AssignedModels {
+		replica, found, err := replicascheduler.GetReplicaByID(s, replicaID)
+		if err != nil {
+			log.Printf("[drain] failed to load replica %s: %v", replicaID, err)
+			continue
+		}
+		if !found {
+			// The node lists a replica that no longer exists; drop the stale reference.
+			if err := registrycontroller.UnassignReplicaFromNode(s, node.ID, replicaID); err != nil {
+				log.Printf("[drain] failed to unassign missing replica %s: %v", replicaID, err)
+			}
+			continue
+		}
+		byModel[replica.ModelID] = append(byModel[replica.ModelID], replica)
+	}
+
+	for modelID, onNode := range byModel {
+		model, found, err := registrycontroller.GetModelByID(s, modelID)
+		if err != nil {
+			log.Printf("[drain] failed to load model %s: %v", modelID, err)
+			continue
+		}
+		if !found {
+			// Replicas of a deregistered model have nothing to keep available.
+			for _, r := range onNode {
+				sch.RemoveReplica(r)
+			}
+			continue
+		}
+		migrateReplica(s, sch, node, model, onNode, draining)
+	}
+}
+
+// migrateReplica surges replacements for the model's replicas on the draining
+// node and removes one of them once it is safe to do so.
+func migrateReplica(s *store.Store, sch *placementscheduler.Scheduler, node store.NodeInfo, model store.ModelInfo, onNode []store.ReplicaInfo, draining map[string]bool) {
+	outside, runningOutside, err := countOutside(s, model.ID, draining)
+	if err != nil {
+		log.Printf("[drain] failed to list replicas of model %s: %v", model.Name, err)
+		return
+	}
+
+	// Bring up a replacement elsewhere for every replica still on the node.
+	placementFailed := false
+	desired := model.DesiredReplicas()
+	if missing := min(len(onNode), desired-outside); missing > 0 {
+		if err := sch.Surge(model.ID, missing); err != nil {
+			log.Printf("[drain] cannot place replacement for model %s: %v", model.Name, err)
+			placementFailed = true
+		}
+	}
+
+	// Remove replicas that are not serving first; they do not reduce availability.
+	victim := onNode[0]
+	runningOnNode := 0
+	for _, r := range onNode {
+		if r.Status == constants.ModelReplicaStatusRunning {
+			runningOnNode++
+		} else {
+			victim = r
+		}
+	}
+	runningAfter := runningOutside + runningOnNode
+	if victim.Status == constants.ModelReplicaStatusRunning {
+		runningAfter--
+	}
+
+	// A replacement for the victim is running once the replicas outside the
+	// draining nodes cover everything already moved plus this one.
+	replaced := runningOutside >= desired-len(onNode)+1
+	if !(replaced || placementFailed) || runningAfter < model.MinAvailable {
+		log.Printf("[drain] waiting to move replica %s of model %s off node %s (%d running elsewhere, min available %d)",
+			victim.ID, model.Name, node.ID, runningOutside, model.MinAvailable)
+		return
+	}
+
+	sch.RemoveReplica(victim)
+	log.Printf("[drain] removed replica %s of model %s from node %s", victim.ID, model.Name, node.ID)
+}
+
+// countOutside counts the replicas of a model bound to nodes that are not
+// draining, and how many of them are running.
+func countOutside(s *store.Store, modelID string, draining map[string]bool) (int, int, error) {
+	replicas, err := replicascheduler.ListReplicasByModelID(s, modelID)
+	if err != nil {
+		return 0, 0, err
+	}
+	bound, running := 0, 0
+	for _, r := range replicas {
+		if r.NodeID == "" || draining[r.NodeID] {
+			continue
+		}
+		bound++
+		if r.Status == constants.ModelReplicaStatusRunning {
+			running++
+		}
+	}
+	return bound, running, nil
+}
+
+// StartDrainController runs HandleDrain periodically. It blocks forever and is
+// meant to be run in its own goroutine.
+func StartDrainController(s *store.Store, sch *placementscheduler.Scheduler, interval time.Duration) {
+	log.Printf("Starting drain controller with interval: %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := HandleDrain(s, sch); err != nil {
+			log.Printf("Error in drain controller: %v", err)
+		}
+	}
+}
@@ -1,30 +1,45 @@
 package heartbeatcontroller
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
 	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+	deploycaller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/api/grpc/deploy"
 	heartbeatcaller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/api/grpc/heartbeat"
 	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
 	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
 	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// undeployTimeout bounds the call undeploying a replica a node should no longer run.
+const undeployTimeout = 15 * time.Second
+
 func HandleHeartbeat(s *store.Store) error {
 	log.Println("Heartbeat controller started")
 
 	// Pre-compute endpoints to send to all nodes
 	endpoints := buildServiceEndpoints(s)
+	pipelines := buildPipelineDefinitions(s)
 
-	nodes, err := registrycontroller.ListNodesByStatuses(s, []constants.Status{constants.StatusOnline, constants.StatusUnknown})
+	// Offline nodes are polled too, so that a node that comes back is noticed.
+	nodes, err := registrycontroller.ListNodesByStatuses(s, []constants.Status{constants.StatusOnline, constants.StatusUnknown, constants.StatusOffline})
 	if err != nil {
 		return err
 	}
 	for _, node := range nodes {
-		resp, err := heartbeatcaller.CallHeartbeat(node, endpoints)
+		resp, err := heartbeatcaller.CallHeartbeat(node, endpoints, pipelines)
 		if err != nil {
+			if node.Status == constants.StatusOffline {
+				continue // still unreachable
+			}
 			log.Printf("Failed to call heartbeat for node %s", node.ID)
 			if node.LastHeartbeat.Add(40 * time.Second).Before(time.Now()) {
 				log.Printf("Node %s has not sent heartbeat in 40 seconds, setting status to offline", node.ID)
@@ -36,6 +51,20 @@ func HandleHeartbeat(s *store.Store) error {
 		}
 		log.Printf("Heartbeat response for node %s", node.ID)
 
+		// A node coming back from offline may still run replicas that were
+		// moved off it while it was unreachable.
+		if node.Status == constants.StatusOffline {
+			undeployUnbound(s, node, resp.GetModelReplicas())
+		}
+
+		// A successful heartbeat confirms the node is online (and makes it schedulable).
+		if err := registrycontroller.UpdateNodeStatus(s, node.ID, constants.StatusOnline); err != nil {
+			log.Printf("Failed to mark node %s online: %v", node.ID, err)
+		}
+		if err := registrycontroller.SetNodePeers(s, node.ID, peerStatsFromProto(resp.GetPeers())); err != nil {
+			log.Printf("Failed to record peer health of node %s: %v", node.ID, err)
+		}
+
 		// Update status of all replicas in the node based on the response
 		for _, replicaID := range node.AssignedModels {
 			// Try to find the replica in the response
@@ -47,38 +76,34 @@ func HandleHeartbeat(s *store.Store) error {
 				}
 			}
 
-			// Get the existing replica info from the store
-			replicaInfo, exists, err := replicascheduler.GetReplicaByID(s, replicaID)
+			// Update the stored replica status based on whether it was found in the response
+			exists, err := replicascheduler.MutateReplica(s, replicaID, func(replicaInfo *store.ReplicaInfo) {
+				if foundReplica != nil {
+					// Replica found in response - update with status from response
+					status := convertStringToReplicaStatus(foundReplica.GetStatus())
+					replicaInfo.Status = status
+					replicaInfo.ErrorCode = int(foundReplica.GetErrorCode())
+					replicaInfo.ErrorMessage = foundReplica.GetErrorMessage()
+					replicaInfo.QueueDepth = int(foundReplica.GetQueueDepth())
+					replicaInfo.InFlight = int(foundReplica.GetInFlight())
+					replicaInfo.P95LatencyMs = foundReplica.GetP95LatencyMs()
+					replicaInfo.RecentJobs = int(foundReplica.GetRecentJobs())
+					replicaInfo.QueueClasses = queueClassesFromProto(foundReplica.GetQueueClasses())
+					replicaInfo.LastHeartbeat = time.Now()
+					log.Printf("Updating replica %s with status: %s", replicaID, status)
+				} else {
+					// Replica not found in response - set to unknown
+					replicaInfo.Status = constants.ModelReplicaStatusUnknown
+					replicaInfo.LastHeartbeat = time.Now()
+					log.Printf("Replica %s not found in response, setting status to unknown", replicaID)
+				}
+			})
 			if err != nil {
-				log.Printf("Failed to get replica %s from store: %v", replicaID, err)
+				log.Printf("Failed to update replica %s: %v", replicaID, err)
 				continue
 			}
-
 			if !exists {
 				log.Printf("Replica %s not found in store, skipping update", replicaID)
-				continue
-			}
-
-			// Update replica status based on whether it was found in the response
-			if foundReplica != nil {
-				// Replica found in response - update with status from response
-				status := convertStringToReplicaStatus(foundReplica.GetStatus())
-				replicaInfo.Status = status
-				replicaInfo.ErrorCode = int(foundReplica.GetErrorCode())
-				replicaInfo.ErrorMessage = foundReplica.GetErrorMessage()
-				replicaInfo.LastHeartbeat = time.Now()
-				log.Printf("Updating replica %s with status: %s", replicaID, status)
-			} else {
-				// Replica not found in response - set to unknown
-				replicaInfo.Status = constants.ModelReplicaStatusUnknown
-				replicaInfo.LastHeartbeat = time.Now()
-				log.Printf("Replica %s not found in response, setting status to unknown", replicaID)
-			}
-
-			// Save the updated replica info
-			if err := replicascheduler.UpdateReplicaInfo(s, replicaID, replicaInfo); err != nil {
-				log.Printf("Failed to update replica %s: %v", replicaID, err)
-				continue
 			}
 		}
 	}
@@ -86,6 +111,80 @@ func HandleHeartbeat(s *store.Store) error {
 	return nil
 }
 
+// undeployUnbound asks the agent on node to undeploy the replicas it reported
+// that the store binds to another node or to no node. Replicas the store has no
+// record of, such as ones deployed with `edgectl deploy`, are left alone.
+// Failures are logged; a replica the agent no longer knows is already gone.
+func undeployUnbound(s *store.Store, node store.NodeInfo, reported []*heartbeatpb.ModelReplicaDetails) {
+	for _, replica := range reported {
+		replicaID := replica.GetReplicaId()
+		r, exists, err := replicascheduler.GetReplicaByID(s, replicaID)
+		if err != nil {
+			log.Printf("Failed to load replica %s reported by node %s: %v", replicaID, node.ID, err)
+			continue
+		}
+		if !exists || r.NodeID == node.ID {
+			continue
+		}
+
+		log.Printf("Replica %s on node %s is no longer bound to it, undeploying", replicaID, node.ID)
+		ctx, cancel := context.WithTimeout(context.Background(), undeployTimeout)
+		resp, err := deploycaller.CallUndeployModel(ctx, node, &deploypb.UndeployModelRequest{ReplicaId: replicaID})
+		cancel()
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err == nil && !resp.GetSuccess() {
+			err = fmt.Errorf("agent rejected undeployment: %s", resp.GetMessage())
+		}
+		if err != nil {
+			log.Printf("Failed to undeploy replica %s from node %s: %v", replicaID, node.ID, err)
+		}
+	}
+}
+
+// peerStatsFromProto converts the peer health reported by an agent, logging
+// peers that it has ejected or stopped sending requests to.
+func peerStatsFromProto(peers []*heartbeatpb.PeerStats) []store.PeerStats {
+	var out []store.PeerStats
+	for _, p := range peers {
+		ps := store.PeerStats{
+			NodeID:              p.GetNodeId(),
+			Address:             p.GetAddress(),
+			Breaker:             p.GetBreaker(),
+			ConsecutiveFailures: int(p.GetConsecutiveFailures()),
+			Ejections:           int(p.GetEjections()),
+			Requests:            p.GetRequests(),
+			Failures:            p.GetFailures(),
+			Retries:             p.GetRetries(),
+		}
+		if p.GetEjectedUntil() > 0 {
+			ps.EjectedUntil = time.Unix(p.GetEjectedUntil(), 0)
+			log.Printf("Peer %s (%s) is ejected until %s", ps.NodeID, ps.Address, ps.EjectedUntil.Format(time.RFC3339))
+		}
+		if ps.Breaker == "open" {
+			log.Printf("Circuit breaker to peer %s (%s) is open", ps.NodeID, ps.Address)
+		}
+		out = append(out, ps)
+	}
+	return out
+}
+
+// queueClassesFromProto converts the per-class queue metrics reported for a replica.
+func queueClassesFromProto(classes []*heartbeatpb.QueueClassStats) []store.QueueClassStats {
+	var out []store.QueueClassStats
+	for _, c := range classes {
+		out = append(out, store.QueueClassStats{
+			Class:      constants.PriorityClass(c.GetClass()),
+			QueueDepth: int(c.GetQueueDepth()),
+			Enqueued:   c.GetEnqueued(),
+			Rejected:   c.GetRejected(),
+			P95WaitMs:  c.GetP95WaitMs(),
+		})
+	}
+	return out
+}
+
 // convertStringToReplicaStatus converts a string status to ModelReplicaStatus constant.
 func convertStringToReplicaStatus(statusStr string) constants.ModelReplicaStatus {
 	switch statusStr {
@@ -137,12 +236,43 @@ func buildServiceEndpoints(s *store.Store) []*heartbeatpb.ServiceEndpoints {
 
 	var result []*heartbeatpb.ServiceEndpoints
 	for modelID, eps := range endpointsByModel {
-		result = append(result, &heartbeatpb.ServiceEndpoints{
+		se := &heartbeatpb.ServiceEndpoints{
 			ModelId:   modelID,
 			Endpoints: eps,
-		})
+		}
+		// Agents pick endpoints with the model's load balancing strategy.
+		if model, exists, err := registrycontroller.GetModelByID(s, modelID); err == nil && exists {
+			se.LoadBalancer = string(model.LoadBalancer)
+		}
+		result = append(result, se)
+	}
+
+	return result
+}
+
+// buildPipelineDefinitions lists the registered pipelines for the agents,
+// which run pipeline requests themselves.
+func buildPipelineDefinitions(s *store.Store) []*heartbeatpb.PipelineDefinition {
+	pipelines, err := registrycontroller.ListPipelines(s)
+	if err != nil {
+		log.Printf("Failed to list pipelines: %v", err)
+		return nil
 	}
 
+	result := make([]*heartbeatpb.PipelineDefinition, 0, len(pipelines))
+	for _, p := range pipelines {
+		spec, err := json.Marshal(p.Spec)
+		if err != nil {
+			log.Printf("Failed to encode pipeline %s: %v", p.ID, err)
+			continue
+		}
+		result = append(result, &heartbeatpb.PipelineDefinition{
+			Id:        p.ID,
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Spec:      string(spec),
+		})
+	}
 	return result
 }
 
@@ -4,11 +4,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
+	"time"
 
 	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
 	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
 )
 
+// modelMu serializes read-modify-write cycles on model records.
+var modelMu sync.Mutex
+
 type NodeAddress struct {
 	NodeID string
 	IP     string
@@ -41,6 +47,12 @@ func RegisterModel(s *store.Store, modelID string, info store.ModelInfo) error {
 		return fmt.Errorf("model info ID %q does not match modelID %q", info.ID, modelID)
 	}
 
+	// The registered artifact is revision 1.
+	info.Revision = 1
+	first := info.CurrentRevision()
+	first.CreatedAt = time.Now()
+	info.Revisions = []store.ModelRevision{first}
+
 	b, err := json.Marshal(info)
 	if err != nil {
 		return fmt.Errorf("marshal model info: %w", err)
@@ -56,7 +68,12 @@ func DeRegisterModel(s *store.Store, modelID string) error {
 	return s.Delete("model:" + modelID)
 }
 
-// UpdateModelInfo replaces the stored ModelInfo for a modelID.
+// UpdateModelInfo updates the stored ModelInfo for a modelID.
+// Every field the update leaves unset (empty, zero or nil) keeps its stored
+// value, so a partial update such as a new FilePath alone does not reset the
+// model's name, replica count or scheduling constraints. If the update
+// changes the artifact, a new revision is recorded and the rollout controller
+// replaces the running replicas.
 func UpdateModelInfo(s *store.Store, modelID string, info store.ModelInfo) error {
 	if modelID == "" {
 		return errors.New("modelID cannot be empty")
@@ -68,6 +85,207 @@ func UpdateModelInfo(s *store.Store, modelID string, info store.ModelInfo) error
 	}
 	info.ID = modelID
 
+	modelMu.Lock()
+	defer modelMu.Unlock()
+
+	if existing, found, err := GetModelByID(s, modelID); err != nil {
+		return err
+	} else if found {
+		if info.Name == "" {
+			info.Name = existing.Name
+		}
+		if info.Namespace == "" {
+			info.Namespace = existing.Namespace
+		}
+		if info.Replicas == 0 {
+			info.Replicas = existing.Replicas
+		}
+		if info.MinAvailable == 0 {
+			info.MinAvailable = existing.MinAvailable
+		}
+		if len(info.NodeSelector) == 0 {
+			info.NodeSelector = existing.NodeSelector
+		}
+		if info.Affinity == nil {
+			info.Affinity = existing.Affinity
+		}
+		if len(info.Tolerations) == 0 {
+			info.Tolerations = existing.Tolerations
+		}
+		if info.Autoscaling == nil {
+			info.Autoscaling = existing.Autoscaling
+		}
+		if info.ReplicaIDs == nil {
+			info.ReplicaIDs = existing.ReplicaIDs
+		}
+		if info.ActiveReplicas == 0 {
+			info.ActiveReplicas = existing.ActiveReplicas
+		}
+		if info.ScaledReplicas == 0 {
+			info.ScaledReplicas = existing.ScaledReplicas
+		}
+		if info.LastScaleTime.IsZero() {
+			info.LastScaleTime = existing.LastScaleTime
+		}
+		if info.Version == "" {
+			info.Version = existing.Version
+		}
+		if info.FilePath == "" {
+			info.FilePath = existing.FilePath
+		}
+		if info.SHA256Hash == "" {
+			info.SHA256Hash = existing.SHA256Hash
+		}
+		if info.ModelType == "" {
+			info.ModelType = existing.ModelType
+		}
+		if info.ModelSize == 0 {
+			info.ModelSize = existing.ModelSize
+		}
+		if info.Rollout == nil {
+			info.Rollout = existing.Rollout
+		}
+		if info.Batching == nil {
+			info.Batching = existing.Batching
+		}
+		if info.Preprocessing == nil {
+			info.Preprocessing = existing.Preprocessing
+		}
+		if info.LoadBalancer == "" {
+			info.LoadBalancer = existing.LoadBalancer
+		}
+		if info.InputFormat == nil {
+			info.InputFormat = existing.InputFormat
+		}
+		recordRevision(&info, existing, "")
+	}
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("marshal model info: %w", err)
+	}
+	return s.Put("model:"+modelID, b)
+}
+
+// RollbackModel makes the artifact of an earlier revision current again. It is
+// recorded as a new revision, so the rollout controller replaces the running
+// replicas just like after an update. A toRevision of 0 selects the revision
+// before the current one. It returns the new revision number.
+func RollbackModel(s *store.Store, modelID string, toRevision int) (int, error) {
+	modelMu.Lock()
+	defer modelMu.Unlock()
+
+	info, found, err := GetModelByID(s, modelID)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("model %q not found", modelID)
+	}
+
+	if toRevision == 0 {
+		for _, r := range info.Revisions {
+			if r.Revision < info.Revision && r.Revision > toRevision {
+				toRevision = r.Revision
+			}
+		}
+		if toRevision == 0 {
+			return 0, fmt.Errorf("model %q has no previous revision", modelID)
+		}
+	}
+	if toRevision == info.Revision {
+		return 0, fmt.Errorf("revision %d is already current", toRevision)
+	}
+	target, ok := info.FindRevision(toRevision)
+	if !ok {
+		return 0, fmt.Errorf("revision %d of model %q not found", toRevision, modelID)
+	}
+
+	existing := info
+	info.Version = target.Version
+	info.FilePath = target.FilePath
+	info.SHA256Hash = target.SHA256Hash
+	info.ModelType = target.ModelType
+	info.ModelSize = target.ModelSize
+	recordRevision(&info, existing, fmt.Sprintf("rollback to revision %d", toRevision))
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		return 0, fmt.Errorf("marshal model info: %w", err)
+	}
+	if err := s.Put("model:"+modelID, b); err != nil {
+		return 0, err
+	}
+	return info.Revision, nil
+}
+
+// recordRevision carries the revision history of existing over to info and
+// appends a new revision if info deploys a different artifact. Only the most
+// recent store.MaxRevisionHistory revisions are kept.
+func recordRevision(info *store.ModelInfo, existing store.ModelInfo, cause string) {
+	info.Revision = existing.Revision
+	info.Revisions = existing.Revisions
+	if len(info.Revisions) == 0 {
+		// Records stored before revisions were tracked start their history here.
+		info.Revisions = []store.ModelRevision{existing.CurrentRevision()}
+	}
+	if info.CurrentRevision().SameArtifact(existing.CurrentRevision()) {
+		return
+	}
+
+	info.Revision = info.Revisions[len(info.Revisions)-1].Revision + 1
+	next := info.CurrentRevision()
+	next.CreatedAt = time.Now()
+	next.ChangeCause = cause
+	info.Revisions = append(slices.Clone(info.Revisions), next)
+	if extra := len(info.Revisions) - store.MaxRevisionHistory; extra > 0 {
+		info.Revisions = info.Revisions[extra:]
+	}
+}
+
+// SetModelReplicaState records the replicas currently backing a model and how
+// many of them are running. The record is only rewritten when something changed.
+func SetModelReplicaState(s *store.Store, modelID string, replicaIDs []string, activeReplicas int) error {
+	modelMu.Lock()
+	defer modelMu.Unlock()
+
+	info, found, err := GetModelByID(s, modelID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("model %q not found", modelID)
+	}
+
+	slices.Sort(replicaIDs)
+	if slices.Equal(info.ReplicaIDs, replicaIDs) && info.ActiveReplicas == activeReplicas {
+		return nil
+	}
+	info.ReplicaIDs = replicaIDs
+	info.ActiveReplicas = activeReplicas
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("marshal model info: %w", err)
+	}
+	return s.Put("model:"+modelID, b)
+}
+
+// SetModelScale records the replica count chosen by the autoscaler and when it was set.
+func SetModelScale(s *store.Store, modelID string, replicas int, at time.Time) error {
+	modelMu.Lock()
+	defer modelMu.Unlock()
+
+	info, found, err := GetModelByID(s, modelID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("model %q not found", modelID)
+	}
+	info.ScaledReplicas = replicas
+	info.LastScaleTime = at
+
 	b, err := json.Marshal(info)
 	if err != nil {
 		return fmt.Errorf("marshal model info: %w", err)
@@ -5,18 +5,50 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
 	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
 )
 
+// nodeMu serializes read-modify-write cycles on node records so that concurrent
+// controllers (heartbeat, scheduler) do not overwrite each other's changes.
+var nodeMu sync.Mutex
+
 // RegisterNode stores a new NodeInfo under the given nodeID.
+// Nodes registered without a status start as StatusUnknown so that the
+// heartbeat controller polls them and promotes them to StatusOnline.
+// Registering a node that is already stored keeps the fields owned by the
+// scheduler and the operator: its replicas, labels, taints, cordon and drain
+// flags, peers and registration time.
 func RegisterNode(s *store.Store, nodeID string, nodeInfo store.NodeInfo) error {
 	if nodeID == "" {
 		return errors.New("nodeID cannot be empty")
 	}
 
+	nodeMu.Lock()
+	defer nodeMu.Unlock()
+
+	raw, found, err := getNodeRaw(s, nodeID)
+	if err != nil {
+		return err
+	}
+	if found {
+		var existing store.NodeInfo
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			return fmt.Errorf("unmarshal node info: %w", err)
+		}
+		nodeInfo.AssignedModels = existing.AssignedModels
+		nodeInfo.Labels = existing.Labels
+		nodeInfo.Taints = existing.Taints
+		nodeInfo.Unschedulable = existing.Unschedulable
+		nodeInfo.Draining = existing.Draining
+		nodeInfo.Peers = existing.Peers
+		nodeInfo.RegisteredAt = existing.RegisteredAt
+	}
+
 	// Ensure the stored NodeInfo has a consistent ID and timestamps.
 	if nodeInfo.ID == "" {
 		nodeInfo.ID = nodeID
@@ -29,6 +61,9 @@ func RegisterNode(s *store.Store, nodeID string, nodeInfo store.NodeInfo) error
 	if nodeInfo.LastActivity.IsZero() {
 		nodeInfo.LastActivity = now
 	}
+	if nodeInfo.Status == "" {
+		nodeInfo.Status = constants.StatusUnknown
+	}
 
 	deviceInfoBytes, err := json.Marshal(nodeInfo)
 	if err != nil {
@@ -37,15 +72,44 @@ func RegisterNode(s *store.Store, nodeID string, nodeInfo store.NodeInfo) error
 	return s.Put("node:"+nodeID, deviceInfoBytes)
 }
 
-// DeRegisterNode removes a node from the store.
+// DeRegisterNode removes a node from the store. Replicas bound to the node are
+// marked failed and unbound, as when an offline node is evacuated, so that the
+// placement scheduler replaces them.
 func DeRegisterNode(s *store.Store, nodeID string) error {
 	if nodeID == "" {
 		return errors.New("nodeID cannot be empty")
 	}
-	return s.Delete("node:" + nodeID)
+	if err := s.Delete("node:" + nodeID); err != nil {
+		return err
+	}
+
+	replicas, err := replicascheduler.ListReplicas(s)
+	if err != nil {
+		return fmt.Errorf("list replicas: %w", err)
+	}
+	for _, replica := range replicas {
+		if replica.NodeID != nodeID {
+			continue
+		}
+		if _, err := replicascheduler.MutateReplica(s, replica.ID, func(r *store.ReplicaInfo) {
+			if r.NodeID != nodeID {
+				return
+			}
+			r.Status = constants.ModelReplicaStatusFailed
+			r.ErrorMessage = fmt.Sprintf("node %s was deregistered", nodeID)
+			r.NodeID = ""
+			r.EvictedFrom = nodeID
+		}); err != nil {
+			return fmt.Errorf("unbind replica %s: %w", replica.ID, err)
+		}
+	}
+	return nil
 }
 
-// UpdateNodeInfo replaces the stored NodeInfo for a nodeID.
+// UpdateNodeInfo updates the fields a node reports about itself: its name,
+// address, metadata and resource capabilities. Everything else, such as the
+// replicas placed on the node, its labels, taints and status, is left as
+// stored so that an update cannot overwrite concurrent scheduling changes.
 func UpdateNodeInfo(s *store.Store, nodeID string, info store.NodeInfo) error {
 	if nodeID == "" {
 		return errors.New("nodeID cannot be empty")
@@ -55,43 +119,170 @@ func UpdateNodeInfo(s *store.Store, nodeID string, info store.NodeInfo) error {
 	if info.ID != "" && info.ID != nodeID {
 		return fmt.Errorf("node info ID %q does not match nodeID %q", info.ID, nodeID)
 	}
-	info.ID = nodeID
 
-	// Preserve original RegisteredAt if the node already exists.
-	if existing, found, err := GetNodeByID(s, nodeID); err != nil {
-		return err
-	} else if found {
-		if info.RegisteredAt.IsZero() {
-			info.RegisteredAt = existing.RegisteredAt
-		}
-		if info.LastHeartbeat.IsZero() {
-			info.LastHeartbeat = existing.LastHeartbeat
-		}
-		if info.LastActivity.IsZero() {
-			info.LastActivity = existing.LastActivity
+	return mutateNode(s, nodeID, func(node *store.NodeInfo) {
+		node.Name = info.Name
+		node.IP = info.IP
+		node.Port = info.Port
+		node.Metadata = info.Metadata
+		node.ResourceCapabilities = info.ResourceCapabilities
+		if node.LastActivity.IsZero() {
+			node.LastActivity = time.Now()
 		}
+	})
+}
+
+// UpdateNodeStatus updates only the Status (and related timestamps) of a node.
+// LastHeartbeat is refreshed only when the node is marked online.
+func UpdateNodeStatus(s *store.Store, nodeID string, status constants.Status) error {
+	if nodeID == "" {
+		return errors.New("nodeID cannot be empty")
+	}
+
+	nodeMu.Lock()
+	defer nodeMu.Unlock()
+
+	var info store.NodeInfo
+	raw, found, err := getNodeRaw(s, nodeID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("node %q not found", nodeID)
+	}
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return fmt.Errorf("unmarshal node info: %w", err)
 	}
 
 	now := time.Now()
+	info.Status = status
 	info.UpdatedAt = now
+
+	// Only a node reported online has actually answered a heartbeat. Keeping
+	// LastHeartbeat untouched otherwise lets the heartbeat and revival controllers
+	// measure how long a node has been unreachable.
+	if status == constants.StatusOnline {
+		info.LastHeartbeat = now
+	}
 	if info.LastActivity.IsZero() {
 		info.LastActivity = now
 	}
 
-	b, err := json.Marshal(info)
+	b, err := json.Marshal(&info)
 	if err != nil {
-		return fmt.Errorf("marshal node info: %w", err)
+		return fmt.Errorf("marshal updated node info: %w", err)
 	}
 	return s.Put("node:"+nodeID, b)
 }
 
-// UpdateNodeStatus updates only the Status (and related timestamps) of a node.
-func UpdateNodeStatus(s *store.Store, nodeID string, status constants.Status) error {
+// AssignReplicaToNode appends replicaID to the node's AssignedModels.
+// Assigning a replica that is already present is a no-op.
+func AssignReplicaToNode(s *store.Store, nodeID, replicaID string) error {
+	if replicaID == "" {
+		return errors.New("replicaID cannot be empty")
+	}
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		if !slices.Contains(info.AssignedModels, replicaID) {
+			info.AssignedModels = append(info.AssignedModels, replicaID)
+		}
+	})
+}
+
+// UnassignReplicaFromNode removes replicaID from the node's AssignedModels.
+// Removing a replica that is not assigned is a no-op.
+func UnassignReplicaFromNode(s *store.Store, nodeID, replicaID string) error {
+	if replicaID == "" {
+		return errors.New("replicaID cannot be empty")
+	}
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		info.AssignedModels = slices.DeleteFunc(info.AssignedModels, func(id string) bool { return id == replicaID })
+	})
+}
+
+// SetNodeLabels adds or overwrites the labels in set and deletes the keys in remove.
+func SetNodeLabels(s *store.Store, nodeID string, set map[string]string, remove []string) error {
 	if nodeID == "" {
 		return errors.New("nodeID cannot be empty")
 	}
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		if info.Labels == nil {
+			info.Labels = make(map[string]string, len(set))
+		}
+		for k, v := range set {
+			info.Labels[k] = v
+		}
+		for _, k := range remove {
+			delete(info.Labels, k)
+		}
+	})
+}
+
+// SetNodeTaints adds the taints in add, replacing existing taints with the same
+// key and effect, and deletes the taints in remove. A taint in remove without an
+// effect deletes every taint with its key.
+func SetNodeTaints(s *store.Store, nodeID string, add, remove []store.Taint) error {
+	if nodeID == "" {
+		return errors.New("nodeID cannot be empty")
+	}
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		for _, r := range remove {
+			info.Taints = slices.DeleteFunc(info.Taints, func(t store.Taint) bool {
+				return t.Key == r.Key && (r.Effect == "" || t.Effect == r.Effect)
+			})
+		}
+		for _, a := range add {
+			info.Taints = slices.DeleteFunc(info.Taints, func(t store.Taint) bool {
+				return t.Key == a.Key && t.Effect == a.Effect
+			})
+			info.Taints = append(info.Taints, a)
+		}
+	})
+}
+
+// CordonNode marks a node unschedulable so no new replicas are placed on it.
+// Replicas already on the node keep running.
+func CordonNode(s *store.Store, nodeID string) error {
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		info.Unschedulable = true
+	})
+}
+
+// UncordonNode makes a node schedulable again and stops a drain in progress.
+func UncordonNode(s *store.Store, nodeID string) error {
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		info.Unschedulable = false
+		info.Draining = false
+	})
+}
+
+// DrainNode cordons a node and marks it for draining; the drain controller
+// then migrates its replicas to other nodes.
+func DrainNode(s *store.Store, nodeID string) error {
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		info.Unschedulable = true
+		info.Draining = true
+	})
+}
+
+// FinishDrain clears the draining flag once a node has no replicas left. The node stays cordoned.
+func FinishDrain(s *store.Store, nodeID string) error {
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		info.Draining = false
+	})
+}
+
+// SetNodePeers records the peer health a node reported in its last heartbeat.
+func SetNodePeers(s *store.Store, nodeID string, peers []store.PeerStats) error {
+	return mutateNode(s, nodeID, func(info *store.NodeInfo) {
+		info.Peers = peers
+	})
+}
+
+// mutateNode loads a node, applies fn and persists the result while holding nodeMu.
+func mutateNode(s *store.Store, nodeID string, fn func(info *store.NodeInfo)) error {
+	nodeMu.Lock()
+	defer nodeMu.Unlock()
 
-	var info store.NodeInfo
 	raw, found, err := getNodeRaw(s, nodeID)
 	if err != nil {
 		return err
@@ -99,19 +290,14 @@ func UpdateNodeStatus(s *store.Store, nodeID string, status constants.Status) er
 	if !found {
 		return fmt.Errorf("node %q not found", nodeID)
 	}
+
+	var info store.NodeInfo
 	if err := json.Unmarshal(raw, &info); err != nil {
 		return fmt.Errorf("unmarshal node info: %w", err)
 	}
 
-	now := time.Now()
-	info.Status = status
-	info.UpdatedAt = now
-
-	// Treat any status change as a heartbeat for now.
-	info.LastHeartbeat = now
-	if info.LastActivity.IsZero() {
-		info.LastActivity = now
-	}
+	fn(&info)
+	info.UpdatedAt = time.Now()
 
 	b, err := json.Marshal(&info)
 	if err != nil {
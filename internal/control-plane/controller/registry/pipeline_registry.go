@@ -0,0 +1,148 @@
+package registrycontroller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/pipeline"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+const pipelinePrefix = "pipeline:"
+
+// RegisterPipeline stores a new PipelineInfo under the given pipelineID.
+// The pipeline name must be unique within its namespace, its spec must be a
+// valid DAG, and every step must name a model registered in the pipeline's
+// namespace. Steps naming their model are resolved to its ID; steps naming
+// a model ID get its name.
+func RegisterPipeline(s *store.Store, pipelineID string, info store.PipelineInfo) error {
+	if pipelineID == "" {
+		return errors.New("pipelineID cannot be empty")
+	}
+	if info.Name == "" {
+		return errors.New("pipeline name cannot be empty")
+	}
+	if err := info.Spec.Validate(); err != nil {
+		return err
+	}
+
+	if existing, found, err := GetPipelineByNamespaceAndName(s, info.Namespace, info.Name); err != nil {
+		return fmt.Errorf("check pipeline name uniqueness: %w", err)
+	} else if found {
+		return fmt.Errorf("pipeline name %q is already registered in namespace %q (id=%s)", info.Name, info.Namespace, existing.ID)
+	}
+
+	if info.ID == "" {
+		info.ID = pipelineID
+	} else if info.ID != pipelineID {
+		return fmt.Errorf("pipeline info ID %q does not match pipelineID %q", info.ID, pipelineID)
+	}
+
+	steps := make([]pipeline.Step, 0, len(info.Spec.Steps))
+	for _, step := range info.Spec.Steps {
+		model, err := stepModel(s, info.Namespace, step.Model, step.ModelID)
+		if err != nil {
+			return fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		step.Model, step.ModelID = model.Name, model.ID
+		steps = append(steps, step)
+	}
+	info.Spec.Steps = steps
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("marshal pipeline info: %w", err)
+	}
+	return s.Put(pipelinePrefix+pipelineID, b)
+}
+
+// stepModel looks up the model of a pipeline step by ID, or else by name.
+func stepModel(s *store.Store, namespace, name, modelID string) (store.ModelInfo, error) {
+	if modelID != "" {
+		model, found, err := GetModelByID(s, modelID)
+		if err != nil {
+			return store.ModelInfo{}, err
+		}
+		if !found || model.Namespace != namespace {
+			return store.ModelInfo{}, fmt.Errorf("model %s is not registered in namespace %q", modelID, namespace)
+		}
+		return model, nil
+	}
+
+	model, found, err := GetModelByNamespaceAndName(s, namespace, name)
+	if err != nil {
+		return store.ModelInfo{}, err
+	}
+	if !found {
+		return store.ModelInfo{}, fmt.Errorf("model %q is not registered in namespace %q", name, namespace)
+	}
+	return model, nil
+}
+
+// DeRegisterPipeline removes a pipeline from the store.
+func DeRegisterPipeline(s *store.Store, pipelineID string) error {
+	if pipelineID == "" {
+		return errors.New("pipelineID cannot be empty")
+	}
+	return s.Delete(pipelinePrefix + pipelineID)
+}
+
+// GetPipelineByID loads a PipelineInfo by ID.
+// Returns (zero PipelineInfo, false, nil) if the pipeline is not found.
+func GetPipelineByID(s *store.Store, pipelineID string) (store.PipelineInfo, bool, error) {
+	if pipelineID == "" {
+		return store.PipelineInfo{}, false, errors.New("pipelineID cannot be empty")
+	}
+
+	raw, ok := s.Get(pipelinePrefix + pipelineID)
+	if !ok {
+		return store.PipelineInfo{}, false, nil
+	}
+
+	var info store.PipelineInfo
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return store.PipelineInfo{}, false, fmt.Errorf("unmarshal pipeline info: %w", err)
+	}
+	return info, true, nil
+}
+
+// ListPipelines returns all PipelineInfo records currently in the store.
+func ListPipelines(s *store.Store) ([]store.PipelineInfo, error) {
+	var pipelines []store.PipelineInfo
+	for _, k := range s.Keys() {
+		if !strings.HasPrefix(k, pipelinePrefix) {
+			continue
+		}
+		raw, ok := s.Get(k)
+		if !ok {
+			continue
+		}
+		var info store.PipelineInfo
+		if err := json.Unmarshal(raw, &info); err != nil {
+			return nil, fmt.Errorf("unmarshal pipeline %q: %w", k, err)
+		}
+		pipelines = append(pipelines, info)
+	}
+	return pipelines, nil
+}
+
+// GetPipelineByNamespaceAndName looks up a pipeline by its namespace and name.
+// Returns (zero PipelineInfo, false, nil) if no such pipeline exists.
+func GetPipelineByNamespaceAndName(s *store.Store, namespace, name string) (store.PipelineInfo, bool, error) {
+	if name == "" {
+		return store.PipelineInfo{}, false, errors.New("pipeline name cannot be empty")
+	}
+
+	pipelines, err := ListPipelines(s)
+	if err != nil {
+		return store.PipelineInfo{}, false, err
+	}
+	for _, p := range pipelines {
+		if p.Namespace == namespace && p.Name == name {
+			return p, true, nil
+		}
+	}
+	return store.PipelineInfo{}, false, nil
+}
@@ -1 +1,183 @@
 package revivalcliniccontroller
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// Config controls how the revival clinic treats dead nodes and failed replicas.
+type Config struct {
+	// GracePeriod is how long an offline node may go without a heartbeat before
+	// its replicas are rescheduled elsewhere.
+	GracePeriod time.Duration
+	// A failed replica is restarted right away the first time. BaseBackoff is the
+	// delay before the second restart; it doubles with every further restart up
+	// to MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// MaxRestarts caps consecutive restarts; a replica that reaches it is
+	// considered crash looping and is left failed.
+	MaxRestarts int
+	// StableAfter resets the restart count of a replica that has been running
+	// for this long since its last restart.
+	StableAfter time.Duration
+}
+
+// DefaultConfig returns the configuration used when no overrides are set.
+func DefaultConfig() Config {
+	return Config{
+		GracePeriod: 60 * time.Second,
+		BaseBackoff: 10 * time.Second,
+		MaxBackoff:  5 * time.Minute,
+		MaxRestarts: 5,
+		StableAfter: 10 * time.Minute,
+	}
+}
+
+// HandleRevival runs a single revival pass:
+//  1. replicas on nodes that have been offline for longer than the grace period are
+//     marked failed, unbound from the node and replaced through the scheduler;
+//  2. failed replicas on live nodes are redeployed with exponential backoff;
+//  3. restart counts of replicas that have been stable for a while are reset.
+func HandleRevival(s *store.Store, sch *placementscheduler.Scheduler, cfg Config) error {
+	if err := evacuateDeadNodes(s, sch, cfg); err != nil {
+		return err
+	}
+	return restartFailedReplicas(s, sch, cfg)
+}
+
+// evacuateDeadNodes moves replicas off nodes that are past the grace period.
+func evacuateDeadNodes(s *store.Store, sch *placementscheduler.Scheduler, cfg Config) error {
+	nodes, err := registrycontroller.ListNodesByStatuses(s, []constants.Status{constants.StatusOffline})
+	if err != nil {
+		return fmt.Errorf("list offline nodes: %w", err)
+	}
+
+	affectedModels := make(map[string]bool)
+	for _, node := range nodes {
+		if len(node.AssignedModels) == 0 || time.Since(node.LastHeartbeat) < cfg.GracePeriod {
+			continue
+		}
+
+		log.Printf("[revival] node %s offline since %v, rescheduling %d replica(s)", node.ID, node.LastHeartbeat, len(node.AssignedModels))
+		for _, replicaID := range node.AssignedModels {
+			modelID, err := placementscheduler.EvictReplica(s, node.ID, replicaID, fmt.Sprintf("node %s is offline", node.ID))
+			if err != nil {
+				log.Printf("[revival] failed to evict replica %s: %v", replicaID, err)
+				continue
+			}
+			if modelID != "" {
+				affectedModels[modelID] = true
+			}
+		}
+	}
+
+	for modelID := range affectedModels {
+		if err := sch.ReconcileModel(modelID); err != nil {
+			log.Printf("[revival] failed to reschedule replicas of model %s: %v", modelID, err)
+		}
+	}
+	return nil
+}
+
+// restartFailedReplicas redeploys failed replicas that are bound to a live node
+// once their backoff has expired, and resets the restart count of stable replicas.
+func restartFailedReplicas(s *store.Store, sch *placementscheduler.Scheduler, cfg Config) error {
+	replicas, err := replicascheduler.ListReplicas(s)
+	if err != nil {
+		return fmt.Errorf("list replicas: %w", err)
+	}
+
+	for _, replica := range replicas {
+		if replica.NodeID == "" {
+			continue
+		}
+
+		switch replica.Status {
+		case constants.ModelReplicaStatusRunning:
+			if replica.RestartCount > 0 && time.Since(replica.LastRestartAt) >= cfg.StableAfter {
+				if _, err := replicascheduler.MutateReplica(s, replica.ID, func(r *store.ReplicaInfo) {
+					r.RestartCount = 0
+				}); err != nil {
+					log.Printf("[revival] failed to reset restart count of replica %s: %v", replica.ID, err)
+				}
+			}
+
+		case constants.ModelReplicaStatusFailed:
+			if replica.RestartCount >= cfg.MaxRestarts {
+				continue // crash loop: leave it failed
+			}
+			if time.Since(replica.LastRestartAt) < Backoff(cfg, replica.RestartCount) {
+				continue
+			}
+			restartReplica(s, sch, cfg, replica)
+		}
+	}
+	return nil
+}
+
+// restartReplica records a restart attempt and redeploys the replica on its node.
+func restartReplica(s *store.Store, sch *placementscheduler.Scheduler, cfg Config, replica store.ReplicaInfo) {
+	node, found, err := registrycontroller.GetNodeByID(s, replica.NodeID)
+	if err != nil || !found || node.Status != constants.StatusOnline {
+		return // evacuation handles replicas on dead nodes
+	}
+
+	attempt := replica.RestartCount + 1
+	if _, err := replicascheduler.MutateReplica(s, replica.ID, func(r *store.ReplicaInfo) {
+		r.RestartCount = attempt
+		r.LastRestartAt = time.Now()
+	}); err != nil {
+		log.Printf("[revival] failed to record restart of replica %s: %v", replica.ID, err)
+		return
+	}
+
+	if err := sch.RedeployReplica(replica); err != nil {
+		log.Printf("[revival] restart %d/%d of replica %s on node %s failed: %v", attempt, cfg.MaxRestarts, replica.ID, replica.NodeID, err)
+		return
+	}
+
+	if _, err := replicascheduler.MutateReplica(s, replica.ID, func(r *store.ReplicaInfo) {
+		r.Status = constants.ModelReplicaStatusPending
+		r.ErrorCode = constants.ReplicaErrorNone
+		r.ErrorMessage = ""
+	}); err != nil {
+		log.Printf("[revival] failed to mark replica %s pending: %v", replica.ID, err)
+	}
+	log.Printf("[revival] restarted replica %s on node %s (attempt %d/%d)", replica.ID, replica.NodeID, attempt, cfg.MaxRestarts)
+}
+
+// Backoff returns the delay required since the last restart before restarting
+// a replica that has already been restarted restartCount times.
+func Backoff(cfg Config, restartCount int) time.Duration {
+	if restartCount <= 0 {
+		return 0
+	}
+	delay := cfg.BaseBackoff
+	for i := 1; i < restartCount && delay < cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, cfg.MaxBackoff)
+}
+
+// StartRevivalClinic runs HandleRevival periodically. It blocks forever and is
+// meant to be run in its own goroutine.
+func StartRevivalClinic(s *store.Store, sch *placementscheduler.Scheduler, cfg Config, interval time.Duration) {
+	log.Printf("Starting revival clinic with interval: %v (grace period %v, max restarts %d)", interval, cfg.GracePeriod, cfg.MaxRestarts)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := HandleRevival(s, sch, cfg); err != nil {
+			log.Printf("Error in revival clinic: %v", err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package rolloutcontroller
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	statuscontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/status"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// HandleRollout runs a single rollout pass over every model that still has
+// replicas deployed with an older revision.
+//
+// Replicas of the current revision are surged until the model has its desired
+// count of them, never exceeding the desired count plus max surge in total.
+// Old replicas that are not running are removed right away. Running old
+// replicas are removed only while the running replicas of both revisions stay
+// at or above the desired count minus max unavailable, so with the default
+// limits an old replica goes only once a new one is running.
+func HandleRollout(s *store.Store, sch *placementscheduler.Scheduler) error {
+	models, err := registrycontroller.ListModels(s)
+	if err != nil {
+		return fmt.Errorf("list models: %w", err)
+	}
+
+	for _, model := range models {
+		if err := rolloutModel(s, sch, model); err != nil {
+			log.Printf("[rollout] model %s (%s): %v", model.Name, model.ID, err)
+		}
+	}
+	return nil
+}
+
+// rolloutModel advances the rollout of a single model by one step.
+func rolloutModel(s *store.Store, sch *placementscheduler.Scheduler, model store.ModelInfo) error {
+	replicas, err := replicascheduler.ListReplicasByModelID(s, model.ID)
+	if err != nil {
+		return fmt.Errorf("list replicas: %w", err)
+	}
+	st := statuscontroller.CountRollout(model, replicas)
+	if st.OldReplicas == 0 {
+		return nil
+	}
+
+	maxSurge, maxUnavailable := model.RolloutLimits()
+	desired := st.DesiredReplicas
+
+	if add := min(desired-st.UpdatedReplicas, desired+maxSurge-st.UpdatedReplicas-st.OldReplicas); add > 0 {
+		if err := sch.Surge(model.ID, add); err != nil {
+			log.Printf("[rollout] cannot place revision %d replicas of model %s: %v", model.Revision, model.Name, err)
+		}
+	}
+
+	old := make([]store.ReplicaInfo, 0, st.OldReplicas)
+	for _, r := range replicas {
+		if r.NodeID != "" && r.Revision != model.Revision {
+			old = append(old, r)
+		}
+	}
+	sort.SliceStable(old, func(i, j int) bool {
+		return old[i].Status != constants.ModelReplicaStatusRunning && old[j].Status == constants.ModelReplicaStatusRunning
+	})
+
+	removable := st.UpdatedRunning + st.OldRunning - (desired - maxUnavailable)
+	for _, r := range old {
+		if r.Status == constants.ModelReplicaStatusRunning {
+			if removable <= 0 {
+				log.Printf("[rollout] waiting for revision %d of model %s: %d/%d updated replicas running",
+					model.Revision, model.Name, st.UpdatedRunning, desired)
+				break
+			}
+			removable--
+		}
+		sch.RemoveReplica(r)
+		log.Printf("[rollout] replaced replica %s (revision %d) of model %s", r.ID, r.Revision, model.Name)
+	}
+	return nil
+}
+
+// StartRolloutController runs HandleRollout periodically. It blocks forever and
+// is meant to be run in its own goroutine.
+func StartRolloutController(s *store.Store, sch *placementscheduler.Scheduler, interval time.Duration) {
+	log.Printf("Starting rollout controller with interval: %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := HandleRollout(s, sch); err != nil {
+			log.Printf("Error in rollout controller: %v", err)
+		}
+	}
+}
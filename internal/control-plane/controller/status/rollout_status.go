@@ -0,0 +1,74 @@
+package statuscontroller
+
+import (
+	"fmt"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// RolloutStatusResult describes how far the replicas of a model have been
+// replaced with its current revision. Only replicas bound to a node count.
+type RolloutStatusResult struct {
+	ModelID         string
+	Revision        int
+	DesiredReplicas int
+	UpdatedReplicas int // Replicas deployed with the current revision
+	UpdatedRunning  int
+	OldReplicas     int // Replicas still deployed with an older revision
+	OldRunning      int
+	Revisions       []store.ModelRevision
+}
+
+// Complete reports whether every old replica is gone and the desired number of
+// updated replicas is running.
+func (r RolloutStatusResult) Complete() bool {
+	return r.OldReplicas == 0 && r.UpdatedRunning >= r.DesiredReplicas
+}
+
+// GetRolloutStatus returns the rollout progress and revision history of a model.
+func GetRolloutStatus(s *store.Store, modelID string) (RolloutStatusResult, error) {
+	model, found, err := registrycontroller.GetModelByID(s, modelID)
+	if err != nil {
+		return RolloutStatusResult{}, fmt.Errorf("failed to get model: %w", err)
+	}
+	if !found {
+		return RolloutStatusResult{}, fmt.Errorf("model not found")
+	}
+
+	replicas, err := replicascheduler.ListReplicasByModelID(s, model.ID)
+	if err != nil {
+		return RolloutStatusResult{}, fmt.Errorf("failed to list replicas: %w", err)
+	}
+	return CountRollout(model, replicas), nil
+}
+
+// CountRollout tallies the bound replicas of model by revision.
+func CountRollout(model store.ModelInfo, replicas []store.ReplicaInfo) RolloutStatusResult {
+	result := RolloutStatusResult{
+		ModelID:         model.ID,
+		Revision:        model.Revision,
+		DesiredReplicas: model.DesiredReplicas(),
+		Revisions:       model.Revisions,
+	}
+	for _, r := range replicas {
+		if r.NodeID == "" {
+			continue
+		}
+		running := r.Status == constants.ModelReplicaStatusRunning
+		if r.Revision == model.Revision {
+			result.UpdatedReplicas++
+			if running {
+				result.UpdatedRunning++
+			}
+		} else {
+			result.OldReplicas++
+			if running {
+				result.OldRunning++
+			}
+		}
+	}
+	return result
+}
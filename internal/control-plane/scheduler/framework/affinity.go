@@ -0,0 +1,145 @@
+package frameworkscheduler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// NodeSelector filters out nodes that do not carry every label in ModelInfo.NodeSelector.
+type NodeSelector struct{}
+
+func (NodeSelector) Name() string { return "nodeselector" }
+
+func (NodeSelector) Filter(state *State, node store.NodeInfo) error {
+	for key, want := range state.Model.NodeSelector {
+		if got, ok := node.Labels[key]; !ok || got != want {
+			return fmt.Errorf("node does not match selector %s=%s", key, want)
+		}
+	}
+	return nil
+}
+
+// NodeAffinity enforces the required node affinity terms of a model and
+// scores nodes by the summed weight of the preferred terms they match.
+type NodeAffinity struct{}
+
+func (NodeAffinity) Name() string { return "nodeaffinity" }
+
+func (NodeAffinity) Filter(state *State, node store.NodeInfo) error {
+	na := nodeAffinity(state.Model)
+	if na == nil || len(na.Required) == 0 {
+		return nil
+	}
+	for _, term := range na.Required {
+		if MatchesTerm(term, node.Labels) {
+			return nil
+		}
+	}
+	return errors.New("node does not match required node affinity")
+}
+
+func (NodeAffinity) Score(state *State, node store.NodeInfo) int64 {
+	na := nodeAffinity(state.Model)
+	if na == nil {
+		return 0
+	}
+	var score int64
+	for _, p := range na.Preferred {
+		if MatchesTerm(p.Term, node.Labels) {
+			score += int64(p.Weight)
+		}
+	}
+	return score
+}
+
+// ModelAffinity enforces model affinity and anti-affinity: the required models
+// must (or must not) already have a replica on the node, and preferred terms
+// add their weight to nodes that satisfy them.
+type ModelAffinity struct{}
+
+func (ModelAffinity) Name() string { return "modelaffinity" }
+
+func (ModelAffinity) Filter(state *State, node store.NodeInfo) error {
+	if state.Model.Affinity == nil {
+		return nil
+	}
+	hosted := state.NodeModelNames[node.ID]
+	if a := state.Model.Affinity.ModelAffinity; a != nil {
+		for _, name := range a.Required {
+			if hosted[name] == 0 {
+				return fmt.Errorf("node does not host required model %q", name)
+			}
+		}
+	}
+	if a := state.Model.Affinity.ModelAntiAffinity; a != nil {
+		for _, name := range a.Required {
+			if hosted[name] > 0 {
+				return fmt.Errorf("node already hosts model %q (anti-affinity)", name)
+			}
+		}
+	}
+	return nil
+}
+
+func (ModelAffinity) Score(state *State, node store.NodeInfo) int64 {
+	if state.Model.Affinity == nil {
+		return 0
+	}
+	hosted := state.NodeModelNames[node.ID]
+	hostsAny := func(names []string) bool {
+		return slices.ContainsFunc(names, func(n string) bool { return hosted[n] > 0 })
+	}
+
+	var score int64
+	if a := state.Model.Affinity.ModelAffinity; a != nil {
+		for _, p := range a.Preferred {
+			if hostsAny(p.Models) {
+				score += int64(p.Weight)
+			}
+		}
+	}
+	if a := state.Model.Affinity.ModelAntiAffinity; a != nil {
+		for _, p := range a.Preferred {
+			if !hostsAny(p.Models) {
+				score += int64(p.Weight)
+			}
+		}
+	}
+	return score
+}
+
+// MatchesTerm reports whether labels satisfy every expression of term.
+func MatchesTerm(term store.NodeSelectorTerm, labels map[string]string) bool {
+	for _, expr := range term.MatchExpressions {
+		if !matchesExpression(expr, labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesExpression(expr store.LabelExpression, labels map[string]string) bool {
+	value, ok := labels[expr.Key]
+	switch expr.Operator {
+	case store.LabelOpIn:
+		return ok && slices.Contains(expr.Values, value)
+	case store.LabelOpNotIn:
+		return !ok || !slices.Contains(expr.Values, value)
+	case store.LabelOpExists:
+		return ok
+	case store.LabelOpDoesNotExist:
+		return !ok
+	default:
+		return false
+	}
+}
+
+func nodeAffinity(model store.ModelInfo) *store.NodeAffinity {
+	if model.Affinity == nil {
+		return nil
+	}
+	return model.Affinity.NodeAffinity
+}
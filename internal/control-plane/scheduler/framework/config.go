@@ -0,0 +1,87 @@
+package frameworkscheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Default plugin configuration, in the same format accepted by Parse.
+const (
+	DefaultFilters = "memory,storage,nodeselector,nodeaffinity,modelaffinity,tainttoleration"
+	DefaultScorers = "spread:2,memory:1,tops:1,accelerator:2,nodeaffinity:2,modelaffinity:2,tainttoleration:3"
+)
+
+// filterPlugins and scorePlugins map configuration names to the built-in plugins.
+var (
+	filterPlugins = map[string]FilterPlugin{
+		"memory":          MemoryFit{},
+		"storage":         StorageFit{},
+		"nodeselector":    NodeSelector{},
+		"nodeaffinity":    NodeAffinity{},
+		"modelaffinity":   ModelAffinity{},
+		"tainttoleration": TaintToleration{},
+	}
+	scorePlugins = map[string]ScorePlugin{
+		"memory":          MemoryFit{},
+		"tops":            TOPS{},
+		"accelerator":     Accelerator{},
+		"spread":          Spread{},
+		"nodeaffinity":    NodeAffinity{},
+		"modelaffinity":   ModelAffinity{},
+		"tainttoleration": TaintToleration{},
+	}
+)
+
+// Default returns a Framework with the default plugin set.
+func Default() *Framework {
+	fw, err := Parse(DefaultFilters, DefaultScorers)
+	if err != nil {
+		panic(err) // the defaults are static and always valid
+	}
+	return fw
+}
+
+// Parse builds a Framework from a comma-separated list of filter names
+// (e.g. "memory,storage") and a comma-separated list of score plugins with
+// optional weights (e.g. "spread:2,tops"). A missing weight defaults to 1.
+func Parse(filters, scorers string) (*Framework, error) {
+	var fs []FilterPlugin
+	for _, name := range splitList(filters) {
+		p, ok := filterPlugins[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter plugin %q", name)
+		}
+		fs = append(fs, p)
+	}
+
+	var ss []WeightedScorer
+	for _, entry := range splitList(scorers) {
+		name, weightStr, hasWeight := strings.Cut(entry, ":")
+		p, ok := scorePlugins[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown score plugin %q", name)
+		}
+		weight := int64(1)
+		if hasWeight {
+			w, err := strconv.ParseInt(weightStr, 10, 64)
+			if err != nil || w < 0 {
+				return nil, fmt.Errorf("invalid weight %q for score plugin %q", weightStr, name)
+			}
+			weight = w
+		}
+		ss = append(ss, WeightedScorer{Plugin: p, Weight: weight})
+	}
+
+	return New(fs, ss), nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
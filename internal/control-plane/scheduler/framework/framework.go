@@ -0,0 +1,150 @@
+// Package frameworkscheduler implements the filter/score pipeline used by the
+// placement scheduler to choose a node for a new replica.
+//
+// Every candidate node first passes through all Filter plugins; a node rejected
+// by any filter is not considered. The remaining nodes are scored by every
+// Score plugin, each plugin's scores are normalized to 0..MaxScore across the
+// candidates, multiplied by the plugin's weight and summed. The highest total
+// wins; ties go to the node with the fewest replicas overall, then the lowest ID.
+package frameworkscheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// MaxScore is the highest normalized score a Score plugin can contribute (before weighting).
+const MaxScore int64 = 100
+
+// State carries the information plugins need about the replica being placed
+// and the replicas already bound to nodes. It is built once per placement.
+type State struct {
+	// Model is the model a replica is being placed for.
+	Model store.ModelInfo
+	// ModelReplicas counts the replicas of Model bound to each node ID.
+	ModelReplicas map[string]int
+	// AllocatedBytes sums the model size of all replicas bound to each node ID.
+	AllocatedBytes map[string]int64
+	// NodeModelNames counts, per node ID, the bound replicas of each model name
+	// in Model's namespace. Used by model affinity and anti-affinity.
+	NodeModelNames map[string]map[string]int
+}
+
+// FilterPlugin rejects nodes that cannot host the replica.
+type FilterPlugin interface {
+	Name() string
+	// Filter returns nil if the node can host the replica, or an error describing why not.
+	Filter(state *State, node store.NodeInfo) error
+}
+
+// ScorePlugin ranks nodes that passed all filters.
+type ScorePlugin interface {
+	Name() string
+	// Score returns a non-negative raw score; higher is better. Raw scores are
+	// normalized across all candidate nodes by the framework.
+	Score(state *State, node store.NodeInfo) int64
+}
+
+// WeightedScorer pairs a ScorePlugin with its weight.
+type WeightedScorer struct {
+	Plugin ScorePlugin
+	Weight int64
+}
+
+// Framework runs a fixed set of filter and score plugins.
+type Framework struct {
+	filters []FilterPlugin
+	scorers []WeightedScorer
+}
+
+// New creates a Framework from the given plugins.
+func New(filters []FilterPlugin, scorers []WeightedScorer) *Framework {
+	return &Framework{filters: filters, scorers: scorers}
+}
+
+// Filters returns the names of the configured filter plugins.
+func (f *Framework) Filters() []string {
+	names := make([]string, len(f.filters))
+	for i, p := range f.filters {
+		names[i] = p.Name()
+	}
+	return names
+}
+
+// Scorers returns the configured score plugins as "name:weight".
+func (f *Framework) Scorers() []string {
+	names := make([]string, len(f.scorers))
+	for i, s := range f.scorers {
+		names[i] = fmt.Sprintf("%s:%d", s.Plugin.Name(), s.Weight)
+	}
+	return names
+}
+
+// SelectNode returns the best node for a replica of state.Model among nodes.
+// If no node passes the filters, the error lists the reason for every node.
+func (f *Framework) SelectNode(state *State, nodes []store.NodeInfo) (store.NodeInfo, error) {
+	if len(nodes) == 0 {
+		return store.NodeInfo{}, fmt.Errorf("no candidate nodes")
+	}
+
+	feasible := make([]store.NodeInfo, 0, len(nodes))
+	var reasons []string
+	for _, node := range nodes {
+		if err := f.runFilters(state, node); err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: %v", node.ID, err))
+			continue
+		}
+		feasible = append(feasible, node)
+	}
+	if len(feasible) == 0 {
+		sort.Strings(reasons)
+		return store.NodeInfo{}, fmt.Errorf("0/%d nodes are available: %s", len(nodes), strings.Join(reasons, "; "))
+	}
+
+	totals := f.score(state, feasible)
+
+	sort.SliceStable(feasible, func(i, j int) bool {
+		a, b := feasible[i], feasible[j]
+		if totals[a.ID] != totals[b.ID] {
+			return totals[a.ID] > totals[b.ID]
+		}
+		if len(a.AssignedModels) != len(b.AssignedModels) {
+			return len(a.AssignedModels) < len(b.AssignedModels)
+		}
+		return a.ID < b.ID
+	})
+	return feasible[0], nil
+}
+
+func (f *Framework) runFilters(state *State, node store.NodeInfo) error {
+	for _, p := range f.filters {
+		if err := p.Filter(state, node); err != nil {
+			return fmt.Errorf("%s: %w", p.Name(), err)
+		}
+	}
+	return nil
+}
+
+// score returns the weighted, normalized total score of every node.
+func (f *Framework) score(state *State, nodes []store.NodeInfo) map[string]int64 {
+	totals := make(map[string]int64, len(nodes))
+	raw := make([]int64, len(nodes))
+
+	for _, s := range f.scorers {
+		var highest int64
+		for i, node := range nodes {
+			raw[i] = max(s.Plugin.Score(state, node), 0)
+			highest = max(highest, raw[i])
+		}
+		if highest == 0 {
+			continue // plugin does not distinguish between the candidates
+		}
+		for i, node := range nodes {
+			totals[node.ID] += s.Weight * raw[i] * MaxScore / highest
+		}
+	}
+	return totals
+}
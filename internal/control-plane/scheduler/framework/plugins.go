@@ -0,0 +1,114 @@
+package frameworkscheduler
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+const bytesPerMB = 1024 * 1024
+
+// acceleratorDevices are the compute devices that speed up vision models.
+var acceleratorDevices = []constants.ComputeDeviceType{
+	constants.ComputeDeviceGPU,
+	constants.ComputeDeviceNPU,
+	constants.ComputeDeviceTPU,
+	constants.ComputeDeviceIntegratedGPU,
+}
+
+// acceleratedModelTypes are the model types that benefit from an accelerator.
+var acceleratedModelTypes = []constants.ModelType{
+	constants.ModelTypeCNN,
+	constants.ModelTypeVisionTransformer,
+}
+
+// MemoryFit filters out nodes without enough free memory for the model and
+// prefers nodes that keep the most memory free after placement. Memory already
+// claimed by replicas bound to the node is subtracted from the reported free memory.
+type MemoryFit struct{}
+
+func (MemoryFit) Name() string { return "memory" }
+
+func (MemoryFit) Filter(state *State, node store.NodeInfo) error {
+	if state.Model.ModelSize <= 0 {
+		return nil
+	}
+	free := freeMemoryMB(state, node)
+	if need := sizeMB(state.Model.ModelSize); free < need {
+		return fmt.Errorf("insufficient memory (need %d MB, %d MB free)", need, free)
+	}
+	return nil
+}
+
+func (MemoryFit) Score(state *State, node store.NodeInfo) int64 {
+	return max(freeMemoryMB(state, node)-sizeMB(state.Model.ModelSize), 0)
+}
+
+// StorageFit filters out nodes without enough free disk space to cache the model file.
+type StorageFit struct{}
+
+func (StorageFit) Name() string { return "storage" }
+
+func (StorageFit) Filter(state *State, node store.NodeInfo) error {
+	if state.Model.ModelSize <= 0 {
+		return nil
+	}
+	free := node.ResourceCapabilities.Storage.Free - sizeMB(state.AllocatedBytes[node.ID])
+	if need := sizeMB(state.Model.ModelSize); free < need {
+		return fmt.Errorf("insufficient storage (need %d MB, %d MB free)", need, free)
+	}
+	return nil
+}
+
+// TOPS prefers nodes with more total compute across all available devices.
+type TOPS struct{}
+
+func (TOPS) Name() string { return "tops" }
+
+func (TOPS) Score(state *State, node store.NodeInfo) int64 {
+	var tops float64
+	for _, dev := range node.ResourceCapabilities.ComputeDevices {
+		if dev.IsAvailable {
+			tops += dev.TOPS
+		}
+	}
+	return int64(tops * 100)
+}
+
+// Accelerator prefers nodes with an available GPU/NPU for model types that
+// benefit from one (cnn, vision_transformer). Other model types are unaffected.
+type Accelerator struct{}
+
+func (Accelerator) Name() string { return "accelerator" }
+
+func (Accelerator) Score(state *State, node store.NodeInfo) int64 {
+	if !slices.Contains(acceleratedModelTypes, state.Model.ModelType) {
+		return 0
+	}
+	for _, dev := range node.ResourceCapabilities.ComputeDevices {
+		if dev.IsAvailable && slices.Contains(acceleratorDevices, dev.Type) {
+			return MaxScore
+		}
+	}
+	return 0
+}
+
+// Spread prefers nodes hosting fewer replicas of the same model.
+type Spread struct{}
+
+func (Spread) Name() string { return "spread" }
+
+func (Spread) Score(state *State, node store.NodeInfo) int64 {
+	return MaxScore / int64(1+state.ModelReplicas[node.ID])
+}
+
+func freeMemoryMB(state *State, node store.NodeInfo) int64 {
+	return node.ResourceCapabilities.Memory.Free - sizeMB(state.AllocatedBytes[node.ID])
+}
+
+// sizeMB converts bytes to megabytes, rounding up.
+func sizeMB(bytes int64) int64 {
+	return (bytes + bytesPerMB - 1) / bytesPerMB
+}
@@ -0,0 +1,35 @@
+package frameworkscheduler
+
+import (
+	"fmt"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// TaintToleration filters out nodes with NoSchedule or NoExecute taints the
+// model does not tolerate and prefers nodes with fewer untolerated
+// PreferNoSchedule taints.
+type TaintToleration struct{}
+
+func (TaintToleration) Name() string { return "tainttoleration" }
+
+func (TaintToleration) Filter(state *State, node store.NodeInfo) error {
+	taint, ok := store.ToleratesAll(state.Model.Tolerations, node.Taints, store.TaintEffectNoSchedule, store.TaintEffectNoExecute)
+	if !ok {
+		return fmt.Errorf("node has untolerated taint %s", taint)
+	}
+	return nil
+}
+
+func (TaintToleration) Score(state *State, node store.NodeInfo) int64 {
+	untolerated := 0
+	for _, taint := range node.Taints {
+		if taint.Effect != store.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if _, ok := store.ToleratesAll(state.Model.Tolerations, []store.Taint{taint}); !ok {
+			untolerated++
+		}
+	}
+	return MaxScore / int64(1+untolerated)
+}
@@ -0,0 +1,610 @@
+package placementscheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
+	deploycaller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/api/grpc/deploy"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	frameworkscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/framework"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultDeployTimeout bounds a single DeployModel call to an agent.
+const defaultDeployTimeout = 15 * time.Second
+
+// Scheduler reconciles the desired replica count of every registered model
+// against the replicas actually placed on nodes. Missing replicas are created
+// in the store, bound to an online node chosen by the scheduling framework and
+// deployed through the agent's DeployAPI. Extra replicas, and replicas of
+// models that were deregistered, are undeployed and deleted.
+type Scheduler struct {
+	store         *store.Store
+	framework     *frameworkscheduler.Framework
+	deployTimeout time.Duration
+
+	mu sync.Mutex // serializes reconciliation passes
+}
+
+// New creates a Scheduler backed by the given store that uses the default plugin set.
+func New(s *store.Store) *Scheduler {
+	return NewWithFramework(s, frameworkscheduler.Default())
+}
+
+// NewWithFramework creates a Scheduler that selects nodes with the given framework.
+func NewWithFramework(s *store.Store, fw *frameworkscheduler.Framework) *Scheduler {
+	return &Scheduler{
+		store:         s,
+		framework:     fw,
+		deployTimeout: defaultDeployTimeout,
+	}
+}
+
+// Start runs Reconcile immediately and then periodically at the given interval.
+// It blocks forever and is meant to be run in its own goroutine.
+func (sch *Scheduler) Start(interval time.Duration) {
+	log.Printf("Starting placement scheduler with interval: %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if err := sch.Reconcile(); err != nil {
+		log.Printf("Error in initial scheduling pass: %v", err)
+	}
+
+	for range ticker.C {
+		if err := sch.Reconcile(); err != nil {
+			log.Printf("Error in scheduling pass: %v", err)
+		}
+	}
+}
+
+// Reconcile runs a single scheduling pass over all registered models.
+// Replicas of deregistered models are removed and replicas on nodes with
+// NoExecute taints their model does not tolerate are evicted first, so the
+// pass can replace them elsewhere.
+// Failures for an individual model are logged and do not stop the pass.
+func (sch *Scheduler) Reconcile() error {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	models, err := registrycontroller.ListModels(sch.store)
+	if err != nil {
+		return fmt.Errorf("list models: %w", err)
+	}
+
+	if err := sch.removeOrphaned(models); err != nil {
+		log.Printf("[scheduler] orphaned replicas: %v", err)
+	}
+	if err := sch.evictUntolerated(models); err != nil {
+		log.Printf("[scheduler] taint eviction: %v", err)
+	}
+
+	for _, model := range models {
+		if err := sch.reconcileModel(model); err != nil {
+			log.Printf("[scheduler] model %s (%s): %v", model.Name, model.ID, err)
+		}
+	}
+	return nil
+}
+
+// ReconcileModel runs a scheduling pass for a single model.
+func (sch *Scheduler) ReconcileModel(modelID string) error {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	model, found, err := registrycontroller.GetModelByID(sch.store, modelID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("model %q not found", modelID)
+	}
+	return sch.reconcileModel(model)
+}
+
+// reconcileModel places as many replicas as the model is missing, or removes
+// the ones it has too many of, relative to ModelInfo.DesiredReplicas. Only
+// replicas bound to a node count towards the desired replica count. While a
+// rollout is in progress the rollout controller removes surplus replicas
+// instead. Once the model is fully placed, unbound replicas left behind by
+// evacuated nodes are deleted.
+func (sch *Scheduler) reconcileModel(model store.ModelInfo) error {
+	defer sch.syncReplicaState(model.ID)
+
+	replicas, err := replicascheduler.ListReplicasByModelID(sch.store, model.ID)
+	if err != nil {
+		return fmt.Errorf("list replicas: %w", err)
+	}
+
+	if missing := model.DesiredReplicas() - countBound(replicas); missing > 0 {
+		if err := sch.placeMissing(model, missing); err != nil {
+			return err
+		}
+	} else if missing < 0 && !rollingOut(model, replicas) {
+		if err := sch.removeExcess(model, replicas); err != nil {
+			return err
+		}
+	}
+
+	sch.pruneUnbound(model.ID)
+	return nil
+}
+
+// Surge places count replicas of the model in addition to the ones it already
+// has, e.g. to bring up replacements before replicas are removed from a node
+// that is being drained. Unlike a reconciliation pass it does not stop at the
+// desired replica count.
+func (sch *Scheduler) Surge(modelID string, count int) error {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	model, found, err := registrycontroller.GetModelByID(sch.store, modelID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("model %q not found", modelID)
+	}
+	defer sch.syncReplicaState(model.ID)
+	return sch.placeMissing(model, count)
+}
+
+// RemoveReplica undeploys a replica from its node, unbinds it and deletes it
+// from the store.
+func (sch *Scheduler) RemoveReplica(replica store.ReplicaInfo) {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+
+	sch.undeployReplica(replica)
+	sch.syncReplicaState(replica.ModelID)
+}
+
+// removeExcess undeploys replicas of model beyond its desired replica count.
+// Replicas on draining nodes are left to the drain controller, which surges
+// replacements above the desired count on purpose. Replicas on cordoned nodes
+// go first, then replicas that are not running.
+func (sch *Scheduler) removeExcess(model store.ModelInfo, replicas []store.ReplicaInfo) error {
+	nodes, err := registrycontroller.ListNodes(sch.store)
+	if err != nil {
+		return fmt.Errorf("list nodes: %w", err)
+	}
+	nodeByID := make(map[string]store.NodeInfo, len(nodes))
+	for _, n := range nodes {
+		nodeByID[n.ID] = n
+	}
+
+	candidates := make([]store.ReplicaInfo, 0, len(replicas))
+	for _, r := range replicas {
+		if r.NodeID != "" && !nodeByID[r.NodeID].Draining {
+			candidates = append(candidates, r)
+		}
+	}
+	excess := len(candidates) - model.DesiredReplicas()
+	if excess <= 0 {
+		return nil
+	}
+
+	rank := func(r store.ReplicaInfo) int {
+		rank := 0
+		if !nodeByID[r.NodeID].Unschedulable {
+			rank += 2
+		}
+		if r.Status == constants.ModelReplicaStatusRunning {
+			rank++
+		}
+		return rank
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return rank(candidates[i]) < rank(candidates[j])
+	})
+
+	for _, r := range candidates[:excess] {
+		sch.undeployReplica(r)
+		log.Printf("[scheduler] removed replica %s of model %s from node %s (scaled down to %d)", r.ID, model.Name, r.NodeID, model.DesiredReplicas())
+	}
+	return nil
+}
+
+// removeOrphaned undeploys and deletes the replicas of models that are no
+// longer registered.
+func (sch *Scheduler) removeOrphaned(models []store.ModelInfo) error {
+	replicas, err := replicascheduler.ListReplicas(sch.store)
+	if err != nil {
+		return fmt.Errorf("list replicas: %w", err)
+	}
+	registered := make(map[string]bool, len(models))
+	for _, m := range models {
+		registered[m.ID] = true
+	}
+
+	for _, r := range replicas {
+		if registered[r.ModelID] {
+			continue
+		}
+		sch.undeployReplica(r)
+		log.Printf("[scheduler] removed replica %s of deregistered model %s", r.ID, r.ModelID)
+	}
+	return nil
+}
+
+// placeMissing places missing replicas of model one at a time on the best available node.
+func (sch *Scheduler) placeMissing(model store.ModelInfo, missing int) error {
+	// Nodes that rejected a deployment during this pass are not retried until the next one.
+	excluded := make(map[string]bool)
+
+	for placed := 0; placed < missing; {
+		nodes, err := registrycontroller.ListNodesByStatuses(sch.store, []constants.Status{constants.StatusOnline})
+		if err != nil {
+			return fmt.Errorf("list online nodes: %w", err)
+		}
+		candidates := make([]store.NodeInfo, 0, len(nodes))
+		for _, n := range nodes {
+			if !excluded[n.ID] && !n.Unschedulable {
+				candidates = append(candidates, n)
+			}
+		}
+		if len(candidates) == 0 {
+			return fmt.Errorf("no online node available for %d remaining replica(s)", missing-placed)
+		}
+
+		state, err := sch.buildState(model)
+		if err != nil {
+			return err
+		}
+		node, err := sch.framework.SelectNode(state, candidates)
+		if err != nil {
+			return fmt.Errorf("cannot place %d remaining replica(s): %w", missing-placed, err)
+		}
+
+		replica, err := sch.PlaceReplica(model, node)
+		if err != nil {
+			log.Printf("[scheduler] failed to place replica of model %s on node %s: %v", model.Name, node.ID, err)
+			excluded[node.ID] = true
+			continue
+		}
+
+		log.Printf("[scheduler] placed replica %s of model %s on node %s", replica.ID, model.Name, node.ID)
+		placed++
+	}
+
+	return nil
+}
+
+// pruneUnbound deletes replicas of the model that are no longer bound to a node.
+// Replicas evicted from a node that is still offline are kept, so that the
+// heartbeat controller can undeploy them if the node comes back.
+func (sch *Scheduler) pruneUnbound(modelID string) {
+	replicas, err := replicascheduler.ListReplicasByModelID(sch.store, modelID)
+	if err != nil {
+		log.Printf("[scheduler] failed to list replicas of model %s: %v", modelID, err)
+		return
+	}
+	for _, r := range replicas {
+		if r.NodeID != "" {
+			continue
+		}
+		if r.EvictedFrom != "" {
+			node, found, err := registrycontroller.GetNodeByID(sch.store, r.EvictedFrom)
+			if err != nil || (found && node.Status == constants.StatusOffline) {
+				continue
+			}
+		}
+		if err := replicascheduler.DeleteReplica(sch.store, r.ID); err != nil {
+			log.Printf("[scheduler] failed to delete unbound replica %s: %v", r.ID, err)
+			continue
+		}
+		log.Printf("[scheduler] deleted unbound replica %s of model %s", r.ID, modelID)
+	}
+}
+
+// PlaceReplica creates a new pending replica of model bound to node and deploys
+// it through the node's DeployAPI. If the agent cannot be reached or rejects the
+// deployment, the replica is removed again so a later pass can retry elsewhere.
+func (sch *Scheduler) PlaceReplica(model store.ModelInfo, node store.NodeInfo) (store.ReplicaInfo, error) {
+	replica := store.ReplicaInfo{
+		ID:       uuid.New().String(),
+		ModelID:  model.ID,
+		Name:     model.Name,
+		NodeID:   node.ID,
+		Status:   constants.ModelReplicaStatusPending,
+		Revision: model.Revision,
+	}
+
+	if err := replicascheduler.CreateReplica(sch.store, replica.ID, replica); err != nil {
+		return store.ReplicaInfo{}, fmt.Errorf("create replica: %w", err)
+	}
+	if err := registrycontroller.AssignReplicaToNode(sch.store, node.ID, replica.ID); err != nil {
+		_ = replicascheduler.DeleteReplica(sch.store, replica.ID)
+		return store.ReplicaInfo{}, fmt.Errorf("assign replica to node: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sch.deployTimeout)
+	defer cancel()
+
+	resp, err := deploycaller.CallDeployModel(ctx, node, deployRequest(model, replica.ID))
+	if err == nil && !resp.GetSuccess() {
+		err = fmt.Errorf("agent rejected deployment: %s", resp.GetMessage())
+	}
+	if err != nil {
+		sch.unbindReplica(node.ID, replica.ID)
+		return store.ReplicaInfo{}, err
+	}
+
+	return replica, nil
+}
+
+// RedeployReplica sends the deployment of an existing replica to the node it is
+// bound to again, reusing its replica ID and the model revision it was placed
+// with. It is used to restart failed replicas.
+func (sch *Scheduler) RedeployReplica(replica store.ReplicaInfo) error {
+	if replica.NodeID == "" {
+		return fmt.Errorf("replica %s is not bound to a node", replica.ID)
+	}
+
+	model, found, err := registrycontroller.GetModelByID(sch.store, replica.ModelID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("model %q not found", replica.ModelID)
+	}
+	node, found, err := registrycontroller.GetNodeByID(sch.store, replica.NodeID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("node %q not found", replica.NodeID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sch.deployTimeout)
+	defer cancel()
+
+	resp, err := deploycaller.CallDeployModel(ctx, node, deployRequest(model.AtRevision(replica.Revision), replica.ID))
+	if err != nil {
+		return err
+	}
+	if !resp.GetSuccess() {
+		return fmt.Errorf("agent rejected deployment: %s", resp.GetMessage())
+	}
+	return nil
+}
+
+// EvictReplica unbinds a replica from its node and marks it failed with reason,
+// so the next reconciliation pass places a replacement. The replica record is
+// kept until its model is fully placed again and nodeID is no longer offline.
+// It returns the replica's model ID, or "" if the replica does not exist.
+func EvictReplica(s *store.Store, nodeID, replicaID, reason string) (string, error) {
+	var modelID string
+	found, err := replicascheduler.MutateReplica(s, replicaID, func(r *store.ReplicaInfo) {
+		modelID = r.ModelID
+		r.Status = constants.ModelReplicaStatusFailed
+		r.ErrorMessage = reason
+		r.NodeID = ""
+		r.EvictedFrom = nodeID
+	})
+	if err != nil {
+		return "", fmt.Errorf("mark replica %s failed: %w", replicaID, err)
+	}
+	if err := registrycontroller.UnassignReplicaFromNode(s, nodeID, replicaID); err != nil {
+		return "", fmt.Errorf("unassign replica %s from node %s: %w", replicaID, nodeID, err)
+	}
+	if !found {
+		return "", nil
+	}
+	return modelID, nil
+}
+
+// evictUntolerated evicts replicas bound to nodes with a NoExecute taint that
+// their model does not tolerate.
+func (sch *Scheduler) evictUntolerated(models []store.ModelInfo) error {
+	nodes, err := registrycontroller.ListNodes(sch.store)
+	if err != nil {
+		return fmt.Errorf("list nodes: %w", err)
+	}
+	byID := make(map[string]store.ModelInfo, len(models))
+	for _, m := range models {
+		byID[m.ID] = m
+	}
+
+	for _, node := range nodes {
+		if len(node.Taints) == 0 || len(node.AssignedModels) == 0 {
+			continue
+		}
+		for _, replicaID := range node.AssignedModels {
+			replica, found, err := replicascheduler.GetReplicaByID(sch.store, replicaID)
+			if err != nil || !found {
+				continue
+			}
+			model, ok := byID[replica.ModelID]
+			if !ok {
+				continue
+			}
+			taint, tolerated := store.ToleratesAll(model.Tolerations, node.Taints, store.TaintEffectNoExecute)
+			if tolerated {
+				continue
+			}
+			reason := fmt.Sprintf("evicted from node %s: untolerated taint %s", node.ID, taint)
+			sch.callUndeploy(node, replicaID)
+			if _, err := EvictReplica(sch.store, node.ID, replicaID, reason); err != nil {
+				log.Printf("[scheduler] failed to evict replica %s: %v", replicaID, err)
+				continue
+			}
+			log.Printf("[scheduler] replica %s of model %s %s", replicaID, model.Name, reason)
+		}
+	}
+	return nil
+}
+
+// undeployReplica removes a replica from the agent it is bound to, then unbinds
+// it and deletes it from the store. The store is cleaned up even if the agent
+// cannot be reached.
+func (sch *Scheduler) undeployReplica(replica store.ReplicaInfo) {
+	if replica.NodeID != "" {
+		node, found, err := registrycontroller.GetNodeByID(sch.store, replica.NodeID)
+		if err != nil {
+			log.Printf("[scheduler] failed to load node %s: %v", replica.NodeID, err)
+		} else if found {
+			sch.callUndeploy(node, replica.ID)
+		}
+	}
+	sch.unbindReplica(replica.NodeID, replica.ID)
+}
+
+// callUndeploy asks the agent on node to undeploy a replica. Offline nodes are
+// not contacted. Failures are logged; a replica the agent does not know is
+// already gone.
+func (sch *Scheduler) callUndeploy(node store.NodeInfo, replicaID string) {
+	if node.Status == constants.StatusOffline {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sch.deployTimeout)
+	defer cancel()
+
+	resp, err := deploycaller.CallUndeployModel(ctx, node, &deploypb.UndeployModelRequest{ReplicaId: replicaID})
+	if status.Code(err) == codes.NotFound {
+		return
+	}
+	if err == nil && !resp.GetSuccess() {
+		err = fmt.Errorf("agent rejected undeployment: %s", resp.GetMessage())
+	}
+	if err != nil {
+		log.Printf("[scheduler] failed to undeploy replica %s from node %s: %v", replicaID, node.ID, err)
+	}
+}
+
+// unbindReplica removes a replica from its node and deletes it from the store.
+func (sch *Scheduler) unbindReplica(nodeID, replicaID string) {
+	if err := registrycontroller.UnassignReplicaFromNode(sch.store, nodeID, replicaID); err != nil {
+		log.Printf("[scheduler] failed to unassign replica %s from node %s: %v", replicaID, nodeID, err)
+	}
+	if err := replicascheduler.DeleteReplica(sch.store, replicaID); err != nil {
+		log.Printf("[scheduler] failed to delete replica %s: %v", replicaID, err)
+	}
+}
+
+// syncReplicaState refreshes the model's ReplicaIDs and ActiveReplicas from the replica records.
+func (sch *Scheduler) syncReplicaState(modelID string) {
+	replicas, err := replicascheduler.ListReplicasByModelID(sch.store, modelID)
+	if err != nil {
+		log.Printf("[scheduler] failed to list replicas of model %s: %v", modelID, err)
+		return
+	}
+
+	ids := make([]string, 0, len(replicas))
+	running := 0
+	for _, r := range replicas {
+		ids = append(ids, r.ID)
+		if r.Status == constants.ModelReplicaStatusRunning {
+			running++
+		}
+	}
+
+	if err := registrycontroller.SetModelReplicaState(sch.store, modelID, ids, running); err != nil {
+		log.Printf("[scheduler] failed to update replica state of model %s: %v", modelID, err)
+	}
+}
+
+// buildState collects the per-node replica counts and allocated model sizes
+// the scheduling plugins need to place a replica of model.
+func (sch *Scheduler) buildState(model store.ModelInfo) (*frameworkscheduler.State, error) {
+	models, err := registrycontroller.ListModels(sch.store)
+	if err != nil {
+		return nil, fmt.Errorf("list models: %w", err)
+	}
+	sizes := make(map[string]int64, len(models))
+	names := make(map[string]string, len(models))
+	for _, m := range models {
+		sizes[m.ID] = m.ModelSize
+		if m.Namespace == model.Namespace {
+			names[m.ID] = m.Name
+		}
+	}
+
+	replicas, err := replicascheduler.ListReplicas(sch.store)
+	if err != nil {
+		return nil, fmt.Errorf("list replicas: %w", err)
+	}
+
+	state := &frameworkscheduler.State{
+		Model:          model,
+		ModelReplicas:  make(map[string]int),
+		AllocatedBytes: make(map[string]int64),
+		NodeModelNames: make(map[string]map[string]int),
+	}
+	for _, r := range replicas {
+		if r.NodeID == "" {
+			continue
+		}
+		state.AllocatedBytes[r.NodeID] += sizes[r.ModelID]
+		if r.ModelID == model.ID {
+			state.ModelReplicas[r.NodeID]++
+		}
+		if name, ok := names[r.ModelID]; ok {
+			if state.NodeModelNames[r.NodeID] == nil {
+				state.NodeModelNames[r.NodeID] = make(map[string]int)
+			}
+			state.NodeModelNames[r.NodeID][name]++
+		}
+	}
+	return state, nil
+}
+
+// rollingOut reports whether any bound replica of model was deployed with an
+// older revision than the model's current one.
+func rollingOut(model store.ModelInfo, replicas []store.ReplicaInfo) bool {
+	for _, r := range replicas {
+		if r.NodeID != "" && r.Revision != model.Revision {
+			return true
+		}
+	}
+	return false
+}
+
+// countBound returns the number of replicas currently bound to a node.
+func countBound(replicas []store.ReplicaInfo) int {
+	n := 0
+	for _, r := range replicas {
+		if r.NodeID != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// deployRequest builds the DeployModelRequest sent to an agent for a replica of model.
+func deployRequest(model store.ModelInfo, replicaID string) *deploypb.DeployModelRequest {
+	req := &deploypb.DeployModelRequest{
+		ModelId:       model.ID,
+		Name:          model.Name,
+		Version:       model.Version,
+		FilePath:      model.FilePath,
+		ModelType:     string(model.ModelType),
+		ModelSize:     model.ModelSize,
+		InstanceCount: 1,
+		Namespace:     model.Namespace,
+		Sha256Hash:    model.SHA256Hash,
+		ReplicaId:     replicaID,
+		InputFormat:   string(model.InputFormat),
+		Revision:      int32(model.Revision),
+	}
+	if b := model.Batching; b != nil {
+		req.MaxBatchSize = int32(b.MaxBatchSize)
+		req.MaxBatchWaitMs = int32(b.MaxBatchWaitMs)
+	}
+	if raw, err := model.Preprocessing.Encode(); err == nil {
+		req.Preprocessing = raw
+	}
+	return req
+}
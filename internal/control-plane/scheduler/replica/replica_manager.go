@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
 )
 
+// replicaMu serializes read-modify-write cycles on replica records.
+var replicaMu sync.Mutex
+
 // CreateReplica stores a new ReplicaInfo under the given replicaID.
 func CreateReplica(s *store.Store, replicaID string, info store.ReplicaInfo) error {
 	if replicaID == "" {
@@ -66,6 +70,27 @@ func UpdateReplicaInfo(s *store.Store, replicaID string, info store.ReplicaInfo)
 	return s.Put("replica:"+replicaID, b)
 }
 
+// MutateReplica loads a replica, applies fn and persists the result while holding
+// replicaMu. It returns false if the replica does not exist.
+func MutateReplica(s *store.Store, replicaID string, fn func(info *store.ReplicaInfo)) (bool, error) {
+	replicaMu.Lock()
+	defer replicaMu.Unlock()
+
+	info, found, err := GetReplicaByID(s, replicaID)
+	if err != nil || !found {
+		return false, err
+	}
+
+	fn(&info)
+	info.ID = replicaID
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		return false, fmt.Errorf("marshal replica info: %w", err)
+	}
+	return true, s.Put("replica:"+replicaID, b)
+}
+
 // DeleteReplica removes a replica from the store.
 func DeleteReplica(s *store.Store, replicaID string) error {
 	if replicaID == "" {
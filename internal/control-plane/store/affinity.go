@@ -0,0 +1,118 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Label selector operators supported in LabelExpression.
+const (
+	LabelOpIn           = "In"
+	LabelOpNotIn        = "NotIn"
+	LabelOpExists       = "Exists"
+	LabelOpDoesNotExist = "DoesNotExist"
+)
+
+// Affinity describes placement constraints of a model's replicas.
+type Affinity struct {
+	NodeAffinity      *NodeAffinity  `json:"node_affinity,omitempty" yaml:"node_affinity,omitempty"`
+	ModelAffinity     *ModelAffinity `json:"model_affinity,omitempty" yaml:"model_affinity,omitempty"`           // co-locate with other models
+	ModelAntiAffinity *ModelAffinity `json:"model_anti_affinity,omitempty" yaml:"model_anti_affinity,omitempty"` // keep away from other models
+}
+
+// NodeAffinity constrains replicas to nodes by their labels.
+// A node satisfies Required if it matches at least one of the terms.
+type NodeAffinity struct {
+	Required  []NodeSelectorTerm     `json:"required,omitempty" yaml:"required,omitempty"`
+	Preferred []WeightedNodeSelector `json:"preferred,omitempty" yaml:"preferred,omitempty"`
+}
+
+// NodeSelectorTerm matches a node if all of its expressions match.
+type NodeSelectorTerm struct {
+	MatchExpressions []LabelExpression `json:"match_expressions" yaml:"match_expressions"`
+}
+
+// WeightedNodeSelector adds Weight to the score of nodes matching Term.
+type WeightedNodeSelector struct {
+	Weight int              `json:"weight" yaml:"weight"`
+	Term   NodeSelectorTerm `json:"term" yaml:"term"`
+}
+
+// LabelExpression matches a node label against Operator and Values.
+type LabelExpression struct {
+	Key      string   `json:"key" yaml:"key"`
+	Operator string   `json:"operator" yaml:"operator"` // In, NotIn, Exists, DoesNotExist
+	Values   []string `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// ModelAffinity relates replicas to replicas of other models (by name, in the
+// same namespace) already placed on a node. Used as affinity, a node satisfies
+// Required if it hosts every listed model; used as anti-affinity, if it hosts none.
+type ModelAffinity struct {
+	Required  []string                `json:"required,omitempty" yaml:"required,omitempty"`
+	Preferred []WeightedModelSelector `json:"preferred,omitempty" yaml:"preferred,omitempty"`
+}
+
+// WeightedModelSelector adds Weight to the score of nodes that satisfy the
+// affinity (or anti-affinity) towards Models.
+type WeightedModelSelector struct {
+	Weight int      `json:"weight" yaml:"weight"`
+	Models []string `json:"models" yaml:"models"`
+}
+
+// Validate checks that the affinity rules are well formed.
+func (a *Affinity) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if na := a.NodeAffinity; na != nil {
+		for i, term := range na.Required {
+			if err := validateTerm(term); err != nil {
+				return fmt.Errorf("node_affinity.required[%d]: %w", i, err)
+			}
+		}
+		for i, p := range na.Preferred {
+			if p.Weight <= 0 {
+				return fmt.Errorf("node_affinity.preferred[%d]: weight must be positive", i)
+			}
+			if err := validateTerm(p.Term); err != nil {
+				return fmt.Errorf("node_affinity.preferred[%d]: %w", i, err)
+			}
+		}
+	}
+	for field, ma := range map[string]*ModelAffinity{"model_affinity": a.ModelAffinity, "model_anti_affinity": a.ModelAntiAffinity} {
+		if ma == nil {
+			continue
+		}
+		for i, p := range ma.Preferred {
+			if p.Weight <= 0 || len(p.Models) == 0 {
+				return fmt.Errorf("%s.preferred[%d]: weight must be positive and models non-empty", field, i)
+			}
+		}
+	}
+	return nil
+}
+
+func validateTerm(term NodeSelectorTerm) error {
+	if len(term.MatchExpressions) == 0 {
+		return errors.New("match_expressions cannot be empty")
+	}
+	for _, expr := range term.MatchExpressions {
+		if expr.Key == "" {
+			return errors.New("expression key cannot be empty")
+		}
+		switch expr.Operator {
+		case LabelOpIn, LabelOpNotIn:
+			if len(expr.Values) == 0 {
+				return fmt.Errorf("operator %s on %q requires values", expr.Operator, expr.Key)
+			}
+		case LabelOpExists, LabelOpDoesNotExist:
+			if len(expr.Values) > 0 {
+				return fmt.Errorf("operator %s on %q does not take values", expr.Operator, expr.Key)
+			}
+		default:
+			return fmt.Errorf("unknown operator %q (expected In, NotIn, Exists or DoesNotExist)", expr.Operator)
+		}
+	}
+	return nil
+}
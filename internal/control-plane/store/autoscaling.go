@@ -0,0 +1,52 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Autoscaling lets the autoscaler move a model's replica count between
+// MinReplicas and MaxReplicas based on the load its replicas report.
+// At least one target must be set.
+type Autoscaling struct {
+	MinReplicas int `json:"min_replicas" yaml:"min_replicas"`
+	MaxReplicas int `json:"max_replicas" yaml:"max_replicas"`
+	// TargetQueueDepth is the desired number of queued plus in-flight jobs per replica.
+	TargetQueueDepth float64 `json:"target_queue_depth,omitempty" yaml:"target_queue_depth,omitempty"`
+	// TargetP95LatencyMs is the desired p95 job latency of the slowest replica.
+	TargetP95LatencyMs float64 `json:"target_p95_latency_ms,omitempty" yaml:"target_p95_latency_ms,omitempty"`
+}
+
+// Validate checks the replica bounds and targets. A nil Autoscaling is valid.
+func (a *Autoscaling) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if a.MinReplicas < 1 {
+		return fmt.Errorf("autoscaling: min_replicas must be at least 1, got %d", a.MinReplicas)
+	}
+	if a.MaxReplicas < a.MinReplicas {
+		return fmt.Errorf("autoscaling: max_replicas (%d) must not be below min_replicas (%d)", a.MaxReplicas, a.MinReplicas)
+	}
+	if a.TargetQueueDepth < 0 || a.TargetP95LatencyMs < 0 {
+		return errors.New("autoscaling: targets must not be negative")
+	}
+	if a.TargetQueueDepth == 0 && a.TargetP95LatencyMs == 0 {
+		return errors.New("autoscaling: target_queue_depth or target_p95_latency_ms is required")
+	}
+	return nil
+}
+
+// DesiredReplicas returns the number of replicas the scheduler should keep.
+// Without autoscaling it is Replicas. With autoscaling it is the count last set
+// by the autoscaler (Replicas until then), kept within the autoscaling bounds.
+func (m ModelInfo) DesiredReplicas() int {
+	if m.Autoscaling == nil {
+		return m.Replicas
+	}
+	n := m.ScaledReplicas
+	if n == 0 {
+		n = m.Replicas
+	}
+	return min(max(n, m.Autoscaling.MinReplicas), m.Autoscaling.MaxReplicas)
+}
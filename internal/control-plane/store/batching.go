@@ -0,0 +1,29 @@
+package store
+
+import "fmt"
+
+// MaxBatchWaitMs bounds how long an agent may hold a request back to fill a batch.
+const MaxBatchWaitMs = 1000
+
+// Batching lets agents stack concurrent requests for a model into a single
+// inference of up to MaxBatchSize rows, waiting at most MaxBatchWaitMs for a
+// batch to fill. The model needs a dynamic leading dimension on its inputs
+// and outputs; agents run requests one by one otherwise.
+type Batching struct {
+	MaxBatchSize   int `json:"max_batch_size" yaml:"max_batch_size"`
+	MaxBatchWaitMs int `json:"max_batch_wait_ms" yaml:"max_batch_wait_ms"`
+}
+
+// Validate checks the batch size and wait time. A nil Batching is valid.
+func (b *Batching) Validate() error {
+	if b == nil {
+		return nil
+	}
+	if b.MaxBatchSize < 1 {
+		return fmt.Errorf("batching: max_batch_size must be at least 1, got %d", b.MaxBatchSize)
+	}
+	if b.MaxBatchWaitMs < 0 || b.MaxBatchWaitMs > MaxBatchWaitMs {
+		return fmt.Errorf("batching: max_batch_wait_ms must be between 0 and %d, got %d", MaxBatchWaitMs, b.MaxBatchWaitMs)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+)
+
+// ValidateLoadBalancer checks that lb is a known load balancing strategy. An
+// empty strategy is valid and means round robin.
+func ValidateLoadBalancer(lb constants.LoadBalancer) error {
+	if lb == "" || slices.Contains(constants.LoadBalancers, lb) {
+		return nil
+	}
+	return fmt.Errorf("unknown load_balancer %q (expected one of %v)", lb, constants.LoadBalancers)
+}
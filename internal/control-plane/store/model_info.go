@@ -2,22 +2,38 @@ package store
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/preprocess"
 )
 
 type ModelInfo struct {
-	ID             string              `json:"id"`
-	Name           string              `json:"name"`
-	Namespace      string              `json:"namespace"`
-	Version        string              `json:"version"`
-	FilePath       string              `json:"file_path"`
-	ModelType      constants.ModelType `json:"model_type"`
-	ModelSize      int64               `json:"model_size"`
-	Replicas       int                 `json:"replicas"`
-	ActiveReplicas int                 `json:"active_replicas"`
-	ReplicaIDs     []string            `json:"replica_ids"`
-	InputFormat    json.RawMessage     `json:"input_format"`
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	Namespace      string                 `json:"namespace"`
+	Version        string                 `json:"version"`
+	FilePath       string                 `json:"file_path"`
+	SHA256Hash     string                 `json:"sha256_hash"`
+	ModelType      constants.ModelType    `json:"model_type"`
+	ModelSize      int64                  `json:"model_size"`
+	Replicas       int                    `json:"replicas"`
+	MinAvailable   int                    `json:"min_available"` // Running replicas a node drain must not go below
+	ActiveReplicas int                    `json:"active_replicas"`
+	ReplicaIDs     []string               `json:"replica_ids"`
+	InputFormat    json.RawMessage        `json:"input_format"`
+	NodeSelector   map[string]string      `json:"node_selector"` // Labels a node must carry to host replicas
+	Affinity       *Affinity              `json:"affinity,omitempty"`
+	Tolerations    []Toleration           `json:"tolerations"` // Taints the model's replicas may be placed on
+	Autoscaling    *Autoscaling           `json:"autoscaling,omitempty"`
+	ScaledReplicas int                    `json:"scaled_replicas"` // Replica count last set by the autoscaler
+	LastScaleTime  time.Time              `json:"last_scale_time"` // When the autoscaler last changed ScaledReplicas
+	Rollout        *RolloutStrategy       `json:"rollout,omitempty"`
+	Revision       int                    `json:"revision"`  // Revision of the current artifact
+	Revisions      []ModelRevision        `json:"revisions"` // Recent revisions, oldest first
+	Batching       *Batching              `json:"batching,omitempty"`
+	Preprocessing  *preprocess.Spec       `json:"preprocessing,omitempty"` // Applied by agents when scaling is enabled
+	LoadBalancer   constants.LoadBalancer `json:"load_balancer,omitempty"` // How agents pick the peer for forwarded requests
 }
 
 // Examples of input formats:
@@ -42,6 +42,19 @@ type NodeMetadata struct {
 	Hostname     string `json:"hostname"`
 }
 
+// PeerStats is a node's view of the inference it forwarded to one peer.
+type PeerStats struct {
+	NodeID              string    `json:"node_id"`
+	Address             string    `json:"address"`
+	Breaker             string    `json:"breaker"` // closed, open or half-open
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	EjectedUntil        time.Time `json:"ejected_until,omitempty"`
+	Ejections           int       `json:"ejections"`
+	Requests            int64     `json:"requests"`
+	Failures            int64     `json:"failures"`
+	Retries             int64     `json:"retries"`
+}
+
 type NodeInfo struct {
 	ID                   string               `json:"id"`
 	Name                 string               `json:"name"`
@@ -50,7 +63,12 @@ type NodeInfo struct {
 	Metadata             NodeMetadata         `json:"metadata"`
 	ResourceCapabilities ResourceCapabilities `json:"resource_capabilities"`
 	Status               constants.Status     `json:"status"`
+	Labels               map[string]string    `json:"labels"`
+	Taints               []Taint              `json:"taints"`
+	Unschedulable        bool                 `json:"unschedulable"`   // Cordoned: no new replicas are placed on the node
+	Draining             bool                 `json:"draining"`        // Replicas are being migrated off the node
 	AssignedModels       []string             `json:"assigned_models"` // This is the list of model Replica IDs NOT the model IDs
+	Peers                []PeerStats          `json:"peers,omitempty"` // Health of inference forwarded to other nodes, from the last heartbeat
 	RegisteredAt         time.Time            `json:"registered_at"`
 	UpdatedAt            time.Time            `json:"updated_at"`
 	LastHeartbeat        time.Time            `json:"last_heartbeat"`
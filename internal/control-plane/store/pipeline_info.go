@@ -0,0 +1,12 @@
+package store
+
+import "github.com/kennethnrk/edgernetes-ai/internal/common/pipeline"
+
+// PipelineInfo is a DAG of registered models that agents run as a single
+// inference call. Its steps refer to models of the same namespace.
+type PipelineInfo struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Namespace string        `json:"namespace"`
+	Spec      pipeline.Spec `json:"spec"`
+}
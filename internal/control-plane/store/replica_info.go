@@ -10,8 +10,28 @@ type ReplicaInfo struct {
 	ID            string                       `json:"id"`
 	ModelID       string                       `json:"model_id"`
 	Name          string                       `json:"name"`
+	NodeID        string                       `json:"node_id"` // Node the replica is placed on; empty if unbound
 	Status        constants.ModelReplicaStatus `json:"status"`
+	Revision      int                          `json:"revision"` // Model revision the replica was deployed with
 	ErrorCode     int                          `json:"error_code"`
 	ErrorMessage  string                       `json:"error_message"`
 	LastHeartbeat time.Time                    `json:"last_heartbeat"`
+	RestartCount  int                          `json:"restart_count"`   // Consecutive restarts by the revival clinic
+	LastRestartAt time.Time                    `json:"last_restart_at"` // Time of the most recent restart
+	QueueDepth    int                          `json:"queue_depth"`     // Jobs waiting in the replica's worker pool
+	InFlight      int                          `json:"in_flight"`       // Jobs being processed
+	P95LatencyMs  float64                      `json:"p95_latency_ms"`  // Recent p95 job latency
+	RecentJobs    int                          `json:"recent_jobs"`     // Jobs finished recently, that P95LatencyMs is taken over
+	QueueClasses  []QueueClassStats            `json:"queue_classes"`   // Queue of every priority class, highest first
+	EvictedFrom   string                       `json:"evicted_from"`    // Node the replica was evicted from, which may still run it
+}
+
+// QueueClassStats is the queue of one priority class of a replica, as last
+// reported by its agent.
+type QueueClassStats struct {
+	Class      constants.PriorityClass `json:"class"`
+	QueueDepth int                     `json:"queue_depth"`
+	Enqueued   int64                   `json:"enqueued"` // Since the replica started
+	Rejected   int64                   `json:"rejected"` // Turned away because the queue was full
+	P95WaitMs  float64                 `json:"p95_wait_ms"`
 }
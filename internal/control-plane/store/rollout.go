@@ -0,0 +1,111 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+)
+
+// Defaults used when a model has no RolloutStrategy.
+const (
+	DefaultMaxSurge       = 1
+	DefaultMaxUnavailable = 0
+)
+
+// MaxRevisionHistory is the number of revisions kept per model.
+const MaxRevisionHistory = 10
+
+// RolloutStrategy controls how the replicas of a model are replaced when its
+// artifact changes. MaxSurge replicas may be placed above the desired count and
+// MaxUnavailable replicas may be missing from it while the rollout progresses.
+type RolloutStrategy struct {
+	MaxSurge       int `json:"max_surge" yaml:"max_surge"`
+	MaxUnavailable int `json:"max_unavailable" yaml:"max_unavailable"`
+}
+
+// Validate checks that the limits are not negative and allow progress.
+// A nil RolloutStrategy is valid.
+func (r *RolloutStrategy) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.MaxSurge < 0 || r.MaxUnavailable < 0 {
+		return errors.New("rollout: max_surge and max_unavailable must not be negative")
+	}
+	if r.MaxSurge == 0 && r.MaxUnavailable == 0 {
+		return errors.New("rollout: max_surge and max_unavailable cannot both be 0")
+	}
+	return nil
+}
+
+// RolloutLimits returns the model's max surge and max unavailable replicas,
+// falling back to the defaults without a RolloutStrategy.
+func (m ModelInfo) RolloutLimits() (int, int) {
+	if m.Rollout == nil {
+		return DefaultMaxSurge, DefaultMaxUnavailable
+	}
+	return m.Rollout.MaxSurge, m.Rollout.MaxUnavailable
+}
+
+// ModelRevision records the artifact a model served at one revision.
+type ModelRevision struct {
+	Revision    int                 `json:"revision"`
+	Version     string              `json:"version"`
+	FilePath    string              `json:"file_path"`
+	SHA256Hash  string              `json:"sha256_hash"`
+	ModelType   constants.ModelType `json:"model_type"`
+	ModelSize   int64               `json:"model_size"`
+	CreatedAt   time.Time           `json:"created_at"`
+	ChangeCause string              `json:"change_cause,omitempty"` // e.g. "rollback to revision 2"
+}
+
+// CurrentRevision returns the model's current artifact as a ModelRevision.
+func (m ModelInfo) CurrentRevision() ModelRevision {
+	return ModelRevision{
+		Revision:   m.Revision,
+		Version:    m.Version,
+		FilePath:   m.FilePath,
+		SHA256Hash: m.SHA256Hash,
+		ModelType:  m.ModelType,
+		ModelSize:  m.ModelSize,
+	}
+}
+
+// SameArtifact reports whether two revisions deploy the same model file.
+func (r ModelRevision) SameArtifact(o ModelRevision) bool {
+	return r.Version == o.Version &&
+		r.FilePath == o.FilePath &&
+		r.SHA256Hash == o.SHA256Hash &&
+		r.ModelType == o.ModelType &&
+		r.ModelSize == o.ModelSize
+}
+
+// FindRevision returns the recorded revision with the given number.
+func (m ModelInfo) FindRevision(revision int) (ModelRevision, bool) {
+	for _, r := range m.Revisions {
+		if r.Revision == revision {
+			return r, true
+		}
+	}
+	return ModelRevision{}, false
+}
+
+// AtRevision returns a copy of the model with the artifact of the given
+// revision. If the revision is no longer recorded, the model is returned as is.
+func (m ModelInfo) AtRevision(revision int) ModelInfo {
+	if revision == m.Revision {
+		return m
+	}
+	r, ok := m.FindRevision(revision)
+	if !ok {
+		return m
+	}
+	m.Revision = r.Revision
+	m.Version = r.Version
+	m.FilePath = r.FilePath
+	m.SHA256Hash = r.SHA256Hash
+	m.ModelType = r.ModelType
+	m.ModelSize = r.ModelSize
+	return m
+}
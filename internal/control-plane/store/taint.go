@@ -0,0 +1,108 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Taint effects.
+const (
+	// TaintEffectNoSchedule keeps new replicas that do not tolerate the taint off the node.
+	TaintEffectNoSchedule = "NoSchedule"
+	// TaintEffectPreferNoSchedule makes the scheduler avoid the node if it can.
+	TaintEffectPreferNoSchedule = "PreferNoSchedule"
+	// TaintEffectNoExecute also evicts replicas already placed on the node.
+	TaintEffectNoExecute = "NoExecute"
+)
+
+// Toleration operators.
+const (
+	TolerationOpEqual  = "Equal"
+	TolerationOpExists = "Exists"
+)
+
+// Taint repels replicas of models that do not tolerate it from a node.
+type Taint struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value,omitempty" yaml:"value,omitempty"`
+	Effect string `json:"effect" yaml:"effect"` // NoSchedule, PreferNoSchedule, NoExecute
+}
+
+// Toleration allows a model's replicas onto nodes with a matching taint.
+// An empty Effect matches every effect; Exists with an empty Key matches every taint.
+type Toleration struct {
+	Key      string `json:"key,omitempty" yaml:"key,omitempty"`
+	Operator string `json:"operator,omitempty" yaml:"operator,omitempty"` // Equal (default) or Exists
+	Value    string `json:"value,omitempty" yaml:"value,omitempty"`
+	Effect   string `json:"effect,omitempty" yaml:"effect,omitempty"`
+}
+
+func (t Taint) String() string {
+	if t.Value == "" {
+		return t.Key + ":" + t.Effect
+	}
+	return t.Key + "=" + t.Value + ":" + t.Effect
+}
+
+// Validate checks that the taint has a key and a known effect.
+func (t Taint) Validate() error {
+	if t.Key == "" {
+		return errors.New("taint key cannot be empty")
+	}
+	return validateEffect(t.Effect)
+}
+
+// Tolerates reports whether the toleration matches taint.
+func (tol Toleration) Tolerates(taint Taint) bool {
+	if tol.Effect != "" && tol.Effect != taint.Effect {
+		return false
+	}
+	if tol.Operator == TolerationOpExists {
+		return tol.Key == "" || tol.Key == taint.Key
+	}
+	return tol.Key == taint.Key && tol.Value == taint.Value
+}
+
+// Validate checks the toleration's operator and effect.
+func (tol Toleration) Validate() error {
+	switch tol.Operator {
+	case "", TolerationOpEqual:
+		if tol.Key == "" {
+			return errors.New("toleration with operator Equal requires a key")
+		}
+	case TolerationOpExists:
+		if tol.Value != "" {
+			return fmt.Errorf("toleration for %q with operator Exists cannot have a value", tol.Key)
+		}
+	default:
+		return fmt.Errorf("unknown toleration operator %q (expected Equal or Exists)", tol.Operator)
+	}
+	if tol.Effect != "" {
+		return validateEffect(tol.Effect)
+	}
+	return nil
+}
+
+func validateEffect(effect string) error {
+	switch effect {
+	case TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+		return nil
+	default:
+		return fmt.Errorf("unknown taint effect %q (expected NoSchedule, PreferNoSchedule or NoExecute)", effect)
+	}
+}
+
+// ToleratesAll reports whether tolerations match every taint with one of the given effects.
+// It returns the first taint that is not tolerated.
+func ToleratesAll(tolerations []Toleration, taints []Taint, effects ...string) (Taint, bool) {
+	for _, taint := range taints {
+		if len(effects) > 0 && !slices.Contains(effects, taint.Effect) {
+			continue
+		}
+		if !slices.ContainsFunc(tolerations, func(tol Toleration) bool { return tol.Tolerates(taint) }) {
+			return taint, false
+		}
+	}
+	return Taint{}, true
+}
@@ -0,0 +1,315 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	grpcagent "github.com/kennethnrk/edgernetes-ai/internal/agent/api/grpc"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/fetcher"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTransferServer streams a fixed payload in small chunks. When failAfterFirst
+// is set, the first DownloadModel call breaks after one chunk.
+type fakeTransferServer struct {
+	deploypb.UnimplementedModelTransferServiceServer
+
+	payload        []byte
+	chunk          int
+	failAfterFirst bool
+
+	mu      sync.Mutex
+	offsets []int64
+}
+
+func (f *fakeTransferServer) DownloadModel(req *deploypb.ModelDownloadRequest, stream grpc.ServerStreamingServer[deploypb.ModelChunk]) error {
+	f.mu.Lock()
+	f.offsets = append(f.offsets, req.GetResumeByteOffset())
+	first := len(f.offsets) == 1
+	f.mu.Unlock()
+
+	for off := req.GetResumeByteOffset(); off < int64(len(f.payload)); off += int64(f.chunk) {
+		end := min(off+int64(f.chunk), int64(len(f.payload)))
+		if err := stream.Send(&deploypb.ModelChunk{ChunkData: f.payload[off:end], ChunkOffset: off}); err != nil {
+			return err
+		}
+		if first && f.failAfterFirst {
+			return status.Error(codes.Unavailable, "connection reset")
+		}
+	}
+	return nil
+}
+
+func (f *fakeTransferServer) resumeOffsets() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]int64(nil), f.offsets...)
+}
+
+// startFakeTransferServer serves srv on a loopback port and returns its address.
+func startFakeTransferServer(t *testing.T, srv *fakeTransferServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	s := grpc.NewServer()
+	deploypb.RegisterModelTransferServiceServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String()
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func newTestFetcher(addr, dir string) *fetcher.Fetcher {
+	f := fetcher.New(addr, dir)
+	f.RetryBackoff = 10 * time.Millisecond
+	return f
+}
+
+func TestFetcher_ResumesInterruptedStream(t *testing.T) {
+	payload := bytes.Repeat([]byte("onnx-model-bytes"), 64)
+	srv := &fakeTransferServer{payload: payload, chunk: 100, failAfterFirst: true}
+	addr := startFakeTransferServer(t, srv)
+	dir := t.TempDir()
+
+	req := &deploypb.DeployModelRequest{ModelId: "model-1", FilePath: "/models/model.onnx", Sha256Hash: sha256Hex(payload)}
+	path, err := newTestFetcher(addr, dir).Fetch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("fetched file differs from payload (%d vs %d bytes)", len(got), len(payload))
+	}
+
+	offsets := srv.resumeOffsets()
+	if len(offsets) != 2 || offsets[0] != 0 || offsets[1] != 100 {
+		t.Errorf("resume offsets = %v, want [0 100]", offsets)
+	}
+	if _, err := os.Stat(path + ".downloading"); !os.IsNotExist(err) {
+		t.Errorf("expected temp file to be renamed away, stat err = %v", err)
+	}
+}
+
+func TestFetcher_ReusesCachedFile(t *testing.T) {
+	payload := []byte("cached-model")
+	srv := &fakeTransferServer{payload: payload, chunk: 4}
+	addr := startFakeTransferServer(t, srv)
+	f := newTestFetcher(addr, t.TempDir())
+
+	req := &deploypb.DeployModelRequest{ModelId: "model-1", Sha256Hash: sha256Hex(payload)}
+	for i := 0; i < 2; i++ {
+		if _, err := f.Fetch(context.Background(), req); err != nil {
+			t.Fatalf("Fetch() #%d error = %v", i+1, err)
+		}
+	}
+
+	if n := len(srv.resumeOffsets()); n != 1 {
+		t.Errorf("expected 1 download, got %d", n)
+	}
+}
+
+func TestFetcher_RefetchesWithoutHash(t *testing.T) {
+	srv := &fakeTransferServer{payload: []byte("model-v1"), chunk: 4}
+	addr := startFakeTransferServer(t, srv)
+	f := newTestFetcher(addr, t.TempDir())
+
+	// A new revision that only changes the file path must not share a cache file.
+	v1 := &deploypb.DeployModelRequest{ModelId: "model-1", Version: "v1", FilePath: "/models/a.onnx"}
+	v2 := &deploypb.DeployModelRequest{ModelId: "model-1", Version: "v1", FilePath: "/models/b.onnx"}
+	if fetcher.CacheFileName(v1) == fetcher.CacheFileName(v2) {
+		t.Fatalf("CacheFileName() = %s for both file paths", fetcher.CacheFileName(v1))
+	}
+
+	path, err := f.Fetch(context.Background(), v1)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	srv.payload = []byte("model-v1-replaced")
+	if _, err := f.Fetch(context.Background(), v1); err != nil {
+		t.Fatalf("Fetch() again error = %v", err)
+	}
+
+	if n := len(srv.resumeOffsets()); n != 2 {
+		t.Errorf("expected 2 downloads without a hash, got %d", n)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "model-v1-replaced" {
+		t.Errorf("cached file = %q, want the refetched payload", got)
+	}
+}
+
+func TestFetcher_ChecksumMismatch(t *testing.T) {
+	srv := &fakeTransferServer{payload: []byte("tampered"), chunk: 4}
+	addr := startFakeTransferServer(t, srv)
+	dir := t.TempDir()
+
+	req := &deploypb.DeployModelRequest{ModelId: "model-1", Sha256Hash: sha256Hex([]byte("original"))}
+	_, err := newTestFetcher(addr, dir).Fetch(context.Background(), req)
+	if !errors.Is(err, fetcher.ErrChecksumMismatch) {
+		t.Fatalf("Fetch() error = %v, want ErrChecksumMismatch", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("expected no files left in cache dir, got %d", len(entries))
+	}
+}
+
+func TestFetcher_HTTPRangeResume(t *testing.T) {
+	payload := bytes.Repeat([]byte("0123456789"), 50)
+
+	var mu sync.Mutex
+	var ranges []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		mu.Unlock()
+		http.ServeContent(w, r, "model.onnx", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	req := &deploypb.DeployModelRequest{ModelId: "model-1", FilePath: ts.URL + "/model.onnx", Sha256Hash: sha256Hex(payload)}
+
+	// Simulate a previous attempt that stopped after 120 bytes.
+	partial := filepath.Join(dir, fetcher.CacheFileName(req)+".downloading")
+	if err := os.WriteFile(partial, payload[:120], 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	path, err := newTestFetcher("", dir).Fetch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("fetched file differs from payload")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ranges) != 1 || ranges[0] != "bytes=120-" {
+		t.Errorf("Range headers = %v, want [bytes=120-]", ranges)
+	}
+}
+
+func TestFetcher_UnsupportedScheme(t *testing.T) {
+	req := &deploypb.DeployModelRequest{ModelId: "model-1", FilePath: "s3://bucket/model.onnx"}
+	_, err := newTestFetcher("", t.TempDir()).Fetch(context.Background(), req)
+	if !errors.Is(err, fetcher.ErrUnsupportedScheme) {
+		t.Fatalf("Fetch() error = %v, want ErrUnsupportedScheme", err)
+	}
+}
+
+func TestDeployModel_ChecksumMismatchFailsReplica(t *testing.T) {
+	srv := &fakeTransferServer{payload: []byte("tampered"), chunk: 4}
+	addr := startFakeTransferServer(t, srv)
+
+	a := &agent.Agent{ID: "agent-1", ControlPlaneAddr: addr, ModelDir: t.TempDir()}
+	deploySrv := grpcagent.NewDeployServer(a)
+
+	resp, err := deploySrv.DeployModel(context.Background(), &deploypb.DeployModelRequest{
+		ModelId:    "model-1",
+		ReplicaId:  "replica-1",
+		FilePath:   "/models/model.onnx",
+		Sha256Hash: sha256Hex([]byte("original")),
+	})
+	if err != nil || !resp.GetSuccess() {
+		t.Fatalf("DeployModel() = %v, %v", resp, err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		replica, ok := a.GetModelReplica("replica-1")
+		if !ok {
+			t.Fatal("replica-1 not assigned to agent")
+		}
+		if replica.Status == constants.ModelReplicaStatusFailed {
+			if replica.ErrorCode != constants.ReplicaErrorChecksumMismatch || replica.ErrorMessage == "" {
+				t.Errorf("replica error = %d %q, want checksum mismatch", replica.ErrorCode, replica.ErrorMessage)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("replica status = %s, want failed", replica.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestUndeployModel_RemovesReplicaAndCachedFile(t *testing.T) {
+	dir := t.TempDir()
+	cached := filepath.Join(dir, "model-1.onnx")
+	if err := os.WriteFile(cached, []byte("model"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	// replica-3 runs the next revision of the model, as during a rollout.
+	next := filepath.Join(dir, "model-1-v2.onnx")
+	if err := os.WriteFile(next, []byte("model v2"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	a := &agent.Agent{ID: "agent-1", ModelDir: dir}
+	for id, path := range map[string]string{"replica-1": cached, "replica-2": cached, "replica-3": next} {
+		if err := a.AssignModel(agent.ModelReplicaDetails{
+			ID: id, ModelID: "model-1", LocalPath: path, Status: constants.ModelReplicaStatusFailed,
+		}); err != nil {
+			t.Fatalf("AssignModel() error = %v", err)
+		}
+	}
+	deploySrv := grpcagent.NewDeployServer(a)
+
+	// The cached file stays while another replica loads it.
+	if resp, err := deploySrv.UndeployModel(context.Background(), &deploypb.UndeployModelRequest{ReplicaId: "replica-1"}); err != nil || !resp.GetSuccess() {
+		t.Fatalf("UndeployModel() = %v, %v", resp, err)
+	}
+	if _, ok := a.GetModelReplica("replica-1"); ok {
+		t.Error("replica-1 still assigned after undeploy")
+	}
+	if _, err := os.Stat(cached); err != nil {
+		t.Errorf("cached file removed while replica-2 uses it: %v", err)
+	}
+
+	if _, err := deploySrv.UndeployModel(context.Background(), &deploypb.UndeployModelRequest{ReplicaId: "replica-2"}); err != nil {
+		t.Fatalf("UndeployModel() error = %v", err)
+	}
+	if _, err := os.Stat(cached); !os.IsNotExist(err) {
+		t.Errorf("cached file still present after the last replica using it was undeployed, stat err = %v", err)
+	}
+	if _, err := os.Stat(next); err != nil {
+		t.Errorf("next revision's file removed while replica-3 uses it: %v", err)
+	}
+
+	_, err := deploySrv.UndeployModel(context.Background(), &deploypb.UndeployModelRequest{ReplicaId: "replica-1"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("UndeployModel() of unknown replica error = %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,333 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	grpcagent "github.com/kennethnrk/edgernetes-ai/internal/agent/api/grpc"
+	grpcinfer "github.com/kennethnrk/edgernetes-ai/internal/agent/api/grpc/infer"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+)
+
+// forwardingAgent returns an agent without local replicas that knows the
+// given peers as endpoints of model-1.
+func forwardingAgent(policy agent.ForwardPolicy, ports ...int) *agent.Agent {
+	a := &agent.Agent{ID: "entry", ForwardPolicy: policy}
+	endpoints := make([]*heartbeatpb.EndpointDetail, len(ports))
+	for i, port := range ports {
+		endpoints[i] = &heartbeatpb.EndpointDetail{
+			NodeId: "peer-" + string(rune('a'+i)), Ip: "127.0.0.1", Port: int32(port), Healthy: true, Weight: 1,
+		}
+	}
+	a.UpdateEndpoints([]*heartbeatpb.ServiceEndpoints{{ModelId: "model-1", Endpoints: endpoints}})
+	return a
+}
+
+func peerStatsOf(t *testing.T, a *agent.Agent, nodeID string) agent.PeerStats {
+	t.Helper()
+	for _, p := range a.PeerStats() {
+		if p.NodeID == nodeID {
+			return p
+		}
+	}
+	t.Fatalf("no peer stats for %s in %+v", nodeID, a.PeerStats())
+	return agent.PeerStats{}
+}
+
+func TestHandleInfer_RetriesAndEjectsFailingPeer(t *testing.T) {
+	bad, badPort := startFakeInferAgent(t)
+	bad.failWith(status.Error(codes.Unavailable, "peer is shutting down"))
+	good, goodPort := startFakeInferAgent(t)
+
+	a := forwardingAgent(agent.ForwardPolicy{
+		MaxRetries:     1,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+		EjectAfter:     2,
+		EjectionTime:   time.Minute,
+	}, badPort, goodPort)
+
+	for i := 0; i < 10; i++ {
+		if _, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})}); err != nil {
+			t.Fatalf("HandleInfer() #%d error = %v", i, err)
+		}
+	}
+	if got := len(bad.seen()); got != 2 {
+		t.Errorf("failing peer received %d requests, want 2 before it was ejected", got)
+	}
+	if got := len(good.seen()); got != 10 {
+		t.Errorf("healthy peer served %d requests, want 10", got)
+	}
+
+	st := peerStatsOf(t, a, "peer-a")
+	if st.Ejections != 1 || st.EjectedUntil.IsZero() || st.Failures != 2 {
+		t.Errorf("failing peer stats = %+v, want 1 ejection and 2 failures", st)
+	}
+	if st := peerStatsOf(t, a, "peer-b"); st.Retries != 2 || st.Failures != 0 {
+		t.Errorf("healthy peer stats = %+v, want 2 retries and no failures", st)
+	}
+}
+
+func TestHandleInfer_DoesNotRetryRejectedInputs(t *testing.T) {
+	peer, port := startFakeInferAgent(t)
+	peer.failWith(status.Error(codes.InvalidArgument, "input \"x\" has shape [1], model expects [1 3]"))
+
+	a := forwardingAgent(agent.DefaultForwardPolicy(), port)
+	_, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+	if !errors.Is(err, runway.ErrInvalidInput) {
+		t.Fatalf("HandleInfer() error = %v, want ErrInvalidInput", err)
+	}
+	if st := peerStatsOf(t, a, "peer-a"); st.Requests != 1 || st.Failures != 0 {
+		t.Errorf("peer stats = %+v, want one request and no failure", st)
+	}
+}
+
+func TestHandleInfer_CircuitBreakerOpensAndIsReported(t *testing.T) {
+	peer, port := startFakeInferAgent(t)
+	peer.failWith(status.Error(codes.Internal, "session crashed"))
+
+	a := forwardingAgent(agent.ForwardPolicy{
+		BreakerWindow:       3,
+		BreakerFailureRatio: 0.5,
+		BreakerOpenTime:     time.Minute,
+	}, port)
+
+	for i := 0; i < 4; i++ {
+		if _, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})}); err == nil {
+			t.Fatalf("HandleInfer() #%d to a failing peer succeeded", i)
+		}
+	}
+	if got := len(peer.seen()); got != 3 {
+		t.Errorf("peer received %d requests, want 3 before its breaker opened", got)
+	}
+	_, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+	if !errors.Is(err, agent.ErrModelUnavailable) {
+		t.Errorf("HandleInfer() with an open breaker error = %v, want ErrModelUnavailable", err)
+	}
+
+	resp, err := grpcagent.NewHeartbeatServer(a).RequestHeartbeat(context.Background(), &heartbeatpb.RequestHeartbeatRequest{})
+	if err != nil {
+		t.Fatalf("RequestHeartbeat() error = %v", err)
+	}
+	if len(resp.Peers) != 1 || resp.Peers[0].Breaker != string(agent.BreakerOpen) || resp.Peers[0].Failures != 3 {
+		t.Errorf("heartbeat peers = %v, want one peer with an open breaker and 3 failures", resp.Peers)
+	}
+}
+
+func TestHandleInfer_ConsistentHashKeepsKeyOnOnePeer(t *testing.T) {
+	peerA, portA := startFakeInferAgent(t)
+	peerB, portB := startFakeInferAgent(t)
+
+	a := &agent.Agent{ID: "entry", ForwardPolicy: agent.DefaultForwardPolicy()}
+	a.UpdateEndpoints([]*heartbeatpb.ServiceEndpoints{{
+		ModelId:      "model-1",
+		LoadBalancer: string(constants.LoadBalancerConsistentHash),
+		Endpoints: []*heartbeatpb.EndpointDetail{
+			{NodeId: "peer-a", Ip: "127.0.0.1", Port: int32(portA), Healthy: true, Weight: 1},
+			{NodeId: "peer-b", Ip: "127.0.0.1", Port: int32(portB), Healthy: true, Weight: 1},
+		},
+	}})
+
+	for i := 0; i < 10; i++ {
+		req := agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1}), RoutingKey: "session-42"}
+		if _, err := a.HandleInfer(context.Background(), req); err != nil {
+			t.Fatalf("HandleInfer() #%d error = %v", i, err)
+		}
+	}
+	a1, b1 := len(peerA.seen()), len(peerB.seen())
+	if a1+b1 != 10 || (a1 != 0 && b1 != 0) {
+		t.Fatalf("requests with one routing key were split %d/%d, want all on one peer", a1, b1)
+	}
+
+	// The key moves to the other peer when its owner fails, and only then.
+	owner, other := peerA, peerB
+	if b1 != 0 {
+		owner, other = peerB, peerA
+	}
+	owner.failWith(status.Error(codes.Unavailable, "peer is shutting down"))
+	if _, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1}), RoutingKey: "session-42"}); err != nil {
+		t.Fatalf("HandleInfer() after the owner failed error = %v", err)
+	}
+	if len(other.seen()) != 1 {
+		t.Errorf("other peer served %d requests after the owner failed, want 1", len(other.seen()))
+	}
+}
+
+func TestHandleInfer_PassesRemainingDeadlineToPeer(t *testing.T) {
+	peer, port := startFakeInferAgent(t)
+	a := forwardingAgent(agent.DefaultForwardPolicy(), port)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := a.HandleInfer(ctx, agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})}); err != nil {
+		t.Fatalf("HandleInfer() error = %v", err)
+	}
+	deadlines := peer.deadlinesSeen()
+	if len(deadlines) != 1 || deadlines[0] <= 0 || deadlines[0] > 2*time.Second {
+		t.Errorf("peer saw deadlines %v, want one within the caller's 2s", deadlines)
+	}
+}
+
+func TestHandleInfer_GivesUpWhenCallerDoes(t *testing.T) {
+	peer, port := startFakeInferAgent(t)
+	a := forwardingAgent(agent.DefaultForwardPolicy(), port)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := a.HandleInfer(ctx, agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("HandleInfer() with a canceled context error = %v, want context.Canceled", err)
+	}
+	if got := len(peer.seen()); got != 0 {
+		t.Errorf("peer received %d requests of a canceled caller, want 0", got)
+	}
+
+	// A peer that is slower than the caller's deadline is not retried or held
+	// against it.
+	peer.slowDown(time.Second)
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = a.HandleInfer(ctx, agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("HandleInfer() past the deadline error = %v, want context.DeadlineExceeded", err)
+	}
+	if st := peerStatsOf(t, a, "peer-a"); st.Requests != 1 || st.Failures != 0 {
+		t.Errorf("peer stats = %+v, want one request and no failure", st)
+	}
+}
+
+func TestHandleInfer_BacksOffWhenEveryPeerIsSaturated(t *testing.T) {
+	peerA, portA := startFakeInferAgent(t)
+	peerA.failWith(agent.OverloadedStatus(&agent.OverloadedError{ModelID: "model-1", RetryAfter: 2 * time.Second}).Err())
+	peerB, portB := startFakeInferAgent(t)
+	peerB.failWith(agent.OverloadedStatus(&agent.OverloadedError{ModelID: "model-1", RetryAfter: 300 * time.Millisecond}).Err())
+
+	a := forwardingAgent(agent.DefaultForwardPolicy(), portA, portB)
+	resp, err := grpcinfer.NewInferServer(a).Infer(context.Background(), &inferpb.InferRequest{ModelId: "model-1", InputData: []float32{1}})
+	if resp != nil || status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Infer() with saturated peers = %v, %v, want RESOURCE_EXHAUSTED", resp, err)
+	}
+	if d, ok := agent.RetryAfterFromStatus(status.Convert(err)); !ok || d != 300*time.Millisecond {
+		t.Errorf("retry after = %v, %v, want the soonest hint of 300ms", d, ok)
+	}
+	for _, id := range []string{"peer-a", "peer-b"} {
+		if st := peerStatsOf(t, a, id); st.Requests != 1 || st.Failures != 0 {
+			t.Errorf("%s stats = %+v, want one request and no failure", id, st)
+		}
+	}
+
+	// Once a peer has room again, the request is served there.
+	peerB.failWith(nil)
+	if _, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})}); err != nil {
+		t.Fatalf("HandleInfer() with a free peer error = %v", err)
+	}
+}
+
+func TestHandleInfer_ForwardsWhenLocalReplicaIsStopped(t *testing.T) {
+	peer, port := startFakeInferAgent(t)
+	peer.serveAs("peer-a")
+	a := forwardingAgent(agent.DefaultForwardPolicy(), port)
+	// The replica is still listed as running, but its workers are already
+	// gone, as while it is being undeployed.
+	if err := a.AssignModel(agent.ModelReplicaDetails{ID: "replica-1", ModelID: "model-1", Status: constants.ModelReplicaStatusRunning}); err != nil {
+		t.Fatalf("AssignModel() error = %v", err)
+	}
+
+	result, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+	if err != nil {
+		t.Fatalf("HandleInfer() error = %v", err)
+	}
+	if result.ServedBy != "peer-a" {
+		t.Errorf("served by %q, want peer-a", result.ServedBy)
+	}
+
+	// Without a peer to take it, the request fails as unavailable.
+	alone := &agent.Agent{ID: "alone"}
+	if err := alone.AssignModel(agent.ModelReplicaDetails{ID: "replica-1", ModelID: "model-1", Status: constants.ModelReplicaStatusRunning}); err != nil {
+		t.Fatalf("AssignModel() error = %v", err)
+	}
+	_, err = alone.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+	if !errors.Is(err, agent.ErrModelUnavailable) {
+		t.Fatalf("HandleInfer() without peers error = %v, want ErrModelUnavailable", err)
+	}
+}
+
+func TestOverloadedStatus_RoundTripsRetryHint(t *testing.T) {
+	err := fmt.Errorf("local inference failed: %w", &runway.QueueFullError{ReplicaID: "rep-1", RetryAfter: 750 * time.Millisecond, Err: runway.ErrQueueFull})
+	st := agent.OverloadedStatus(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("OverloadedStatus() code = %v, want ResourceExhausted", st.Code())
+	}
+	if d, ok := agent.RetryAfterFromStatus(st); !ok || d != 750*time.Millisecond {
+		t.Errorf("RetryAfterFromStatus() = %v, %v, want 750ms", d, ok)
+	}
+	if _, ok := agent.RetryAfterFromStatus(status.New(codes.Unavailable, "down")); ok {
+		t.Error("RetryAfterFromStatus() found a hint in a status without RetryInfo")
+	}
+	if !errors.Is(&agent.OverloadedError{ModelID: "model-1"}, runway.ErrQueueFull) {
+		t.Error("OverloadedError does not wrap runway.ErrQueueFull")
+	}
+}
+
+// startAgentInferServer serves the InferAPI of a on a loopback port and
+// returns its port.
+func startAgentInferServer(t *testing.T, a *agent.Agent) int {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	s := grpc.NewServer()
+	inferpb.RegisterInferAPIServer(s, grpcinfer.NewInferServer(a))
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+func TestHandleInfer_StaleCacheReroutesWithinHopBudget(t *testing.T) {
+	// The entry agent believes peer-a runs model-1, but it has moved to node-c.
+	nodeC, portC := startFakeInferAgent(t)
+	nodeC.serveAs("node-c")
+	stale := &agent.Agent{ID: "peer-a", ForwardPolicy: agent.DefaultForwardPolicy()}
+	stale.UpdateEndpoints([]*heartbeatpb.ServiceEndpoints{{ModelId: "model-1", Endpoints: []*heartbeatpb.EndpointDetail{
+		{NodeId: "entry", Ip: "127.0.0.1", Port: int32(closedPort(t)), Healthy: true, Weight: 1},
+		{NodeId: "node-c", Ip: "127.0.0.1", Port: int32(portC), Healthy: true, Weight: 1},
+	}}})
+	a := forwardingAgent(agent.DefaultForwardPolicy(), startAgentInferServer(t, stale))
+
+	res, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+	if err != nil {
+		t.Fatalf("HandleInfer() through a stale peer error = %v", err)
+	}
+	if res.ServedBy != "node-c" || res.HopCount != 2 {
+		t.Errorf("served by %q after %d hops, want node-c after 2", res.ServedBy, res.HopCount)
+	}
+	got := nodeC.received()
+	if len(got) != 1 || got[0].HopCount != 2 || got[0].MaxHops != 2 || !slices.Equal(got[0].Visited, []string{"entry", "peer-a"}) {
+		t.Fatalf("node-c received %v, want one request at hop 2 of 2 that visited entry and peer-a", got)
+	}
+	if stats := stale.PeerStats(); len(stats) != 1 || stats[0].NodeID != "node-c" {
+		t.Errorf("stale peer forwarded to %+v, want only node-c and never back to entry", stats)
+	}
+
+	// A request with a budget of one hop fails at the stale peer.
+	_, err = a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1}), MaxHops: 1})
+	if !errors.Is(err, agent.ErrModelUnavailable) {
+		t.Fatalf("HandleInfer() with one hop error = %v, want ErrModelUnavailable", err)
+	}
+	if got := len(nodeC.received()); got != 1 {
+		t.Errorf("node-c received %d requests, want none past the hop budget", got)
+	}
+}
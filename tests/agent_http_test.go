@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	httpagent "github.com/kennethnrk/edgernetes-ai/internal/agent/api/http"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+)
+
+// postJSON posts body to url and decodes the JSON answer into out.
+func postJSON(t *testing.T, url, body string, out any) *http.Response {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s error = %v", url, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("decoding answer of POST %s: %v", url, err)
+	}
+	return resp
+}
+
+func TestHTTPInfer_ForwardsV2RequestToPeer(t *testing.T) {
+	peer, port := startFakeInferAgent(t)
+	peer.serveAs("peer-a")
+	srv := httptest.NewServer(httpagent.NewHandler(forwardingAgent(agent.DefaultForwardPolicy(), port)))
+	defer srv.Close()
+
+	for _, path := range []string{"/v2/health/live", "/v2/health/ready", "/v2/models/model-1/ready", "/v2/models/model-1"} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET %s = %d, want 200", path, resp.StatusCode)
+		}
+	}
+	resp, err := http.Get(srv.URL + "/v2/models/model-2")
+	if err != nil {
+		t.Fatalf("GET metadata error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET metadata of an unknown model = %d, want 404", resp.StatusCode)
+	}
+
+	var out struct {
+		ModelName  string         `json:"model_name"`
+		ID         string         `json:"id"`
+		Parameters map[string]any `json:"parameters"`
+	}
+	resp = postJSON(t, srv.URL+"/v2/models/model-1/infer", `{
+		"id": "req-1",
+		"inputs": [{"name": "x", "shape": [1, 3], "datatype": "FP32", "data": [[1, 2, 3]]}],
+		"parameters": {"priority": "high", "routing_key": "session-1"}
+	}`, &out)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("infer = %d, want 200", resp.StatusCode)
+	}
+	if out.ModelName != "model-1" || out.ID != "req-1" || out.Parameters["served_by"] != "peer-a" || out.Parameters["hop_count"] != float64(1) {
+		t.Errorf("infer answer = %+v, want model-1 req-1 served by peer-a after one hop", out)
+	}
+
+	got := peer.received()
+	if len(got) != 1 {
+		t.Fatalf("peer received %d requests, want 1", len(got))
+	}
+	in := got[0].Inputs
+	if len(in) != 1 || in[0].Name != "x" || in[0].Dtype != inferpb.DataType_FLOAT32 ||
+		!slices.Equal(in[0].Shape, []int64{1, 3}) || !slices.Equal(in[0].FloatData, []float32{1, 2, 3}) {
+		t.Errorf("peer received inputs %v, want x FP32 [1 3] = 1 2 3", in)
+	}
+	if got[0].Priority != "high" || got[0].RoutingKey != "session-1" || got[0].Tenant != "127.0.0.1" {
+		t.Errorf("peer received priority %q routing key %q tenant %q, want high, session-1 and the caller's host", got[0].Priority, got[0].RoutingKey, got[0].Tenant)
+	}
+}
+
+func TestHTTPInfer_MapsErrorsToStatusCodes(t *testing.T) {
+	peer, port := startFakeInferAgent(t)
+	srv := httptest.NewServer(httpagent.NewHandler(forwardingAgent(agent.DefaultForwardPolicy(), port)))
+	defer srv.Close()
+
+	input := `{"inputs": [{"name": "x", "shape": [1, 2], "datatype": "FP32", "data": [1, 2]}]}`
+	var e struct {
+		Error string `json:"error"`
+	}
+	for _, tc := range []struct {
+		name, path, body string
+		want             int
+	}{
+		{"shape mismatch", "/v2/models/model-1/infer", `{"inputs": [{"name": "x", "shape": [1, 3], "datatype": "FP32", "data": [1, 2]}]}`, http.StatusBadRequest},
+		{"unsupported datatype", "/v2/models/model-1/infer", `{"inputs": [{"name": "x", "shape": [1], "datatype": "FP16", "data": [1]}]}`, http.StatusBadRequest},
+		{"out of range", "/v2/models/model-1/infer", `{"inputs": [{"name": "x", "shape": [1], "datatype": "UINT8", "data": [300]}]}`, http.StatusBadRequest},
+		{"unknown priority", "/v2/models/model-1/infer", `{"inputs": [{"name": "x", "shape": [1], "datatype": "FP32", "data": [1]}], "parameters": {"priority": "urgent"}}`, http.StatusBadRequest},
+		{"unknown model", "/v2/models/model-2/infer", input, http.StatusServiceUnavailable},
+	} {
+		if resp := postJSON(t, srv.URL+tc.path, tc.body, &e); resp.StatusCode != tc.want || e.Error == "" {
+			t.Errorf("%s: infer = %d %q, want %d with an error", tc.name, resp.StatusCode, e.Error, tc.want)
+		}
+	}
+	if got := len(peer.received()); got != 0 {
+		t.Errorf("peer received %d invalid requests, want 0", got)
+	}
+
+	peer.failWith(agent.OverloadedStatus(&agent.OverloadedError{ModelID: "model-1", RetryAfter: 1500 * time.Millisecond}).Err())
+	resp := postJSON(t, srv.URL+"/v2/models/model-1/infer", input, &e)
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "2" {
+		t.Errorf("infer on a saturated peer = %d Retry-After %q, want 429 after 2s", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+}
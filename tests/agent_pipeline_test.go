@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/pipeline"
+)
+
+func TestHandleInfer_RunsPipelineStepsOnPeers(t *testing.T) {
+	detector, detectorPort := startFakeInferAgent(t)
+	detector.serveAs("peer-a")
+	detector.answerWith(&inferpb.Tensor{Name: "boxes", Dtype: inferpb.DataType_FLOAT32, Shape: []int64{1, 2}, FloatData: []float32{0.25, 0.75}})
+	classifier, classifierPort := startFakeInferAgent(t)
+	classifier.serveAs("peer-b")
+	classifier.answerWith(&inferpb.Tensor{Name: "label", Dtype: inferpb.DataType_INT64, Shape: []int64{1}, Int64Data: []int64{7}})
+
+	a := &agent.Agent{ID: "entry"}
+	a.UpdateEndpoints([]*heartbeatpb.ServiceEndpoints{
+		{ModelId: "model-det", Endpoints: []*heartbeatpb.EndpointDetail{
+			{NodeId: "peer-a", Ip: "127.0.0.1", Port: int32(detectorPort), Healthy: true, Weight: 1},
+		}},
+		{ModelId: "model-cls", Endpoints: []*heartbeatpb.EndpointDetail{
+			{NodeId: "peer-b", Ip: "127.0.0.1", Port: int32(classifierPort), Healthy: true, Weight: 1},
+		}},
+	})
+	spec, err := json.Marshal(pipeline.Spec{Steps: []pipeline.Step{
+		{Name: "detect", ModelID: "model-det", Inputs: []pipeline.Binding{{Input: "images", From: "input.image"}}},
+		{Name: "classify", ModelID: "model-cls", Inputs: []pipeline.Binding{{Input: "features", From: "detect.boxes"}}},
+	}})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	a.UpdatePipelines([]*heartbeatpb.PipelineDefinition{{Id: "pipe-1", Name: "detect-classify", Namespace: "default", Spec: string(spec)}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	image := runway.Tensor{Name: "image", DType: runway.DataTypeFloat32, Shape: []int64{1, 1}, Data: []float32{1}}
+	result, err := a.HandleInfer(ctx, agent.InferRequest{PipelineID: "pipe-1", Inputs: []runway.Tensor{image}})
+	if err != nil {
+		t.Fatalf("HandleInfer() error = %v", err)
+	}
+
+	if len(result.Outputs) != 1 || result.Outputs[0].Name != "classify.label" || !reflect.DeepEqual(result.Outputs[0].Data, []int64{7}) {
+		t.Fatalf("outputs = %+v, want the label of the classify step", result.Outputs)
+	}
+	if result.ServedBy != "entry" || len(result.Steps) != 2 {
+		t.Fatalf("result served by %q with steps %+v, want the entry node and 2 steps", result.ServedBy, result.Steps)
+	}
+	for i, want := range []string{"peer-a", "peer-b"} {
+		if s := result.Steps[i]; s.ServedBy != want || s.HopCount != 1 {
+			t.Fatalf("step %s served by %q after %d hop(s), want %s after 1", s.Name, s.ServedBy, s.HopCount, want)
+		}
+	}
+
+	detected := detector.received()[0].GetInputs()
+	if len(detected) != 1 || detected[0].GetName() != "images" {
+		t.Fatalf("detector got inputs %v, want the request's image bound as images", detected)
+	}
+	classified := classifier.received()[0].GetInputs()
+	if len(classified) != 1 || classified[0].GetName() != "features" || !reflect.DeepEqual(classified[0].GetFloatData(), []float32{0.25, 0.75}) {
+		t.Fatalf("classifier got inputs %v, want the detector's boxes bound as features", classified)
+	}
+
+	if _, err := a.HandleInfer(ctx, agent.InferRequest{PipelineID: "missing", Inputs: []runway.Tensor{image}}); !errors.Is(err, agent.ErrPipelineNotFound) {
+		t.Fatalf("HandleInfer() of an unknown pipeline error = %v, want ErrPipelineNotFound", err)
+	}
+	if _, err := a.HandleInfer(ctx, agent.InferRequest{PipelineID: "pipe-1"}); !errors.Is(err, runway.ErrInvalidInput) {
+		t.Fatalf("HandleInfer() without the pipeline's input error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+)
+
+func pushJobs(t *testing.T, q *runway.FairQueue, priority constants.PriorityClass, tenant string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := q.Push(&runway.InferenceJob{Priority: priority, Tenant: tenant}); err != nil {
+			t.Fatalf("Push(%s, %s) #%d error = %v", priority, tenant, i, err)
+		}
+	}
+}
+
+// popOrder drains q and returns the tenant of every job in the order taken.
+func popOrder(q *runway.FairQueue) string {
+	var order []string
+	for {
+		job, ok := q.TryPop()
+		if !ok {
+			return strings.Join(order, ",")
+		}
+		order = append(order, job.Tenant)
+	}
+}
+
+func TestFairQueue_StrictPriorityBetweenClasses(t *testing.T) {
+	q := runway.NewFairQueue(runway.QueueConfig{Capacity: 10})
+	pushJobs(t, q, constants.PriorityLow, "bulk", 2)
+	pushJobs(t, q, "", "default", 1)
+	pushJobs(t, q, constants.PriorityHigh, "control", 1)
+
+	if got, want := popOrder(q), "control,default,bulk,bulk"; got != want {
+		t.Errorf("pop order = %s, want %s", got, want)
+	}
+}
+
+func TestFairQueue_InterleavesTenantsByWeight(t *testing.T) {
+	q := runway.NewFairQueue(runway.QueueConfig{Capacity: 20})
+	// A noisy tenant queues first; a quiet one arriving later is not stuck behind it.
+	pushJobs(t, q, constants.PriorityNormal, "noisy", 6)
+	pushJobs(t, q, constants.PriorityNormal, "quiet", 2)
+	if got, want := popOrder(q), "noisy,quiet,noisy,quiet,noisy,noisy,noisy,noisy"; got != want {
+		t.Errorf("pop order = %s, want %s", got, want)
+	}
+
+	q = runway.NewFairQueue(runway.QueueConfig{Capacity: 20, TenantWeights: map[string]float64{"gold": 2}})
+	pushJobs(t, q, constants.PriorityNormal, "gold", 4)
+	pushJobs(t, q, constants.PriorityNormal, "free", 2)
+	if got, want := popOrder(q), "gold,gold,free,gold,gold,free"; got != want {
+		t.Errorf("weighted pop order = %s, want %s", got, want)
+	}
+}
+
+func TestFairQueue_LimitsTenantShareAndReportsClasses(t *testing.T) {
+	q := runway.NewFairQueue(runway.QueueConfig{Capacity: 4, MaxPerTenant: 2})
+	pushJobs(t, q, constants.PriorityLow, "noisy", 2)
+	if err := q.Push(&runway.InferenceJob{Priority: constants.PriorityLow, Tenant: "noisy"}); !errors.Is(err, runway.ErrQueueFull) {
+		t.Fatalf("Push() over the tenant limit error = %v, want ErrQueueFull", err)
+	}
+	pushJobs(t, q, constants.PriorityHigh, "control", 2)
+	if err := q.Push(&runway.InferenceJob{Priority: constants.PriorityHigh, Tenant: "other"}); !errors.Is(err, runway.ErrQueueFull) {
+		t.Fatalf("Push() to a full queue error = %v, want ErrQueueFull", err)
+	}
+
+	stats := q.Stats()
+	if len(stats) != 3 || stats[0].Class != constants.PriorityHigh || stats[2].Class != constants.PriorityLow {
+		t.Fatalf("Stats() = %+v, want high, normal and low classes", stats)
+	}
+	if s := stats[0]; s.QueueDepth != 2 || s.Enqueued != 2 || s.Rejected != 1 {
+		t.Errorf("high class stats = %+v, want depth 2, 2 enqueued, 1 rejected", s)
+	}
+	if s := stats[2]; s.QueueDepth != 2 || s.Enqueued != 2 || s.Rejected != 1 {
+		t.Errorf("low class stats = %+v, want depth 2, 2 enqueued, 1 rejected", s)
+	}
+
+	popOrder(q)
+	if q.Len() != 0 || q.Stats()[0].QueueDepth != 0 {
+		t.Errorf("queue still holds %d jobs after draining", q.Len())
+	}
+}
+
+func TestFairQueue_CloseReturnsWaitingJobs(t *testing.T) {
+	q := runway.NewFairQueue(runway.QueueConfig{Capacity: 10})
+	pushJobs(t, q, constants.PriorityHigh, "control", 1)
+	pushJobs(t, q, constants.PriorityNormal, "team-a", 2)
+
+	if left := q.Close(); len(left) != 3 {
+		t.Fatalf("Close() returned %d jobs, want the 3 waiting", len(left))
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() after Close() = %d, want 0", q.Len())
+	}
+	if err := q.Push(&runway.InferenceJob{Tenant: "team-a"}); !errors.Is(err, runway.ErrReplicaStopped) {
+		t.Fatalf("Push() after Close() error = %v, want ErrReplicaStopped", err)
+	}
+}
@@ -1,12 +1,17 @@
 package tests
 
 import (
+	"context"
+	"errors"
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/preprocess"
 )
 
 func init() {
@@ -21,51 +26,99 @@ func init() {
 	}
 }
 
-func TestScaleFeatures(t *testing.T) {
-	raw := []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
-	scaled := runway.ScaleFeatures(raw)
+func TestPreprocessSpec_Transform(t *testing.T) {
+	spec := &preprocess.Spec{
+		Features: []preprocess.Feature{
+			{Name: "price", Type: preprocess.Standard, Mean: 100, Scale: 50},
+			{Name: "year", Type: preprocess.MinMax, Min: 2000, Max: 2020},
+			{Name: "fuel", Type: preprocess.OneHot, Categories: []float64{0, 1, 2}},
+			{Name: "doors", Type: preprocess.None},
+		},
+		Target: &preprocess.Target{Type: preprocess.Standard, Mean: 1000, Scale: 10},
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := spec.Width(); got != 6 {
+		t.Fatalf("Width() = %d, want 6", got)
+	}
+
+	// Two raw rows of four features; more than the old fixed 12 values in total is fine.
+	got, err := spec.Transform([]float32{150, 2010, 2, 4, 50, 2000, 7, 2})
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+	want := []float32{1, 0.5, 0, 0, 1, 4, -1, 0, 0, 0, 0, 2}
+	if !slices.Equal(got, want) {
+		t.Errorf("Transform() = %v, want %v", got, want)
+	}
+	if _, err := spec.Transform([]float32{1, 2, 3}); err == nil {
+		t.Error("Transform() of a partial row succeeded")
+	}
 
-	if len(scaled) != len(raw) {
-		t.Fatalf("Expected length %d, got %d", len(raw), len(scaled))
+	pred := []float32{0.5, -1}
+	spec.InverseTarget(pred)
+	if !slices.Equal(pred, []float32{1005, 990}) {
+		t.Errorf("InverseTarget() = %v, want [1005 990]", pred)
 	}
 
-	for i, v := range scaled {
-		if v != raw[i] { // Default means=0, scales=1 so values should remain the same
-			t.Errorf("Expected %f, got %f at index %d", raw[i], v, i)
+	for _, raw := range []string{
+		`{}`,
+		`{"features":[{"type":"standard"}]}`,
+		`{"features":[{"type":"minmax","min":1,"max":1}]}`,
+		`{"features":[{"type":"onehot"}]}`,
+		`{"features":[{"type":"onehot","categories":[1,1]}]}`,
+		`{"features":[{"type":"log"}]}`,
+		`{"target":{"type":"onehot"}}`,
+	} {
+		if _, err := preprocess.Parse(raw); err == nil {
+			t.Errorf("Parse(%s) succeeded, want error", raw)
 		}
 	}
+	if spec, err := preprocess.Parse(""); spec != nil || err != nil {
+		t.Errorf("Parse(\"\") = %v, %v; want nil, nil", spec, err)
+	}
 }
 
 func TestModelInference(t *testing.T) {
-	runway.InitRuntime()
+	if err := runway.InitRuntime(); err != nil {
+		t.Skipf("onnxruntime not available: %v", err)
+	}
 	defer runway.CloseRuntime()
 
 	modelPath := filepath.Join("tests", "test_assets", "mlp_price_predictor_1.onnx")
 	replicaID := "test-replica-777"
 
 	// Start the background worker explicitly configured for 2 instances
-	err := runway.StartModelWorkers(replicaID, modelPath, 2)
+	err := runway.StartModelWorkers(replicaID, modelPath, 2, runway.BatchConfig{}, nil)
 	if err != nil {
 		t.Fatalf("Failed to start model workers: %v", err)
 	}
 	defer runway.StopModelWorkers(replicaID)
 
-	rawFeatures := []float32{
-		6000, 3, 2, 2, 1, 0, 1, 0, 1, 2, 1, 2,
+	sig, ok := runway.ModelSignature(replicaID)
+	if !ok || len(sig.Inputs) != 1 || len(sig.Outputs) == 0 {
+		t.Fatalf("ModelSignature() = %+v, %v; want one input and an output", sig, ok)
 	}
 
+	rawFeatures := []runway.Tensor{{
+		DType: runway.DataTypeFloat32,
+		Shape: []int64{1, 12},
+		Data:  []float32{6000, 3, 2, 2, 1, 0, 1, 0, 1, 2, 1, 2},
+	}}
+
 	// Run 10 inferences concurrently to ensure thread safety
 	errCh := make(chan error, 10)
 	priceCh := make(chan float32, 10)
 
 	for i := 0; i < 10; i++ {
 		go func() {
-			price, err := runway.ModelInference(replicaID, rawFeatures, true)
+			outputs, err := runway.ModelInference(context.Background(), replicaID, rawFeatures, runway.InferOptions{ScalingEnabled: true})
 			if err != nil {
 				errCh <- err
 				return
 			}
-			priceCh <- price
+			priceCh <- outputs[0].Data.([]float32)[0]
 		}()
 	}
 
@@ -81,3 +134,183 @@ func TestModelInference(t *testing.T) {
 		}
 	}
 }
+
+func TestStopModelWorkers_FailsRequestsInFlight(t *testing.T) {
+	if err := runway.InitRuntime(); err != nil {
+		t.Skipf("onnxruntime not available: %v", err)
+	}
+	defer runway.CloseRuntime()
+
+	modelPath := filepath.Join("tests", "test_assets", "mlp_price_predictor_1.onnx")
+	replicaID := "test-replica-stop"
+	if err := runway.StartModelWorkers(replicaID, modelPath, 1, runway.BatchConfig{}, nil); err != nil {
+		t.Fatalf("Failed to start model workers: %v", err)
+	}
+
+	features := []runway.Tensor{{
+		DType: runway.DataTypeFloat32,
+		Shape: []int64{1, 12},
+		Data:  []float32{6000, 3, 2, 2, 1, 0, 1, 0, 1, 2, 1, 2},
+	}}
+
+	// Queue more requests than one worker gets through before the stop.
+	const requests = 50
+	errCh := make(chan error, requests)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 0; i < requests; i++ {
+		go func() {
+			_, err := runway.ModelInference(ctx, replicaID, features, runway.InferOptions{})
+			errCh <- err
+		}()
+	}
+
+	if err := runway.StopModelWorkers(replicaID); err != nil {
+		t.Fatalf("StopModelWorkers() error = %v", err)
+	}
+
+	// Every request is answered: run before the stop, or failed by it,
+	// rather than left waiting for its deadline.
+	for i := 0; i < requests; i++ {
+		select {
+		case err := <-errCh:
+			if err != nil && !errors.Is(err, runway.ErrReplicaStopped) {
+				t.Fatalf("ModelInference() error = %v, want nil or ErrReplicaStopped", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%d request(s) still waiting a second after the stop", requests-i)
+		}
+	}
+}
+
+func TestValidateInputs(t *testing.T) {
+	specs := []runway.TensorSpec{
+		{Name: "image", DType: runway.DataTypeFloat32, Shape: []int64{-1, 3, 2, 2}},
+		{Name: "ids", DType: runway.DataTypeInt64, Shape: []int64{-1, 2}},
+	}
+	image := runway.Tensor{Name: "image", DType: runway.DataTypeFloat32, Shape: []int64{1, 3, 2, 2}, Data: make([]float32, 12)}
+	ids := runway.Tensor{Name: "ids", DType: runway.DataTypeInt64, Shape: []int64{2, 2}, Data: []int64{1, 2, 3, 4}}
+
+	ordered, err := runway.ValidateInputs(specs, []runway.Tensor{ids, image})
+	if err != nil {
+		t.Fatalf("ValidateInputs() error = %v", err)
+	}
+	if ordered[0].Name != "image" || ordered[1].Name != "ids" {
+		t.Errorf("inputs not in model order: %s, %s", ordered[0].Name, ordered[1].Name)
+	}
+
+	wrongDType := ids
+	wrongDType.DType, wrongDType.Data = runway.DataTypeInt32, []int32{1, 2, 3, 4}
+	wrongShape := image
+	wrongShape.Shape = []int64{1, 4, 2, 2}
+	shortData := ids
+	shortData.Data = []int64{1, 2, 3}
+	unnamed := image
+	unnamed.Name = ""
+
+	tests := []struct {
+		name   string
+		inputs []runway.Tensor
+	}{
+		{"missing input", []runway.Tensor{image}},
+		{"unknown input", []runway.Tensor{image, ids, {Name: "extra", DType: runway.DataTypeBool, Shape: []int64{1}, Data: []bool{true}}}},
+		{"duplicate input", []runway.Tensor{image, ids, ids}},
+		{"wrong dtype", []runway.Tensor{image, wrongDType}},
+		{"fixed dimension mismatch", []runway.Tensor{wrongShape, ids}},
+		{"data does not fill shape", []runway.Tensor{image, shortData}},
+		{"unnamed input with several model inputs", []runway.Tensor{unnamed, ids}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runway.ValidateInputs(specs, tt.inputs)
+			if !errors.Is(err, runway.ErrInvalidInput) {
+				t.Errorf("ValidateInputs() error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+
+	// A single-input model binds an unnamed tensor to its input.
+	ordered, err = runway.ValidateInputs(specs[:1], []runway.Tensor{unnamed})
+	if err != nil || ordered[0].Name != "image" {
+		t.Errorf("ValidateInputs(unnamed) = %v, %v; want it bound to image", ordered, err)
+	}
+}
+
+func TestLatencyWindow_Percentile(t *testing.T) {
+	w := runway.NewLatencyWindow(100, 0)
+	if got := w.Percentile(95); got != 0 {
+		t.Errorf("Percentile() of empty window = %v, want 0", got)
+	}
+	for i := 1; i <= 100; i++ {
+		w.Observe(time.Duration(i) * time.Millisecond)
+	}
+	if got := w.Percentile(95); got != 95*time.Millisecond {
+		t.Errorf("Percentile(95) = %v, want 95ms", got)
+	}
+
+	// New samples replace the oldest ones once the window is full.
+	for i := 0; i < 100; i++ {
+		w.Observe(time.Second)
+	}
+	if got := w.Percentile(50); got != time.Second {
+		t.Errorf("Percentile(50) after refill = %v, want 1s", got)
+	}
+
+	// Samples older than the window's maximum age no longer count, so an idle
+	// replica stops reporting the latency of its last busy period.
+	aging := runway.NewLatencyWindow(100, 50*time.Millisecond)
+	aging.Observe(time.Second)
+	if got := aging.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1", got)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got, n := aging.Percentile(95), aging.Len(); got != 0 || n != 0 {
+		t.Errorf("Percentile(95), Len() after max age = %v, %d, want 0, 0", got, n)
+	}
+}
+
+func TestStackInputsAndSplitOutputs(t *testing.T) {
+	jobs := [][]runway.Tensor{
+		{{Name: "x", DType: runway.DataTypeFloat32, Shape: []int64{1, 2}, Data: []float32{1, 2}}},
+		{{Name: "x", DType: runway.DataTypeFloat32, Shape: []int64{2, 2}, Data: []float32{3, 4, 5, 6}}},
+	}
+	stacked, rows, err := runway.StackInputs(jobs)
+	if err != nil {
+		t.Fatalf("StackInputs() error = %v", err)
+	}
+	if got := stacked[0]; !slices.Equal(got.Shape, []int64{3, 2}) || !slices.Equal(got.Data.([]float32), []float32{1, 2, 3, 4, 5, 6}) {
+		t.Fatalf("stacked = %+v, want shape [3 2] with all rows", got)
+	}
+	if !slices.Equal(rows, []int64{1, 2}) {
+		t.Fatalf("rows = %v, want [1 2]", rows)
+	}
+
+	outputs := []runway.Tensor{{Name: "y", DType: runway.DataTypeInt64, Shape: []int64{3, 1}, Data: []int64{10, 20, 30}}}
+	split, err := runway.SplitOutputs(outputs, rows)
+	if err != nil {
+		t.Fatalf("SplitOutputs() error = %v", err)
+	}
+	if got := split[1][0]; !slices.Equal(got.Shape, []int64{2, 1}) || !slices.Equal(got.Data.([]int64), []int64{20, 30}) {
+		t.Errorf("second job output = %+v, want rows 20 and 30", got)
+	}
+
+	if _, err := runway.SplitOutputs([]runway.Tensor{{Name: "y", Shape: []int64{1}, Data: []int64{1}}}, rows); err == nil {
+		t.Error("SplitOutputs() of an output without a row per input succeeded")
+	}
+	jobs[1][0].Shape, jobs[1][0].Data = []int64{1, 3}, []float32{3, 4, 5}
+	if _, _, err := runway.StackInputs(jobs); err == nil {
+		t.Error("StackInputs() of inputs with different trailing dimensions succeeded")
+	}
+}
+
+func TestSignature_Batchable(t *testing.T) {
+	spec := func(shape ...int64) runway.TensorSpec {
+		return runway.TensorSpec{Name: "t", DType: runway.DataTypeFloat32, Shape: shape}
+	}
+	if sig := (runway.Signature{Inputs: []runway.TensorSpec{spec(-1, 12)}, Outputs: []runway.TensorSpec{spec(-1, 1)}}); !sig.Batchable() {
+		t.Error("signature with dynamic leading dimensions is not batchable")
+	}
+	if sig := (runway.Signature{Inputs: []runway.TensorSpec{spec(1, 12)}, Outputs: []runway.TensorSpec{spec(-1, 1)}}); sig.Batchable() {
+		t.Error("signature with a fixed batch dimension is batchable")
+	}
+}
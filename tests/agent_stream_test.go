@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	grpcinfer "github.com/kennethnrk/edgernetes-ai/internal/agent/api/grpc/infer"
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+)
+
+// countingInferServer serves an agent's InferAPI and counts the streams opened
+// to it.
+type countingInferServer struct {
+	inferpb.InferAPIServer
+	streams atomic.Int32
+}
+
+func (s *countingInferServer) StreamInfer(stream inferpb.InferAPI_StreamInferServer) error {
+	s.streams.Add(1)
+	return s.InferAPIServer.StreamInfer(stream)
+}
+
+// startStreamingAgent serves a as node peer-a, forwarding model-1 to the
+// fake agent on nextPort, and returns the server and its port.
+func startStreamingAgent(t *testing.T, nextPort int) (*countingInferServer, int) {
+	t.Helper()
+	a := &agent.Agent{ID: "peer-a", ForwardPolicy: agent.DefaultForwardPolicy()}
+	a.UpdateEndpoints([]*heartbeatpb.ServiceEndpoints{{ModelId: "model-1", Endpoints: []*heartbeatpb.EndpointDetail{
+		{NodeId: "node-c", Ip: "127.0.0.1", Port: int32(nextPort), Healthy: true, Weight: 1},
+	}}})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	srv := &countingInferServer{InferAPIServer: grpcinfer.NewInferServer(a)}
+	s := grpc.NewServer()
+	inferpb.RegisterInferAPIServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+	return srv, lis.Addr().(*net.TCPAddr).Port
+}
+
+func TestStreamInfer_CorrelatesResponsesByRequestID(t *testing.T) {
+	nodeC, portC := startFakeInferAgent(t)
+	nodeC.serveAs("node-c")
+	_, port := startStreamingAgent(t, portC)
+
+	conn, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := inferpb.NewInferAPIClient(conn).StreamInfer(ctx)
+	if err != nil {
+		t.Fatalf("StreamInfer() error = %v", err)
+	}
+
+	const n = 20
+	for i := 0; i < n; i++ {
+		req := &inferpb.InferRequest{RequestId: fmt.Sprintf("req-%d", i), ModelId: "model-1", InputData: []float32{1}}
+		if i == 0 {
+			req.Priority = "urgent"
+		}
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	if err := stream.Send(&inferpb.InferRequest{ModelId: "model-1"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend() error = %v", err)
+	}
+
+	got := make(map[string]*inferpb.InferResponse)
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if _, dup := got[resp.RequestId]; dup {
+			t.Fatalf("two responses for request %q", resp.RequestId)
+		}
+		got[resp.RequestId] = resp
+	}
+	if len(got) != n+1 {
+		t.Fatalf("received %d responses, want %d", len(got), n+1)
+	}
+	if r := got["req-0"]; codes.Code(r.Code) != codes.InvalidArgument || r.Success {
+		t.Errorf("response to an unknown priority = %v, want INVALID_ARGUMENT", r)
+	}
+	if r := got[""]; codes.Code(r.Code) != codes.InvalidArgument {
+		t.Errorf("response to a request without ID = %v, want INVALID_ARGUMENT", r)
+	}
+	for i := 1; i < n; i++ {
+		if r := got[fmt.Sprintf("req-%d", i)]; !r.Success || r.ServedBy != "node-c" || r.HopCount != 1 {
+			t.Errorf("response %d = %v, want success served by node-c after one hop", i, r)
+		}
+	}
+}
+
+func TestHandleInfer_ForwardsOverOneStreamPerPeer(t *testing.T) {
+	nodeC, portC := startFakeInferAgent(t)
+	nodeC.serveAs("node-c")
+	middle, port := startStreamingAgent(t, portC)
+
+	policy := agent.DefaultForwardPolicy()
+	policy.Streams = true
+	a := forwardingAgent(policy, port)
+
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			res, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+			if err == nil && (res.ServedBy != "node-c" || res.HopCount != 2) {
+				err = fmt.Errorf("served by %q after %d hops, want node-c after 2", res.ServedBy, res.HopCount)
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("HandleInfer() over a stream error = %v", err)
+		}
+	}
+	if got := middle.streams.Load(); got != 1 {
+		t.Errorf("peer had %d streams opened, want 1 shared by every request", got)
+	}
+
+	// Per-request failures keep their code and retry hint over the stream.
+	nodeC.failWith(agent.OverloadedStatus(&agent.OverloadedError{ModelID: "model-1", RetryAfter: 700 * time.Millisecond}).Err())
+	_, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})})
+	var over *agent.OverloadedError
+	if !errors.As(err, &over) || over.RetryAfter != 700*time.Millisecond {
+		t.Errorf("HandleInfer() with a saturated replica behind the stream error = %v, want OverloadedError after 700ms", err)
+	}
+}
+
+func TestHandleInfer_FallsBackToUnaryWithoutStreams(t *testing.T) {
+	peer, port := startFakeInferAgent(t)
+	policy := agent.DefaultForwardPolicy()
+	policy.Streams = true
+	a := forwardingAgent(policy, port)
+
+	for i := 0; i < 3; i++ {
+		if _, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Inputs: agent.FlatInput([]float32{1})}); err != nil {
+			t.Fatalf("HandleInfer() #%d to a peer without StreamInfer error = %v", i, err)
+		}
+	}
+	if got := len(peer.seen()); got != 3 {
+		t.Errorf("peer served %d unary requests, want 3", got)
+	}
+	if st := peerStatsOf(t, a, "peer-a"); st.Failures != 0 {
+		t.Errorf("peer stats = %+v, want no failures for the missing stream support", st)
+	}
+}
@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	"github.com/kennethnrk/edgernetes-ai/internal/agent/runway"
+	"github.com/kennethnrk/edgernetes-ai/internal/client"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/inputformat"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+)
+
+func TestTensorsProtoRoundTrip(t *testing.T) {
+	tensors := []runway.Tensor{
+		{Name: "image", DType: runway.DataTypeUint8, Shape: []int64{1, 2}, Data: []uint8{0, 255}},
+		{Name: "ids", DType: runway.DataTypeInt64, Shape: []int64{1, 3}, Data: []int64{7, 8, 9}},
+		{Name: "mask", DType: runway.DataTypeBool, Shape: []int64{1}, Data: []bool{true}},
+	}
+
+	got, err := agent.TensorsFromProto(agent.TensorsToProto(tensors))
+	if err != nil {
+		t.Fatalf("TensorsFromProto() error = %v", err)
+	}
+	for i, want := range tensors {
+		if got[i].Name != want.Name || got[i].DType != want.DType || !slices.Equal(got[i].Shape, want.Shape) || got[i].Len() != want.Len() {
+			t.Errorf("tensor %d = %+v, want %+v", i, got[i], want)
+		}
+	}
+	if data := got[0].Data.([]uint8); data[1] != 255 {
+		t.Errorf("uint8 data = %v, want [0 255]", data)
+	}
+
+	_, err = agent.TensorsFromProto([]*inferpb.Tensor{{Name: "image", Dtype: inferpb.DataType_UINT8, Int32Data: []int32{256}}})
+	if !errors.Is(err, runway.ErrInvalidInput) {
+		t.Errorf("out of range uint8: error = %v, want ErrInvalidInput", err)
+	}
+	_, err = agent.TensorsFromProto([]*inferpb.Tensor{{Name: "x"}})
+	if !errors.Is(err, runway.ErrInvalidInput) {
+		t.Errorf("missing dtype: error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestParseTensor(t *testing.T) {
+	tensor, err := client.ParseTensor("ids:int64:2x2=1,2,3,4")
+	if err != nil {
+		t.Fatalf("ParseTensor() error = %v", err)
+	}
+	if tensor.Name != "ids" || tensor.Dtype != inferpb.DataType_INT64 || !slices.Equal(tensor.Shape, []int64{2, 2}) || !slices.Equal(tensor.Int64Data, []int64{1, 2, 3, 4}) {
+		t.Errorf("ParseTensor() = %v", tensor)
+	}
+	if got := client.FormatTensorValues(tensor); got != "1,2,3,4" {
+		t.Errorf("FormatTensorValues() = %q", got)
+	}
+
+	for _, arg := range []string{"ids:int64:2x2", "ids:int16:4=1,2,3,4", "ids:int64:2xa=1,2", "x:float32:1=abc"} {
+		if _, err := client.ParseTensor(arg); err == nil {
+			t.Errorf("ParseTensor(%q) succeeded, want error", arg)
+		}
+	}
+}
+
+func TestInputFormat_Encode(t *testing.T) {
+	format, err := inputformat.Parse([]byte(`{
+		"name": "string",
+		"year": "integer",
+		"price": "number",
+		"fuel": {"type": "string", "enum": ["petrol", "diesel"]},
+		"used": "boolean",
+		"extras": {"type": "array", "length": 2}
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// Fields are laid out in declaration order, whatever order they arrive in.
+	row, err := format.Encode(map[string]any{
+		"extras": []any{7.0, 8.0},
+		"used":   true,
+		"fuel":   "diesel",
+		"price":  25000.0,
+		"year":   2019.0,
+		"name":   "civic",
+	})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if want := []float32{2019, 25000, 1, 1, 7, 8}; !slices.Equal(row, want) {
+		t.Errorf("Encode() = %v, want %v", row, want)
+	}
+
+	_, err = format.Encode(map[string]any{
+		"name":   "civic",
+		"year":   2019.5,
+		"price":  "cheap",
+		"fuel":   "electric",
+		"extras": []any{1.0},
+		"color":  "red",
+	})
+	var verr *inputformat.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Encode() error = %v, want a ValidationError", err)
+	}
+	var got []string
+	for _, f := range verr.Fields {
+		got = append(got, f.Field)
+	}
+	if want := []string{"year", "price", "fuel", "used", "extras", "color"}; !slices.Equal(got, want) {
+		t.Errorf("rejected fields = %v, want %v", got, want)
+	}
+
+	for _, raw := range []string{`[]`, `{}`, `{"x": "text"}`, `{"x": {"type": "number", "enum": ["a"]}}`, `{"x": "number", "x": "number"}`} {
+		if _, err := inputformat.Parse([]byte(raw)); err == nil {
+			t.Errorf("Parse(%s) succeeded, want error", raw)
+		}
+	}
+}
+
+func TestHandleInfer_RejectsInvalidFields(t *testing.T) {
+	a := &agent.Agent{ID: "agent-1"}
+	if err := a.AssignModel(agent.ModelReplicaDetails{
+		ID:          "replica-1",
+		ModelID:     "model-1",
+		Status:      constants.ModelReplicaStatusRunning,
+		InputFormat: json.RawMessage(`{"price": "number", "year": "number"}`),
+	}); err != nil {
+		t.Fatalf("AssignModel() error = %v", err)
+	}
+
+	_, err := a.HandleInfer(context.Background(), agent.InferRequest{ModelID: "model-1", Fields: map[string]any{"price": "high"}})
+	if !errors.Is(err, runway.ErrInvalidInput) {
+		t.Fatalf("HandleInfer() error = %v, want ErrInvalidInput", err)
+	}
+
+	// The rejected fields survive the round trip through a gRPC status.
+	verr := agent.ValidationErrorFromStatus(agent.InvalidInputStatus(err))
+	want := []inputformat.FieldError{
+		{Field: "price", Description: "expected number, got string"},
+		{Field: "year", Description: "required number field is missing"},
+	}
+	if verr == nil || !reflect.DeepEqual(verr.Fields, want) {
+		t.Errorf("field violations = %+v, want %+v", verr, want)
+	}
+}
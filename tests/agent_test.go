@@ -64,3 +64,22 @@ func TestAssignModel_AlreadyAssigned(t *testing.T) {
 		t.Fatalf("Expected exactly 1 assigned model, got %d", len(a.AssignedModels))
 	}
 }
+
+func TestReplaceFailedModel(t *testing.T) {
+	failed := agent.ModelReplicaDetails{ID: "replica-1", ModelID: "model-1", Status: constants.ModelReplicaStatusFailed}
+	a := &agent.Agent{ID: "agent-1", AssignedModels: []agent.ModelReplicaDetails{failed}}
+
+	restarted := failed
+	restarted.Status = constants.ModelReplicaStatusPending
+	if !a.ReplaceFailedModel(restarted) {
+		t.Fatal("ReplaceFailedModel() = false, want true for a failed replica")
+	}
+	if got, _ := a.GetModelReplica("replica-1"); got.Status != constants.ModelReplicaStatusPending {
+		t.Fatalf("replica status = %s, want pending", got.Status)
+	}
+
+	// A replica that has not failed cannot be replaced.
+	if a.ReplaceFailedModel(restarted) {
+		t.Fatal("ReplaceFailedModel() = true, want false for a pending replica")
+	}
+}
@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/client"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+	grpcregistry "github.com/kennethnrk/edgernetes-ai/internal/control-plane/api/grpc/registry"
+)
+
+// fakeInferAgent records the requests it is asked to run and the deadlines
+// of the calls, and answers after delay with err if set. Successful answers
+// are served by nodeID and carry outputs.
+type fakeInferAgent struct {
+	inferpb.UnimplementedInferAPIServer
+
+	mu        sync.Mutex
+	nodeID    string
+	models    []string
+	requests  []*inferpb.InferRequest
+	deadlines []time.Duration
+	err       error
+	delay     time.Duration
+	outputs   []*inferpb.Tensor
+}
+
+func (f *fakeInferAgent) Infer(ctx context.Context, req *inferpb.InferRequest) (*inferpb.InferResponse, error) {
+	f.mu.Lock()
+	f.models = append(f.models, req.ModelId)
+	f.requests = append(f.requests, req)
+	if d, ok := ctx.Deadline(); ok {
+		f.deadlines = append(f.deadlines, time.Until(d))
+	}
+	err, delay, nodeID, outputs := f.err, f.delay, f.nodeID, f.outputs
+	f.mu.Unlock()
+
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inferpb.InferResponse{Success: true, Prediction: 42, ServedBy: nodeID, HopCount: req.HopCount, Outputs: outputs}, nil
+}
+
+func (f *fakeInferAgent) answerWith(outputs ...*inferpb.Tensor) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.outputs = outputs
+}
+
+func (f *fakeInferAgent) serveAs(nodeID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nodeID = nodeID
+}
+
+func (f *fakeInferAgent) received() []*inferpb.InferRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*inferpb.InferRequest(nil), f.requests...)
+}
+
+func (f *fakeInferAgent) slowDown(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delay = d
+}
+
+// deadlinesSeen returns the time left until the deadline of every call.
+func (f *fakeInferAgent) deadlinesSeen() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Duration(nil), f.deadlines...)
+}
+
+func (f *fakeInferAgent) failWith(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeInferAgent) seen() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.models...)
+}
+
+// startFakeInferAgent serves a fake InferAPI on a loopback port and returns its port.
+func startFakeInferAgent(t *testing.T) (*fakeInferAgent, int) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	agent := &fakeInferAgent{}
+	s := grpc.NewServer()
+	inferpb.RegisterInferAPIServer(s, agent)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	return agent, lis.Addr().(*net.TCPAddr).Port
+}
+
+// closedPort returns a loopback port nothing listens on.
+func closedPort(t *testing.T) int {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	port := lis.Addr().(*net.TCPAddr).Port
+	lis.Close()
+	return port
+}
+
+func TestRouter_InferByNameFailsOverAndCaches(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, livePort := startFakeInferAgent(t)
+	requireRegisterModel(t, s, "model-1", "ModelA", 2)
+	requireCreateReplica(t, s, "rep-1", "model-1", constants.ModelReplicaStatusRunning)
+	requireCreateReplica(t, s, "rep-2", "model-1", constants.ModelReplicaStatusRunning)
+	requireRegisterNodeWithReplicas(t, s, "node-dead", "127.0.0.1", closedPort(t), []string{"rep-1"})
+	requireRegisterNodeWithReplicas(t, s, "node-live", "127.0.0.1", livePort, []string{"rep-2"})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	srv := grpc.NewServer()
+	grpcregistry.RegisterServices(srv, s, t.TempDir())
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	c, err := client.New(lis.Addr().String(), 5*time.Second)
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	defer c.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "endpoints.json")
+	router := client.NewRouter(c, client.LoadEndpointCache(cachePath, time.Minute), 5*time.Second)
+	for i := 0; i < 4; i++ {
+		resp, served, err := router.InferByName("default", "ModelA", &inferpb.InferRequest{InputData: []float32{1}})
+		if err != nil {
+			t.Fatalf("InferByName() error = %v", err)
+		}
+		if !resp.Success || served.NodeID != "node-live" {
+			t.Fatalf("InferByName() served by %q success=%v, want node-live", served.NodeID, resp.Success)
+		}
+	}
+	for _, id := range agent.seen() {
+		if id != "model-1" {
+			t.Fatalf("agent ran model %q, want the ID resolved from the name", id)
+		}
+	}
+
+	// A later invocation reuses the cached endpoints without the control plane.
+	cached := client.NewRouter(nil, client.LoadEndpointCache(cachePath, time.Minute), 5*time.Second)
+	if _, served, err := cached.InferByName("default", "ModelA", &inferpb.InferRequest{InputData: []float32{1}}); err != nil || served.NodeID != "node-live" {
+		t.Fatalf("InferByName() from cache = %q, %v, want node-live", served.NodeID, err)
+	}
+	if _, _, err := cached.InferByName("default", "ModelB", &inferpb.InferRequest{}); err == nil {
+		t.Error("InferByName() of an uncached model without a control plane succeeded")
+	}
+}
+
+func TestRouter_AllNodesUnreachable(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "endpoints.json")
+	cache := client.LoadEndpointCache(cachePath, time.Minute)
+	if err := cache.Put("nodes", client.Endpoints{
+		Nodes: []client.Endpoint{
+			{NodeID: "node-1", IP: "127.0.0.1", Port: int32(closedPort(t))},
+			{NodeID: "node-2", IP: "127.0.0.1", Port: int32(closedPort(t))},
+		},
+		FetchedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	router := client.NewRouter(nil, cache, time.Second)
+	_, _, err := router.InferByID(&inferpb.InferRequest{ModelId: "model-1"})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("InferByID() error = %v, want Unavailable", err)
+	}
+	if _, ok := client.LoadEndpointCache(cachePath, time.Minute).Get("nodes"); ok {
+		t.Error("stale endpoints were kept after every node failed")
+	}
+}
+
+func TestEndpointCache_ExpiresAfterTTL(t *testing.T) {
+	cache := client.LoadEndpointCache(filepath.Join(t.TempDir(), "endpoints.json"), time.Minute)
+	eps := client.Endpoints{Nodes: []client.Endpoint{{NodeID: "node-1", IP: "10.0.0.1", Port: 50052}}}
+
+	eps.FetchedAt = time.Now().Add(-30 * time.Second)
+	if err := cache.Put("nodes", eps); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, ok := cache.Get("nodes"); !ok {
+		t.Error("Get() missed an entry younger than the TTL")
+	}
+
+	eps.FetchedAt = time.Now().Add(-2 * time.Minute)
+	if err := cache.Put("nodes", eps); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, ok := cache.Get("nodes"); ok {
+		t.Error("Get() returned an entry older than the TTL")
+	}
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/connpool"
+	inferpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/infer"
+)
+
+func TestPool_ReusesConnectionPerPeer(t *testing.T) {
+	_, port := startFakeInferAgent(t)
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+
+	p := connpool.New(connpool.Options{})
+	defer p.Close()
+
+	c1, release1, err := p.Get(addr)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	c2, release2, err := p.Get(addr)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer release2()
+	if c1 != c2 || p.Len() != 1 {
+		t.Fatalf("Get() twice for %s opened %d connection(s), want one shared", addr, p.Len())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := inferpb.NewInferAPIClient(c1).Infer(ctx, &inferpb.InferRequest{ModelId: "model-1"}); err != nil {
+		t.Fatalf("Infer() over pooled connection error = %v", err)
+	}
+	release1()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if st, ok := p.State(addr); ok && st == connectivity.Ready {
+			break
+		}
+		if time.Now().After(deadline) {
+			st, _ := p.State(addr)
+			t.Fatalf("State() = %v after a successful call, want READY", st)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPool_EvictsOnlyReleasedIdleConnections(t *testing.T) {
+	_, port := startFakeInferAgent(t)
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+
+	p := connpool.New(connpool.Options{IdleTimeout: 20 * time.Millisecond})
+	defer p.Close()
+
+	_, release, err := p.Get(addr)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if p.EvictIdle() != 0 || p.Len() != 1 {
+		t.Fatal("EvictIdle() closed a connection that is still in use")
+	}
+
+	release()
+	deadline := time.Now().Add(time.Second)
+	for p.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("released connection was not closed after the idle timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	autoscalercontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/autoscaler"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// reportLoad marks every replica of the model running with the given load, as
+// if its agent had just answered a heartbeat at now. A replica reporting a p95
+// latency has finished jobs recently.
+func reportLoad(t *testing.T, s *store.Store, modelID string, queueDepth int, p95 float64, now time.Time) {
+	t.Helper()
+	replicas, err := replicascheduler.ListReplicasByModelID(s, modelID)
+	if err != nil {
+		t.Fatalf("ListReplicasByModelID() error = %v", err)
+	}
+	for _, r := range replicas {
+		if _, err := replicascheduler.MutateReplica(s, r.ID, func(r *store.ReplicaInfo) {
+			r.Status = constants.ModelReplicaStatusRunning
+			r.QueueDepth = queueDepth
+			r.P95LatencyMs = p95
+			r.RecentJobs = 0
+			if p95 > 0 {
+				r.RecentJobs = 10
+			}
+			r.LastHeartbeat = now
+		}); err != nil {
+			t.Fatalf("MutateReplica() error = %v", err)
+		}
+	}
+}
+
+// setupAutoscaledModel registers model-1 with one replica, autoscaled between
+// 1 and 4 replicas at 2 jobs per replica, on a single online node.
+func setupAutoscaledModel(t *testing.T, s *store.Store) *placementscheduler.Scheduler {
+	t.Helper()
+	_, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	if err := registrycontroller.RegisterModel(s, "model-1", store.ModelInfo{
+		ID: "model-1", Name: "ModelA", Namespace: "default", Replicas: 1,
+		Autoscaling: &store.Autoscaling{MinReplicas: 1, MaxReplicas: 4, TargetQueueDepth: 2},
+	}); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+	sch := placementscheduler.New(s)
+	if err := sch.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	return sch
+}
+
+func TestAutoscaler_ScalesUpOnQueueDepth(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+	sch := setupAutoscaledModel(t, s)
+
+	now := time.Now()
+	reportLoad(t, s, "model-1", 6, 0, now)
+
+	as := autoscalercontroller.New(s, sch, autoscalercontroller.DefaultConfig())
+	if err := as.HandleAutoscaling(now); err != nil {
+		t.Fatalf("HandleAutoscaling() error = %v", err)
+	}
+
+	model, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	if model.DesiredReplicas() != 3 {
+		t.Errorf("DesiredReplicas() = %d, want 3 (6 jobs / target 2)", model.DesiredReplicas())
+	}
+	replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(replicas) != 3 {
+		t.Errorf("expected scheduler to place 3 replicas, got %d", len(replicas))
+	}
+
+	// The scale-up cooldown blocks a second step right away, and max_replicas caps it later.
+	reportLoad(t, s, "model-1", 20, 0, now.Add(10*time.Second))
+	_ = as.HandleAutoscaling(now.Add(10 * time.Second))
+	if model, _, _ = registrycontroller.GetModelByID(s, "model-1"); model.DesiredReplicas() != 3 {
+		t.Errorf("DesiredReplicas() during cooldown = %d, want 3", model.DesiredReplicas())
+	}
+	reportLoad(t, s, "model-1", 20, 0, now.Add(time.Minute))
+	_ = as.HandleAutoscaling(now.Add(time.Minute))
+	if model, _, _ = registrycontroller.GetModelByID(s, "model-1"); model.DesiredReplicas() != 4 {
+		t.Errorf("DesiredReplicas() after cooldown = %d, want max_replicas 4", model.DesiredReplicas())
+	}
+}
+
+func TestAutoscaler_ScaleDownWaitsForStabilizationWindow(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+	sch := setupAutoscaledModel(t, s)
+
+	cfg := autoscalercontroller.DefaultConfig()
+	cfg.ScaleDownStabilization = time.Minute
+	cfg.ScaleDownCooldown = 0
+	as := autoscalercontroller.New(s, sch, cfg)
+
+	start := time.Now()
+	reportLoad(t, s, "model-1", 8, 0, start)
+	_ = as.HandleAutoscaling(start)
+
+	// Load drops to nothing; the high recommendation from the start keeps the
+	// model at 4 replicas until it leaves the window.
+	for _, offset := range []time.Duration{20 * time.Second, 50 * time.Second} {
+		at := start.Add(offset)
+		reportLoad(t, s, "model-1", 0, 0, at)
+		_ = as.HandleAutoscaling(at)
+		if model, _, _ := registrycontroller.GetModelByID(s, "model-1"); model.DesiredReplicas() != 4 {
+			t.Fatalf("DesiredReplicas() at +%v = %d, want 4", offset, model.DesiredReplicas())
+		}
+	}
+
+	at := start.Add(90 * time.Second)
+	reportLoad(t, s, "model-1", 0, 0, at)
+	_ = as.HandleAutoscaling(at)
+	model, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	if model.DesiredReplicas() != 1 {
+		t.Errorf("DesiredReplicas() after window = %d, want min_replicas 1", model.DesiredReplicas())
+	}
+	replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(replicas) != 1 {
+		t.Errorf("expected scheduler to remove extra replicas, got %d", len(replicas))
+	}
+}
+
+func TestAutoscaler_ScalesOnLatencyAndIgnoresStaleMetrics(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+	sch := setupAutoscaledModel(t, s)
+
+	model, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	model.Autoscaling = &store.Autoscaling{MinReplicas: 1, MaxReplicas: 4, TargetP95LatencyMs: 100}
+	if err := registrycontroller.UpdateModelInfo(s, "model-1", model); err != nil {
+		t.Fatalf("UpdateModelInfo() error = %v", err)
+	}
+	as := autoscalercontroller.New(s, sch, autoscalercontroller.DefaultConfig())
+
+	// Metrics older than MetricsMaxAge are ignored.
+	now := time.Now()
+	reportLoad(t, s, "model-1", 0, 250, now.Add(-time.Hour))
+	_ = as.HandleAutoscaling(now)
+	if model, _, _ = registrycontroller.GetModelByID(s, "model-1"); model.DesiredReplicas() != 1 {
+		t.Fatalf("DesiredReplicas() with stale metrics = %d, want 1", model.DesiredReplicas())
+	}
+
+	reportLoad(t, s, "model-1", 0, 250, now)
+	_ = as.HandleAutoscaling(now)
+	if model, _, _ = registrycontroller.GetModelByID(s, "model-1"); model.DesiredReplicas() != 3 {
+		t.Errorf("DesiredReplicas() = %d, want 3 (p95 250ms / target 100ms)", model.DesiredReplicas())
+	}
+}
+
+func TestAutoscaler_IgnoresLatencyOfIdleReplicas(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+	sch := setupAutoscaledModel(t, s)
+
+	model, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	model.Autoscaling = &store.Autoscaling{MinReplicas: 1, MaxReplicas: 4, TargetP95LatencyMs: 100}
+	if err := registrycontroller.UpdateModelInfo(s, "model-1", model); err != nil {
+		t.Fatalf("UpdateModelInfo() error = %v", err)
+	}
+	as := autoscalercontroller.New(s, sch, autoscalercontroller.DefaultConfig())
+
+	// Fresh heartbeats still carry the p95 of a busy period that ended: no
+	// jobs were finished recently and none are queued or running.
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		at := now.Add(time.Duration(i) * time.Minute)
+		reportLoad(t, s, "model-1", 0, 250, at)
+		replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+		for _, r := range replicas {
+			if _, err := replicascheduler.MutateReplica(s, r.ID, func(r *store.ReplicaInfo) { r.RecentJobs = 0 }); err != nil {
+				t.Fatalf("MutateReplica() error = %v", err)
+			}
+		}
+		if err := as.HandleAutoscaling(at); err != nil {
+			t.Fatalf("HandleAutoscaling() error = %v", err)
+		}
+		if model, _, _ = registrycontroller.GetModelByID(s, "model-1"); model.DesiredReplicas() != 1 {
+			t.Fatalf("DesiredReplicas() of an idle model at +%v = %d, want 1", at.Sub(now), model.DesiredReplicas())
+		}
+	}
+}
+
+func TestAutoscaling_Validate(t *testing.T) {
+	for _, a := range []store.Autoscaling{
+		{MinReplicas: 0, MaxReplicas: 2, TargetQueueDepth: 1},
+		{MinReplicas: 3, MaxReplicas: 2, TargetQueueDepth: 1},
+		{MinReplicas: 1, MaxReplicas: 2},
+		{MinReplicas: 1, MaxReplicas: 2, TargetP95LatencyMs: -5},
+	} {
+		if err := a.Validate(); err == nil {
+			t.Errorf("Validate(%+v) error = nil, want error", a)
+		}
+	}
+
+	// DesiredReplicas keeps the autoscaler's choice within the bounds.
+	m := store.ModelInfo{Replicas: 1, ScaledReplicas: 9, Autoscaling: &store.Autoscaling{MinReplicas: 2, MaxReplicas: 5, TargetQueueDepth: 1}}
+	if got := m.DesiredReplicas(); got != 5 {
+		t.Errorf("DesiredReplicas() = %d, want 5", got)
+	}
+	m.Autoscaling = nil
+	if got := m.DesiredReplicas(); got != 1 {
+		t.Errorf("DesiredReplicas() without autoscaling = %d, want 1", got)
+	}
+}
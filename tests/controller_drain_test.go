@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	draincontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/drain"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// requireSingleReplica places the model's replicas and returns the only one.
+func requireSingleReplica(t *testing.T, s *store.Store, sch *placementscheduler.Scheduler, modelID string) store.ReplicaInfo {
+	t.Helper()
+	if err := sch.ReconcileModel(modelID); err != nil {
+		t.Fatalf("ReconcileModel() error = %v", err)
+	}
+	replicas, err := replicascheduler.ListReplicasByModelID(s, modelID)
+	if err != nil || len(replicas) != 1 {
+		t.Fatalf("ListReplicasByModelID() = %v, %v; want 1 replica", replicas, err)
+	}
+	return replicas[0]
+}
+
+func TestScheduler_SkipsCordonedNodes(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	_, port1 := startFakeDeployAgent(t, false)
+	_, port2 := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port1)
+	requireOnlineNode(t, s, "node-2", port2)
+	requireRegisterModel(t, s, "model-1", "ModelA", 2)
+	if err := registrycontroller.CordonNode(s, "node-1"); err != nil {
+		t.Fatalf("CordonNode() error = %v", err)
+	}
+
+	if err := placementscheduler.New(s).Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	for _, r := range replicas {
+		if r.NodeID == "node-1" {
+			t.Errorf("replica %s placed on cordoned node-1", r.ID)
+		}
+	}
+
+	if err := registrycontroller.UncordonNode(s, "node-1"); err != nil {
+		t.Fatalf("UncordonNode() error = %v", err)
+	}
+	node, _, _ := registrycontroller.GetNodeByID(s, "node-1")
+	if node.Unschedulable || node.Draining {
+		t.Errorf("after uncordon: unschedulable=%v draining=%v", node.Unschedulable, node.Draining)
+	}
+}
+
+func TestDrain_MigratesReplicaBeforeRemovingIt(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	_, port1 := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port1)
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+
+	sch := placementscheduler.New(s)
+	old := requireSingleReplica(t, s, sch, "model-1")
+	markReplicaStatus(t, s, old.ID, constants.ModelReplicaStatusRunning, time.Time{})
+
+	_, port2 := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-2", port2)
+	if err := registrycontroller.DrainNode(s, "node-1"); err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+
+	// First pass: a replacement comes up on node-2, the old replica stays until it runs.
+	if err := draincontroller.HandleDrain(s, sch); err != nil {
+		t.Fatalf("HandleDrain() error = %v", err)
+	}
+	replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(replicas) != 2 {
+		t.Fatalf("expected old replica plus replacement, got %d replicas", len(replicas))
+	}
+	var replacement store.ReplicaInfo
+	for _, r := range replicas {
+		if r.ID != old.ID {
+			replacement = r
+		}
+	}
+	if replacement.NodeID != "node-2" {
+		t.Fatalf("replacement on %q, want node-2", replacement.NodeID)
+	}
+
+	if err := draincontroller.HandleDrain(s, sch); err != nil {
+		t.Fatalf("HandleDrain() error = %v", err)
+	}
+	if _, found, _ := replicascheduler.GetReplicaByID(s, old.ID); !found {
+		t.Fatal("old replica removed before its replacement was running")
+	}
+
+	// Once the replacement runs the old replica is removed and the node finishes draining.
+	markReplicaStatus(t, s, replacement.ID, constants.ModelReplicaStatusRunning, time.Time{})
+	for range 2 {
+		if err := draincontroller.HandleDrain(s, sch); err != nil {
+			t.Fatalf("HandleDrain() error = %v", err)
+		}
+	}
+	if _, found, _ := replicascheduler.GetReplicaByID(s, old.ID); found {
+		t.Error("old replica still exists after replacement is running")
+	}
+	node, _, _ := registrycontroller.GetNodeByID(s, "node-1")
+	if len(node.AssignedModels) != 0 || node.Draining || !node.Unschedulable {
+		t.Errorf("node-1 after drain: assigned=%v draining=%v unschedulable=%v, want empty, false, true",
+			node.AssignedModels, node.Draining, node.Unschedulable)
+	}
+	model, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	if len(model.ReplicaIDs) != 1 || model.ReplicaIDs[0] != replacement.ID {
+		t.Errorf("model ReplicaIDs = %v, want [%s]", model.ReplicaIDs, replacement.ID)
+	}
+}
+
+func TestDrain_RespectsMinAvailableWhenNoReplacementFits(t *testing.T) {
+	for _, tc := range []struct {
+		minAvailable int
+		wantRemoved  bool
+	}{
+		{minAvailable: 1, wantRemoved: false},
+		{minAvailable: 0, wantRemoved: true},
+	} {
+		s := newTestStore(t)
+
+		_, port := startFakeDeployAgent(t, false)
+		requireOnlineNode(t, s, "node-1", port)
+		if err := registrycontroller.RegisterModel(s, "model-1", store.ModelInfo{
+			ID: "model-1", Name: "ModelA", Namespace: "default", Replicas: 1, MinAvailable: tc.minAvailable,
+		}); err != nil {
+			t.Fatalf("RegisterModel() error = %v", err)
+		}
+
+		sch := placementscheduler.New(s)
+		replica := requireSingleReplica(t, s, sch, "model-1")
+		markReplicaStatus(t, s, replica.ID, constants.ModelReplicaStatusRunning, time.Time{})
+
+		if err := registrycontroller.DrainNode(s, "node-1"); err != nil {
+			t.Fatalf("DrainNode() error = %v", err)
+		}
+		if err := draincontroller.HandleDrain(s, sch); err != nil {
+			t.Fatalf("HandleDrain() error = %v", err)
+		}
+
+		_, found, _ := replicascheduler.GetReplicaByID(s, replica.ID)
+		if removed := !found; removed != tc.wantRemoved {
+			t.Errorf("min_available=%d: removed = %v, want %v", tc.minAvailable, removed, tc.wantRemoved)
+		}
+		s.Close()
+	}
+}
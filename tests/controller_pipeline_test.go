@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/pipeline"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+func TestPipelineSpec_ValidateAndLevels(t *testing.T) {
+	diamond := pipeline.Spec{Steps: []pipeline.Step{
+		{Name: "extract", Model: "features"},
+		{Name: "price", Model: "regressor", Inputs: []pipeline.Binding{{From: "extract"}}},
+		{Name: "risk", Model: "classifier", Inputs: []pipeline.Binding{{From: "extract.embedding"}}},
+		{Name: "blend", Model: "ensemble", Inputs: []pipeline.Binding{
+			{Input: "price", From: "price"},
+			{Input: "risk", From: "risk"},
+		}},
+	}}
+	if err := diamond.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	levels, err := diamond.Levels()
+	if err != nil {
+		t.Fatalf("Levels() error = %v", err)
+	}
+	if want := [][]int{{0}, {1, 2}, {3}}; !reflect.DeepEqual(levels, want) {
+		t.Fatalf("Levels() = %v, want %v", levels, want)
+	}
+	if sinks := diamond.Sinks(); !reflect.DeepEqual(sinks, []string{"blend"}) {
+		t.Fatalf("Sinks() = %v, want [blend]", sinks)
+	}
+
+	tests := []struct {
+		name string
+		spec pipeline.Spec
+		want string
+	}{
+		{"no steps", pipeline.Spec{}, "at least one step"},
+		{"reserved name", pipeline.Spec{Steps: []pipeline.Step{{Name: "input", Model: "m"}}}, "reserved"},
+		{"duplicate name", pipeline.Spec{Steps: []pipeline.Step{{Name: "a", Model: "m"}, {Name: "a", Model: "m"}}}, "duplicate"},
+		{"no model", pipeline.Spec{Steps: []pipeline.Step{{Name: "a"}}}, "model is required"},
+		{"unknown step", pipeline.Spec{Steps: []pipeline.Step{
+			{Name: "a", Model: "m", Inputs: []pipeline.Binding{{From: "missing.out"}}},
+		}}, "unknown step"},
+		{"unnamed input among several", pipeline.Spec{Steps: []pipeline.Step{
+			{Name: "a", Model: "m", Inputs: []pipeline.Binding{{From: "input.x"}, {Input: "y", From: "input.y"}}},
+		}}, "input name is required"},
+		{"cycle", pipeline.Spec{Steps: []pipeline.Step{
+			{Name: "a", Model: "m", Inputs: []pipeline.Binding{{From: "b"}}},
+			{Name: "b", Model: "m", Inputs: []pipeline.Binding{{From: "a"}}},
+		}}, "a, b form a cycle"},
+		{"output of pipeline input", pipeline.Spec{Steps: []pipeline.Step{{Name: "a", Model: "m"}}, Outputs: []string{"input"}}, "does not name a step"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Validate() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterPipeline_ResolvesStepModels(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	requireRegisterModel(t, s, "model-det", "detector", 1)
+	requireRegisterModel(t, s, "model-cls", "classifier", 1)
+
+	info := store.PipelineInfo{
+		Name:      "detect-classify",
+		Namespace: "default",
+		Spec: pipeline.Spec{Steps: []pipeline.Step{
+			{Name: "detect", Model: "detector"},
+			{Name: "classify", ModelID: "model-cls", Inputs: []pipeline.Binding{{From: "detect.boxes"}}},
+		}},
+	}
+	if err := registrycontroller.RegisterPipeline(s, "pipe-1", info); err != nil {
+		t.Fatalf("RegisterPipeline() error = %v", err)
+	}
+
+	got, found, err := registrycontroller.GetPipelineByNamespaceAndName(s, "default", "detect-classify")
+	if err != nil || !found {
+		t.Fatalf("GetPipelineByNamespaceAndName() = found %v, error %v", found, err)
+	}
+	steps := got.Spec.Steps
+	if steps[0].ModelID != "model-det" || steps[1].Model != "classifier" {
+		t.Fatalf("steps = %+v, want model IDs and names resolved", steps)
+	}
+
+	err = registrycontroller.RegisterPipeline(s, "pipe-2", info)
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("RegisterPipeline() with a taken name error = %v, want already registered", err)
+	}
+
+	info.Name = "unknown-model"
+	info.Spec.Steps = []pipeline.Step{{Name: "detect", Model: "segmenter"}}
+	err = registrycontroller.RegisterPipeline(s, "pipe-3", info)
+	if err == nil || !strings.Contains(err.Error(), "is not registered") {
+		t.Fatalf("RegisterPipeline() with an unknown model error = %v, want is not registered", err)
+	}
+
+	if err := registrycontroller.DeRegisterPipeline(s, "pipe-1"); err != nil {
+		t.Fatalf("DeRegisterPipeline() error = %v", err)
+	}
+	if pipelines, err := registrycontroller.ListPipelines(s); err != nil || len(pipelines) != 0 {
+		t.Fatalf("ListPipelines() = %v, %v, want none left", pipelines, err)
+	}
+}
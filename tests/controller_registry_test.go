@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -93,6 +94,49 @@ func TestUpdateNodeInfo_PreservesRegisteredAt(t *testing.T) {
 	}
 }
 
+func TestUpdateNodeInfo_KeepsSchedulingFields(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	nodeID := "node-1"
+	if err := registrycontroller.RegisterNode(s, nodeID, store.NodeInfo{Name: "initial"}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+	if err := registrycontroller.AssignReplicaToNode(s, nodeID, "replica-1"); err != nil {
+		t.Fatalf("AssignReplicaToNode() error = %v", err)
+	}
+	if err := registrycontroller.SetNodeLabels(s, nodeID, map[string]string{"zone": "edge"}, nil); err != nil {
+		t.Fatalf("SetNodeLabels() error = %v", err)
+	}
+	if err := registrycontroller.DrainNode(s, nodeID); err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+
+	// The update carries what the agent read before the changes above.
+	if err := registrycontroller.UpdateNodeInfo(s, nodeID, store.NodeInfo{Name: "updated", IP: "10.0.0.2"}); err != nil {
+		t.Fatalf("UpdateNodeInfo() error = %v", err)
+	}
+	// Registering again under the same ID must not drop them either.
+	if err := registrycontroller.RegisterNode(s, nodeID, store.NodeInfo{Name: "reregistered", IP: "10.0.0.3"}); err != nil {
+		t.Fatalf("RegisterNode() again error = %v", err)
+	}
+
+	got, found, err := registrycontroller.GetNodeByID(s, nodeID)
+	if err != nil || !found {
+		t.Fatalf("GetNodeByID() error = %v, found = %v", err, found)
+	}
+	if got.Name != "reregistered" || got.IP != "10.0.0.3" {
+		t.Fatalf("node = %s at %s, want the reported name and address", got.Name, got.IP)
+	}
+	if !slices.Equal(got.AssignedModels, []string{"replica-1"}) || got.Labels["zone"] != "edge" || !got.Unschedulable || !got.Draining {
+		t.Fatalf("node = %+v, want its replicas, labels and drain kept", got)
+	}
+
+	if err := registrycontroller.UpdateNodeInfo(s, "missing-node", store.NodeInfo{Name: "missing"}); err == nil {
+		t.Fatalf("UpdateNodeInfo() of a missing node error = nil, want non-nil")
+	}
+}
+
 func TestUpdateNodeStatus_NotFound(t *testing.T) {
 	s := newTestStore(t)
 	defer s.Close()
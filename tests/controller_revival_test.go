@@ -0,0 +1,377 @@
+package tests
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	grpcagent "github.com/kennethnrk/edgernetes-ai/internal/agent/api/grpc"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
+	heartbeatpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/heartbeat"
+	heartbeatcontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/heartbeat"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	revivalcliniccontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/revivalClinic"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+func testRevivalConfig() revivalcliniccontroller.Config {
+	return revivalcliniccontroller.Config{
+		GracePeriod: 30 * time.Second,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  10 * time.Minute,
+		MaxRestarts: 3,
+		StableAfter: 10 * time.Minute,
+	}
+}
+
+// requireOfflineNode registers an offline node whose last heartbeat was lastHeartbeat.
+func requireOfflineNode(t *testing.T, s *store.Store, nodeID string, lastHeartbeat time.Time, replicaIDs []string) {
+	t.Helper()
+	if err := registrycontroller.RegisterNode(s, nodeID, store.NodeInfo{
+		IP:             "127.0.0.1",
+		Port:           1,
+		Status:         constants.StatusOffline,
+		AssignedModels: replicaIDs,
+		LastHeartbeat:  lastHeartbeat,
+	}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+}
+
+// requireBoundReplica creates a replica bound to nodeID.
+func requireBoundReplica(t *testing.T, s *store.Store, info store.ReplicaInfo) {
+	t.Helper()
+	if err := replicascheduler.CreateReplica(s, info.ID, info); err != nil {
+		t.Fatalf("CreateReplica() error = %v", err)
+	}
+}
+
+func TestRevival_ReschedulesReplicasOffDeadNode(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-live", port)
+	requireOfflineNode(t, s, "node-dead", time.Now().Add(-2*time.Minute), []string{"replica-old"})
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+	requireBoundReplica(t, s, store.ReplicaInfo{ID: "replica-old", ModelID: "model-1", NodeID: "node-dead", Status: constants.ModelReplicaStatusRunning})
+
+	sched := placementscheduler.New(s)
+	if err := revivalcliniccontroller.HandleRevival(s, sched, testRevivalConfig()); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+
+	dead, _, _ := registrycontroller.GetNodeByID(s, "node-dead")
+	if len(dead.AssignedModels) != 0 {
+		t.Errorf("dead node AssignedModels = %v, want empty", dead.AssignedModels)
+	}
+
+	// The evicted replica is kept, unbound, while node-dead may still run it.
+	replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(replicas) != 2 {
+		t.Fatalf("expected 2 replicas after rescheduling, got %d", len(replicas))
+	}
+	for _, r := range replicas {
+		if r.ID == "replica-old" {
+			if r.NodeID != "" || r.EvictedFrom != "node-dead" {
+				t.Errorf("evicted replica = %+v, want unbound and evicted from node-dead", r)
+			}
+		} else if r.NodeID != "node-live" {
+			t.Errorf("replacement replica = %+v, want it on node-live", r)
+		}
+	}
+	if len(agent.calls()) != 1 {
+		t.Errorf("expected 1 DeployModel call on the live node, got %d", len(agent.calls()))
+	}
+}
+
+// startAgentNode serves the heartbeat and deploy APIs of a on a local port and
+// returns the port.
+func startAgentNode(t *testing.T, a *agent.Agent) int {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	srv := grpc.NewServer()
+	heartbeatpb.RegisterHeartbeatAPIServer(srv, grpcagent.NewHeartbeatServer(a))
+	deploypb.RegisterDeployAPIServer(srv, grpcagent.NewDeployServer(a))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+func TestHeartbeat_UndeploysReplicasMovedOffNodeThatCameBack(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	// The agent kept running replica-old through a network partition, next to
+	// a replica deployed manually that the control plane has no record of.
+	a := &agent.Agent{ID: "node-dead", ModelDir: t.TempDir()}
+	for _, id := range []string{"replica-old", "manual-1"} {
+		if err := a.AssignModel(agent.ModelReplicaDetails{ID: id, ModelID: "model-1", Status: constants.ModelReplicaStatusRunning}); err != nil {
+			t.Fatalf("AssignModel() error = %v", err)
+		}
+	}
+	port := startAgentNode(t, a)
+	if err := registrycontroller.RegisterNode(s, "node-dead", store.NodeInfo{
+		IP:             "127.0.0.1",
+		Port:           port,
+		Status:         constants.StatusOffline,
+		AssignedModels: []string{"replica-old"},
+		LastHeartbeat:  time.Now().Add(-2 * time.Minute),
+	}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+
+	_, livePort := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-live", livePort)
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+	requireBoundReplica(t, s, store.ReplicaInfo{ID: "replica-old", ModelID: "model-1", NodeID: "node-dead", Status: constants.ModelReplicaStatusRunning})
+	sched := placementscheduler.New(s)
+	if err := revivalcliniccontroller.HandleRevival(s, sched, testRevivalConfig()); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+
+	// The partition heals and the offline node answers a heartbeat again.
+	if err := heartbeatcontroller.HandleHeartbeat(s); err != nil {
+		t.Fatalf("HandleHeartbeat() error = %v", err)
+	}
+
+	if _, ok := a.GetModelReplica("replica-old"); ok {
+		t.Error("replica-old still runs on the agent after its node came back")
+	}
+	if _, ok := a.GetModelReplica("manual-1"); !ok {
+		t.Error("manually deployed replica without a store record was undeployed")
+	}
+	node, _, _ := registrycontroller.GetNodeByID(s, "node-dead")
+	if node.Status != constants.StatusOnline {
+		t.Errorf("node status = %s, want online", node.Status)
+	}
+
+	// With the node back, the evicted replica's record is pruned.
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if _, found, _ := replicascheduler.GetReplicaByID(s, "replica-old"); found {
+		t.Error("replica-old is still in the store after its node came back")
+	}
+}
+
+func TestHeartbeat_KeepsUnrecordedReplicasOfNewNode(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	a := &agent.Agent{ID: "node-1", ModelDir: t.TempDir()}
+	if err := a.AssignModel(agent.ModelReplicaDetails{ID: "manual-1", ModelID: "model-1", Status: constants.ModelReplicaStatusRunning}); err != nil {
+		t.Fatalf("AssignModel() error = %v", err)
+	}
+	port := startAgentNode(t, a)
+	if err := registrycontroller.RegisterNode(s, "node-1", store.NodeInfo{IP: "127.0.0.1", Port: port}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+
+	if err := heartbeatcontroller.HandleHeartbeat(s); err != nil {
+		t.Fatalf("HandleHeartbeat() error = %v", err)
+	}
+	if _, ok := a.GetModelReplica("manual-1"); !ok {
+		t.Error("replica of a newly registered node was undeployed")
+	}
+}
+
+func TestDeRegisterNode_ReschedulesItsReplicas(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	_, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-live", port)
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+	if err := registrycontroller.RegisterNode(s, "node-gone", store.NodeInfo{
+		IP:             "127.0.0.1",
+		Port:           1,
+		Status:         constants.StatusOnline,
+		AssignedModels: []string{"replica-old"},
+	}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+	requireBoundReplica(t, s, store.ReplicaInfo{ID: "replica-old", ModelID: "model-1", NodeID: "node-gone", Status: constants.ModelReplicaStatusRunning})
+
+	if err := registrycontroller.DeRegisterNode(s, "node-gone"); err != nil {
+		t.Fatalf("DeRegisterNode() error = %v", err)
+	}
+	old, _, _ := replicascheduler.GetReplicaByID(s, "replica-old")
+	if old.NodeID != "" || old.Status != constants.ModelReplicaStatusFailed {
+		t.Errorf("replica of deregistered node = %+v, want unbound and failed", old)
+	}
+
+	if err := placementscheduler.New(s).Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(replicas) != 1 || replicas[0].ID == "replica-old" || replicas[0].NodeID != "node-live" {
+		t.Errorf("replicas after reconcile = %+v, want one replacement on node-live", replicas)
+	}
+}
+
+func TestRevival_KeepsFailedReplicaWhenNoNodeAvailable(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	requireOfflineNode(t, s, "node-dead", time.Now().Add(-2*time.Minute), []string{"replica-old"})
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+	requireBoundReplica(t, s, store.ReplicaInfo{ID: "replica-old", ModelID: "model-1", NodeID: "node-dead", Status: constants.ModelReplicaStatusRunning})
+
+	if err := revivalcliniccontroller.HandleRevival(s, placementscheduler.New(s), testRevivalConfig()); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+
+	replica, found, _ := replicascheduler.GetReplicaByID(s, "replica-old")
+	if !found {
+		t.Fatal("expected replica-old to be kept until a replacement is placed")
+	}
+	if replica.Status != constants.ModelReplicaStatusFailed || replica.NodeID != "" {
+		t.Errorf("replica-old = status %s node %q, want failed and unbound", replica.Status, replica.NodeID)
+	}
+}
+
+func TestRevival_RespectsGracePeriod(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	requireOfflineNode(t, s, "node-dead", time.Now().Add(-5*time.Second), []string{"replica-old"})
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+	requireBoundReplica(t, s, store.ReplicaInfo{ID: "replica-old", ModelID: "model-1", NodeID: "node-dead", Status: constants.ModelReplicaStatusRunning})
+
+	if err := revivalcliniccontroller.HandleRevival(s, placementscheduler.New(s), testRevivalConfig()); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+
+	replica, _, _ := replicascheduler.GetReplicaByID(s, "replica-old")
+	if replica.NodeID != "node-dead" || replica.Status != constants.ModelReplicaStatusRunning {
+		t.Errorf("replica-old changed within grace period: %+v", replica)
+	}
+}
+
+func TestRevival_RestartsFailedReplicaWithBackoff(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+	requireBoundReplica(t, s, store.ReplicaInfo{ID: "replica-1", ModelID: "model-1", NodeID: "node-1", Status: constants.ModelReplicaStatusFailed})
+
+	sched := placementscheduler.New(s)
+	cfg := testRevivalConfig()
+
+	// First failure: restarted right away with the same replica ID.
+	if err := revivalcliniccontroller.HandleRevival(s, sched, cfg); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+	calls := agent.calls()
+	if len(calls) != 1 || calls[0].ReplicaId != "replica-1" {
+		t.Fatalf("expected redeploy of replica-1, got %+v", calls)
+	}
+	replica, _, _ := replicascheduler.GetReplicaByID(s, "replica-1")
+	if replica.RestartCount != 1 || replica.Status != constants.ModelReplicaStatusPending {
+		t.Fatalf("after restart: RestartCount = %d, Status = %s", replica.RestartCount, replica.Status)
+	}
+
+	// Fails again immediately: the backoff holds the next restart back.
+	markReplicaStatus(t, s, "replica-1", constants.ModelReplicaStatusFailed, time.Time{})
+	if err := revivalcliniccontroller.HandleRevival(s, sched, cfg); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+	if len(agent.calls()) != 1 {
+		t.Fatalf("expected no restart within backoff, got %d calls", len(agent.calls()))
+	}
+
+	// Once the backoff has passed the replica is restarted again.
+	markReplicaStatus(t, s, "replica-1", constants.ModelReplicaStatusFailed, time.Now().Add(-cfg.BaseBackoff))
+	if err := revivalcliniccontroller.HandleRevival(s, sched, cfg); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+	if len(agent.calls()) != 2 {
+		t.Fatalf("expected a second restart after backoff, got %d calls", len(agent.calls()))
+	}
+}
+
+func TestRevival_StopsAtCrashLoopCap(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+	cfg := testRevivalConfig()
+	requireBoundReplica(t, s, store.ReplicaInfo{
+		ID:            "replica-1",
+		ModelID:       "model-1",
+		NodeID:        "node-1",
+		Status:        constants.ModelReplicaStatusFailed,
+		RestartCount:  cfg.MaxRestarts,
+		LastRestartAt: time.Now().Add(-time.Hour),
+	})
+
+	if err := revivalcliniccontroller.HandleRevival(s, placementscheduler.New(s), cfg); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+	if len(agent.calls()) != 0 {
+		t.Fatalf("expected crash-looping replica not to be restarted, got %d calls", len(agent.calls()))
+	}
+}
+
+func TestRevival_ResetsRestartCountWhenStable(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	_, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+	cfg := testRevivalConfig()
+	requireBoundReplica(t, s, store.ReplicaInfo{
+		ID:            "replica-1",
+		ModelID:       "model-1",
+		NodeID:        "node-1",
+		Status:        constants.ModelReplicaStatusRunning,
+		RestartCount:  2,
+		LastRestartAt: time.Now().Add(-cfg.StableAfter),
+	})
+
+	if err := revivalcliniccontroller.HandleRevival(s, placementscheduler.New(s), cfg); err != nil {
+		t.Fatalf("HandleRevival() error = %v", err)
+	}
+	replica, _, _ := replicascheduler.GetReplicaByID(s, "replica-1")
+	if replica.RestartCount != 0 {
+		t.Errorf("RestartCount = %d, want 0", replica.RestartCount)
+	}
+}
+
+func TestBackoff_DoublesUpToMax(t *testing.T) {
+	cfg := testRevivalConfig()
+	want := []time.Duration{0, time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 10 * time.Minute, 10 * time.Minute}
+	for n, w := range want {
+		if got := revivalcliniccontroller.Backoff(cfg, n); got != w {
+			t.Errorf("Backoff(%d) = %v, want %v", n, got, w)
+		}
+	}
+}
+
+// markReplicaStatus sets the status of a replica and, if non-zero, its LastRestartAt.
+func markReplicaStatus(t *testing.T, s *store.Store, replicaID string, status constants.ModelReplicaStatus, lastRestart time.Time) {
+	t.Helper()
+	if _, err := replicascheduler.MutateReplica(s, replicaID, func(r *store.ReplicaInfo) {
+		r.Status = status
+		if !lastRestart.IsZero() {
+			r.LastRestartAt = lastRestart
+		}
+	}); err != nil {
+		t.Fatalf("MutateReplica() error = %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
+	grpcregistry "github.com/kennethnrk/edgernetes-ai/internal/control-plane/api/grpc/registry"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	rolloutcontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/rollout"
+	statuscontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/status"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// markRevisionRunning marks every replica of the model deployed with revision as running.
+func markRevisionRunning(t *testing.T, s *store.Store, modelID string, revision int) {
+	t.Helper()
+	replicas, err := replicascheduler.ListReplicasByModelID(s, modelID)
+	if err != nil {
+		t.Fatalf("ListReplicasByModelID() error = %v", err)
+	}
+	for _, r := range replicas {
+		if r.Revision == revision {
+			markReplicaStatus(t, s, r.ID, constants.ModelReplicaStatusRunning, time.Time{})
+		}
+	}
+}
+
+// requireRolloutStatus runs a rollout pass and checks the resulting replica counts.
+func requireRolloutStatus(t *testing.T, s *store.Store, sch *placementscheduler.Scheduler, updated, old int) statuscontroller.RolloutStatusResult {
+	t.Helper()
+	if err := rolloutcontroller.HandleRollout(s, sch); err != nil {
+		t.Fatalf("HandleRollout() error = %v", err)
+	}
+	st, err := statuscontroller.GetRolloutStatus(s, "model-1")
+	if err != nil {
+		t.Fatalf("GetRolloutStatus() error = %v", err)
+	}
+	if st.UpdatedReplicas != updated || st.OldReplicas != old {
+		t.Fatalf("after rollout pass: updated=%d old=%d, want updated=%d old=%d",
+			st.UpdatedReplicas, st.OldReplicas, updated, old)
+	}
+	return st
+}
+
+func TestRollout_ReplacesReplicasOnceNewRevisionRuns(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	if err := registrycontroller.RegisterModel(s, "model-1", store.ModelInfo{
+		Name:      "ModelA",
+		Namespace: "default",
+		Version:   "v1",
+		FilePath:  "/models/a-v1.onnx",
+		Replicas:  2,
+	}); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+
+	sch := placementscheduler.New(s)
+	if err := sch.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	markRevisionRunning(t, s, "model-1", 1)
+
+	// Only the scaling fields are sent; the artifact is kept, so nothing rolls out.
+	if err := registrycontroller.UpdateModelInfo(s, "model-1", store.ModelInfo{Name: "ModelA", Namespace: "default", Replicas: 2}); err != nil {
+		t.Fatalf("UpdateModelInfo() error = %v", err)
+	}
+	model, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	if model.Revision != 1 || model.FilePath != "/models/a-v1.onnx" {
+		t.Fatalf("after update without artifact: revision=%d file=%q, want 1 and the v1 file", model.Revision, model.FilePath)
+	}
+
+	model.Version = "v2"
+	model.FilePath = "/models/a-v2.onnx"
+	if err := registrycontroller.UpdateModelInfo(s, "model-1", model); err != nil {
+		t.Fatalf("UpdateModelInfo() error = %v", err)
+	}
+	model, _, _ = registrycontroller.GetModelByID(s, "model-1")
+	if model.Revision != 2 || len(model.Revisions) != 2 {
+		t.Fatalf("after artifact change: revision=%d history=%d, want 2 and 2", model.Revision, len(model.Revisions))
+	}
+
+	// One new replica is surged; no old replica goes before it runs, and the
+	// scheduler does not scale the surge back down.
+	requireRolloutStatus(t, s, sch, 1, 2)
+	if err := sch.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	requireRolloutStatus(t, s, sch, 1, 2)
+
+	calls := agent.calls()
+	if last := calls[len(calls)-1]; last.FilePath != "/models/a-v2.onnx" || last.Version != "v2" {
+		t.Errorf("surged replica deployed %s %s, want v2 /models/a-v2.onnx", last.Version, last.FilePath)
+	}
+
+	markRevisionRunning(t, s, "model-1", 2)
+	requireRolloutStatus(t, s, sch, 1, 1)
+	requireRolloutStatus(t, s, sch, 2, 1)
+	markRevisionRunning(t, s, "model-1", 2)
+	st := requireRolloutStatus(t, s, sch, 2, 0)
+	if !st.Complete() {
+		t.Errorf("rollout not complete: %+v", st)
+	}
+	if got := len(agent.undeploys()); got != 2 {
+		t.Errorf("undeployed %d old replicas, want 2", got)
+	}
+}
+
+func TestUpdateModel_FilePathOnlyStartsRollout(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	tolerations := []store.Toleration{{Key: "power", Operator: store.TolerationOpExists}}
+	if err := registrycontroller.RegisterModel(s, "model-1", store.ModelInfo{
+		Name:         "ModelA",
+		Namespace:    "default",
+		Version:      "v1",
+		FilePath:     "/models/a-v1.onnx",
+		Replicas:     2,
+		MinAvailable: 1,
+		Tolerations:  tolerations,
+	}); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+
+	sch := placementscheduler.New(s)
+	if err := sch.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	markRevisionRunning(t, s, "model-1", 1)
+
+	// What `edgectl model update --file-path` sends: every other field is unset.
+	srv := grpcregistry.NewModelRegistryServer(s)
+	if _, err := srv.UpdateModel(context.Background(), &modelpb.UpdateModelRequest{
+		Id:       "model-1",
+		FilePath: "/models/a-v2.onnx",
+	}); err != nil {
+		t.Fatalf("UpdateModel() error = %v", err)
+	}
+	model, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	if model.Name != "ModelA" || model.Namespace != "default" || model.Version != "v1" {
+		t.Errorf("after update: name=%q namespace=%q version=%q, want the stored values", model.Name, model.Namespace, model.Version)
+	}
+	if model.Replicas != 2 || model.DesiredReplicas() != 2 || model.MinAvailable != 1 {
+		t.Errorf("after update: replicas=%d desired=%d minAvailable=%d, want 2, 2 and 1",
+			model.Replicas, model.DesiredReplicas(), model.MinAvailable)
+	}
+	if len(model.Tolerations) != 1 || model.Tolerations[0] != tolerations[0] {
+		t.Errorf("after update: tolerations = %+v, want %+v", model.Tolerations, tolerations)
+	}
+	if model.Revision != 2 || model.FilePath != "/models/a-v2.onnx" {
+		t.Fatalf("after update: revision=%d file=%q, want 2 and the new file", model.Revision, model.FilePath)
+	}
+
+	if err := sch.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	requireRolloutStatus(t, s, sch, 1, 2)
+	if got := len(agent.undeploys()); got != 0 {
+		t.Errorf("undeployed %d replicas before the new revision runs, want 0", got)
+	}
+}
+
+func TestRollbackModel_RecordsNewRevision(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	model := store.ModelInfo{Name: "ModelA", Namespace: "default", Version: "v1", FilePath: "/models/v1"}
+	if err := registrycontroller.RegisterModel(s, "model-1", model); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+	if _, err := registrycontroller.RollbackModel(s, "model-1", 0); err == nil {
+		t.Error("RollbackModel() without a previous revision succeeded")
+	}
+	for _, v := range []string{"v2", "v3"} {
+		model.Version, model.FilePath = v, "/models/"+v
+		if err := registrycontroller.UpdateModelInfo(s, "model-1", model); err != nil {
+			t.Fatalf("UpdateModelInfo() error = %v", err)
+		}
+	}
+
+	revision, err := registrycontroller.RollbackModel(s, "model-1", 0)
+	if err != nil {
+		t.Fatalf("RollbackModel() error = %v", err)
+	}
+	got, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	if revision != 4 || got.Revision != 4 || got.Version != "v2" || got.FilePath != "/models/v2" {
+		t.Fatalf("after undo: revision=%d/%d version=%q file=%q, want 4 with the v2 artifact",
+			revision, got.Revision, got.Version, got.FilePath)
+	}
+	if cause := got.Revisions[len(got.Revisions)-1].ChangeCause; cause != "rollback to revision 2" {
+		t.Errorf("change cause = %q", cause)
+	}
+
+	if _, err := registrycontroller.RollbackModel(s, "model-1", 1); err != nil {
+		t.Fatalf("RollbackModel(1) error = %v", err)
+	}
+	if got, _, _ := registrycontroller.GetModelByID(s, "model-1"); got.Version != "v1" || got.Revision != 5 {
+		t.Errorf("after undo to 1: version=%q revision=%d, want v1 and 5", got.Version, got.Revision)
+	}
+	if _, err := registrycontroller.RollbackModel(s, "model-1", 42); err == nil {
+		t.Error("RollbackModel() to an unknown revision succeeded")
+	}
+}
+
+func TestRolloutStrategy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *store.RolloutStrategy
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"surge only", &store.RolloutStrategy{MaxSurge: 2}, false},
+		{"unavailable only", &store.RolloutStrategy{MaxUnavailable: 1}, false},
+		{"both zero", &store.RolloutStrategy{}, true},
+		{"negative", &store.RolloutStrategy{MaxSurge: -1, MaxUnavailable: 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -353,6 +353,47 @@ func TestModelTransfer_ModelNotInRegistry(t *testing.T) {
 	}
 }
 
+// TestModelTransfer_ServesRequestedRevision verifies that a download for an
+// earlier revision streams that revision's file rather than the current one.
+func TestModelTransfer_ServesRequestedRevision(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	v1Path, v1Hash := writeTempModelFile(t, 1024)
+	v2Path, v2Hash := writeTempModelFile(t, 2048)
+	if err := registrycontroller.RegisterModel(s, "model-1", store.ModelInfo{Name: "model", FilePath: v1Path}); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+	if err := registrycontroller.UpdateModelInfo(s, "model-1", store.ModelInfo{FilePath: v2Path}); err != nil {
+		t.Fatalf("UpdateModelInfo() error = %v", err)
+	}
+
+	client := newModelTransferClient(t, s)
+	for _, tc := range []struct {
+		revision int32
+		want     string
+	}{{0, v2Hash}, {1, v1Hash}, {2, v2Hash}} {
+		stream, err := client.DownloadModel(context.Background(), &deploypb.ModelDownloadRequest{
+			ModelId:  "model-1",
+			Revision: tc.revision,
+		})
+		if err != nil {
+			t.Fatalf("DownloadModel(revision %d) error = %v", tc.revision, err)
+		}
+		if got := fmt.Sprintf("%x", sha256.Sum256(receiveAllChunks(t, stream))); got != tc.want {
+			t.Errorf("revision %d: received sha256 %s, want %s", tc.revision, got, tc.want)
+		}
+	}
+
+	stream, err := client.DownloadModel(context.Background(), &deploypb.ModelDownloadRequest{ModelId: "model-1", Revision: 7})
+	if err != nil {
+		t.Fatalf("DownloadModel(revision 7) unexpected dial error = %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown revision: error = %v, want NotFound", err)
+	}
+}
+
 // TestModelTransfer_FileDeletedAfterRegistration verifies that if a model's
 // file is missing from disk (e.g., manually deleted after registration),
 // the server returns an Internal error instead of panicking or blocking.
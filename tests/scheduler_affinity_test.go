@@ -0,0 +1,329 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/agent"
+	"github.com/kennethnrk/edgernetes-ai/internal/client"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	frameworkscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/framework"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+// labeledNode builds a node with ample resources and the given labels.
+func labeledNode(id string, labels map[string]string) store.NodeInfo {
+	node := testNode(id, 4096, 10000)
+	node.Labels = labels
+	return node
+}
+
+func TestFramework_NodeSelector(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	state := newState(store.ModelInfo{ID: "m", NodeSelector: map[string]string{"zone": "lab"}})
+
+	nodes := []store.NodeInfo{
+		labeledNode("a", map[string]string{"zone": "office"}),
+		labeledNode("b", map[string]string{"zone": "lab"}),
+		labeledNode("c", nil),
+	}
+	node, err := fw.SelectNode(state, nodes)
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "b" {
+		t.Errorf("SelectNode() = %s, want b", node.ID)
+	}
+
+	_, err = fw.SelectNode(state, nodes[:1])
+	if err == nil || !strings.Contains(err.Error(), "a: nodeselector: node does not match selector zone=lab") {
+		t.Errorf("SelectNode() error = %v, want selector mismatch", err)
+	}
+}
+
+func TestFramework_RequiredNodeAffinity(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	state := newState(store.ModelInfo{ID: "m", Affinity: &store.Affinity{
+		NodeAffinity: &store.NodeAffinity{
+			Required: []store.NodeSelectorTerm{
+				{MatchExpressions: []store.LabelExpression{
+					{Key: "zone", Operator: store.LabelOpIn, Values: []string{"lab", "factory"}},
+					{Key: "maintenance", Operator: store.LabelOpDoesNotExist},
+				}},
+				{MatchExpressions: []store.LabelExpression{
+					{Key: "gpu", Operator: store.LabelOpExists},
+				}},
+			},
+		},
+	}})
+
+	for _, tc := range []struct {
+		labels map[string]string
+		want   bool
+	}{
+		{map[string]string{"zone": "lab"}, true},
+		{map[string]string{"zone": "factory", "maintenance": "true"}, false},
+		{map[string]string{"zone": "office"}, false},
+		{map[string]string{"zone": "office", "gpu": "jetson"}, true},
+		{nil, false},
+	} {
+		_, err := fw.SelectNode(state, []store.NodeInfo{labeledNode("n", tc.labels)})
+		if got := err == nil; got != tc.want {
+			t.Errorf("labels %v: schedulable = %v, want %v (err = %v)", tc.labels, got, tc.want, err)
+		}
+	}
+}
+
+func TestFramework_PreferredNodeAffinity(t *testing.T) {
+	fw, err := frameworkscheduler.Parse("nodeaffinity", "nodeaffinity")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	state := newState(store.ModelInfo{ID: "m", Affinity: &store.Affinity{
+		NodeAffinity: &store.NodeAffinity{
+			Preferred: []store.WeightedNodeSelector{
+				{Weight: 10, Term: store.NodeSelectorTerm{MatchExpressions: []store.LabelExpression{
+					{Key: "zone", Operator: store.LabelOpNotIn, Values: []string{"office"}},
+				}}},
+				{Weight: 50, Term: store.NodeSelectorTerm{MatchExpressions: []store.LabelExpression{
+					{Key: "camera", Operator: store.LabelOpIn, Values: []string{"true"}},
+				}}},
+			},
+		},
+	}})
+
+	node, err := fw.SelectNode(state, []store.NodeInfo{
+		labeledNode("a", map[string]string{"zone": "lab"}),
+		labeledNode("b", map[string]string{"zone": "office", "camera": "true"}),
+	})
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "b" {
+		t.Errorf("SelectNode() = %s, want b (heavier preferred term)", node.ID)
+	}
+}
+
+func TestFramework_ModelAffinityAndAntiAffinity(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	nodes := []store.NodeInfo{labeledNode("a", nil), labeledNode("b", nil)}
+
+	affine := newState(store.ModelInfo{ID: "m", Affinity: &store.Affinity{
+		ModelAffinity: &store.ModelAffinity{Required: []string{"detector"}},
+	}})
+	affine.NodeModelNames = map[string]map[string]int{"b": {"detector": 1}}
+	node, err := fw.SelectNode(affine, nodes)
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "b" {
+		t.Errorf("affinity: SelectNode() = %s, want b", node.ID)
+	}
+
+	anti := newState(store.ModelInfo{ID: "m", Affinity: &store.Affinity{
+		ModelAntiAffinity: &store.ModelAffinity{Required: []string{"detector"}},
+	}})
+	anti.NodeModelNames = map[string]map[string]int{"a": {"detector": 2}}
+	node, err = fw.SelectNode(anti, nodes)
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "b" {
+		t.Errorf("anti-affinity: SelectNode() = %s, want b", node.ID)
+	}
+
+	anti.NodeModelNames["b"] = map[string]int{"detector": 1}
+	if _, err := fw.SelectNode(anti, nodes); err == nil {
+		t.Error("SelectNode() error = nil, want every node rejected by anti-affinity")
+	}
+}
+
+func TestAffinity_Validate(t *testing.T) {
+	term := func(exprs ...store.LabelExpression) store.NodeSelectorTerm {
+		return store.NodeSelectorTerm{MatchExpressions: exprs}
+	}
+	valid := &store.Affinity{NodeAffinity: &store.NodeAffinity{
+		Required: []store.NodeSelectorTerm{term(store.LabelExpression{Key: "zone", Operator: store.LabelOpIn, Values: []string{"lab"}})},
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := (*store.Affinity)(nil).Validate(); err != nil {
+		t.Errorf("nil Validate() error = %v", err)
+	}
+
+	for name, a := range map[string]*store.Affinity{
+		"unknown operator": {NodeAffinity: &store.NodeAffinity{Required: []store.NodeSelectorTerm{
+			term(store.LabelExpression{Key: "zone", Operator: "Gt", Values: []string{"1"}}),
+		}}},
+		"In without values": {NodeAffinity: &store.NodeAffinity{Required: []store.NodeSelectorTerm{
+			term(store.LabelExpression{Key: "zone", Operator: store.LabelOpIn}),
+		}}},
+		"Exists with values": {NodeAffinity: &store.NodeAffinity{Required: []store.NodeSelectorTerm{
+			term(store.LabelExpression{Key: "zone", Operator: store.LabelOpExists, Values: []string{"lab"}}),
+		}}},
+		"empty term": {NodeAffinity: &store.NodeAffinity{Required: []store.NodeSelectorTerm{term()}}},
+		"zero weight": {ModelAntiAffinity: &store.ModelAffinity{Preferred: []store.WeightedModelSelector{
+			{Weight: 0, Models: []string{"detector"}},
+		}}},
+	} {
+		if err := a.Validate(); err == nil {
+			t.Errorf("%s: Validate() error = nil, want error", name)
+		}
+	}
+}
+
+func TestScheduler_HonorsModelAntiAffinity(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	_, port1 := startFakeDeployAgent(t, false)
+	_, port2 := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port1)
+	requireOnlineNode(t, s, "node-2", port2)
+	requireRegisterModel(t, s, "model-a", "detector", 1)
+
+	sched := placementscheduler.New(s)
+	if err := sched.ReconcileModel("model-a"); err != nil {
+		t.Fatalf("ReconcileModel() error = %v", err)
+	}
+	detector, _ := replicascheduler.ListReplicasByModelID(s, "model-a")
+	if len(detector) != 1 {
+		t.Fatalf("expected 1 detector replica, got %d", len(detector))
+	}
+
+	if err := registrycontroller.RegisterModel(s, "model-b", store.ModelInfo{
+		ID:        "model-b",
+		Name:      "classifier",
+		Namespace: "default",
+		Replicas:  1,
+		Affinity: &store.Affinity{
+			ModelAntiAffinity: &store.ModelAffinity{Required: []string{"detector"}},
+		},
+	}); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+	if err := sched.ReconcileModel("model-b"); err != nil {
+		t.Fatalf("ReconcileModel() error = %v", err)
+	}
+	classifier, _ := replicascheduler.ListReplicasByModelID(s, "model-b")
+	if len(classifier) != 1 {
+		t.Fatalf("expected 1 classifier replica, got %d", len(classifier))
+	}
+	if classifier[0].NodeID == detector[0].NodeID {
+		t.Errorf("classifier placed on %s next to detector, want the other node", classifier[0].NodeID)
+	}
+}
+
+func TestSetNodeLabels(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	if err := registrycontroller.RegisterNode(s, "node-1", store.NodeInfo{
+		Labels: map[string]string{"zone": "lab", "legacy": "true"},
+	}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+	if err := registrycontroller.SetNodeLabels(s, "node-1", map[string]string{"zone": "factory", "camera": "true"}, []string{"legacy"}); err != nil {
+		t.Fatalf("SetNodeLabels() error = %v", err)
+	}
+
+	node, _, err := registrycontroller.GetNodeByID(s, "node-1")
+	if err != nil {
+		t.Fatalf("GetNodeByID() error = %v", err)
+	}
+	if got := client.FormatLabels(node.Labels); got != "camera=true,zone=factory" {
+		t.Errorf("labels = %s, want camera=true,zone=factory", got)
+	}
+
+	if err := registrycontroller.SetNodeLabels(s, "missing", map[string]string{"a": "b"}, nil); err == nil {
+		t.Error("SetNodeLabels() on unknown node error = nil, want error")
+	}
+}
+
+func TestParseLabelArgs(t *testing.T) {
+	set, remove, err := client.ParseLabelArgs([]string{"zone=lab", "empty=", "legacy-"})
+	if err != nil {
+		t.Fatalf("ParseLabelArgs() error = %v", err)
+	}
+	if got := client.FormatLabels(set); got != "empty=,zone=lab" {
+		t.Errorf("set = %s", got)
+	}
+	if len(remove) != 1 || remove[0] != "legacy" {
+		t.Errorf("remove = %v, want [legacy]", remove)
+	}
+
+	for _, arg := range []string{"zone", "=lab", "-"} {
+		if _, _, err := client.ParseLabelArgs([]string{arg}); err == nil {
+			t.Errorf("ParseLabelArgs(%q) error = nil, want error", arg)
+		}
+	}
+}
+
+func TestAgentParseLabels(t *testing.T) {
+	labels, err := agent.ParseLabels(" zone=lab, camera=true ,")
+	if err != nil {
+		t.Fatalf("ParseLabels() error = %v", err)
+	}
+	if got := client.FormatLabels(labels); got != "camera=true,zone=lab" {
+		t.Errorf("ParseLabels() = %s", got)
+	}
+	if _, err := agent.ParseLabels("zone"); err == nil {
+		t.Error("ParseLabels(\"zone\") error = nil, want error")
+	}
+}
+
+func TestParseManifest_Affinity(t *testing.T) {
+	manifest := `apiVersion: edgernetes.ai/v1
+kind: ModelManifest
+models:
+  - name: classifier
+    node_selector:
+      zone: lab
+    affinity:
+      node_affinity:
+        preferred:
+          - weight: 20
+            term:
+              match_expressions:
+                - key: edgernetes.ai/arch
+                  operator: In
+                  values: [arm64]
+      model_anti_affinity:
+        required: [detector]
+`
+	path := filepath.Join(t.TempDir(), "models.yaml")
+	if err := os.WriteFile(path, []byte(manifest), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	m, err := client.ParseManifest(path)
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	spec := m.Models[0]
+	if spec.NodeSelector["zone"] != "lab" {
+		t.Errorf("NodeSelector = %v", spec.NodeSelector)
+	}
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || len(spec.Affinity.NodeAffinity.Preferred) != 1 {
+		t.Fatalf("Affinity = %+v", spec.Affinity)
+	}
+	if got := spec.Affinity.NodeAffinity.Preferred[0].Term.MatchExpressions[0].Key; got != "edgernetes.ai/arch" {
+		t.Errorf("preferred key = %s", got)
+	}
+	if got := spec.Affinity.ModelAntiAffinity.Required; len(got) != 1 || got[0] != "detector" {
+		t.Errorf("model_anti_affinity.required = %v", got)
+	}
+
+	bad := strings.Replace(manifest, "operator: In", "operator: Near", 1)
+	if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := client.ParseManifest(path); err == nil || !strings.Contains(err.Error(), "unknown operator") {
+		t.Errorf("ParseManifest() error = %v, want unknown operator", err)
+	}
+}
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	frameworkscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/framework"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+const mb = 1024 * 1024
+
+// testNode builds a node with the given free memory/storage (MB) and compute devices.
+func testNode(id string, freeMemMB, freeDiskMB int64, devices ...store.ComputeDevice) store.NodeInfo {
+	return store.NodeInfo{
+		ID: id,
+		ResourceCapabilities: store.ResourceCapabilities{
+			Memory:         store.MemoryInfo{Free: freeMemMB},
+			Storage:        store.StorageInfo{Free: freeDiskMB},
+			ComputeDevices: devices,
+		},
+	}
+}
+
+func newState(model store.ModelInfo) *frameworkscheduler.State {
+	return &frameworkscheduler.State{
+		Model:          model,
+		ModelReplicas:  map[string]int{},
+		AllocatedBytes: map[string]int64{},
+	}
+}
+
+func TestFramework_MemoryFitFiltersSmallNodes(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	state := newState(store.ModelInfo{ID: "m", ModelSize: 512 * mb})
+
+	nodes := []store.NodeInfo{
+		testNode("small", 256, 10000),
+		testNode("large", 4096, 10000),
+	}
+	node, err := fw.SelectNode(state, nodes)
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "large" {
+		t.Errorf("SelectNode() = %s, want large", node.ID)
+	}
+}
+
+func TestFramework_AccountsForAllocatedReplicas(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	state := newState(store.ModelInfo{ID: "m", ModelSize: 512 * mb})
+	// node-1 reports 1 GB free but already hosts 768 MB of models.
+	state.AllocatedBytes["node-1"] = 768 * mb
+
+	_, err := fw.SelectNode(state, []store.NodeInfo{testNode("node-1", 1024, 10000)})
+	if err == nil {
+		t.Fatal("SelectNode() error = nil, want insufficient memory")
+	}
+	if !strings.Contains(err.Error(), "node-1: memory: insufficient memory") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFramework_StorageFit(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	state := newState(store.ModelInfo{ID: "m", ModelSize: 100 * mb})
+
+	_, err := fw.SelectNode(state, []store.NodeInfo{testNode("node-1", 4096, 50)})
+	if err == nil || !strings.Contains(err.Error(), "insufficient storage") {
+		t.Fatalf("SelectNode() error = %v, want insufficient storage", err)
+	}
+}
+
+func TestFramework_PrefersAcceleratorForVisionModels(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	gpu := store.ComputeDevice{Type: constants.ComputeDeviceGPU, TOPS: 1, IsAvailable: true}
+	cpu := store.ComputeDevice{Type: constants.ComputeDeviceCPU, TOPS: 3, IsAvailable: true}
+	nodes := []store.NodeInfo{
+		testNode("a-cpu", 4096, 10000, cpu),
+		testNode("b-gpu", 4096, 10000, gpu),
+	}
+
+	node, err := fw.SelectNode(newState(store.ModelInfo{ID: "m", ModelType: constants.ModelTypeCNN}), nodes)
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "b-gpu" {
+		t.Errorf("cnn placed on %s, want b-gpu", node.ID)
+	}
+
+	// Without the accelerator preference the CPU node wins on TOPS.
+	node, err = fw.SelectNode(newState(store.ModelInfo{ID: "m", ModelType: constants.ModelTypeLinear}), nodes)
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "a-cpu" {
+		t.Errorf("linear placed on %s, want a-cpu", node.ID)
+	}
+}
+
+func TestFramework_SpreadsReplicasOfSameModel(t *testing.T) {
+	fw, err := frameworkscheduler.Parse("", "spread")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	state := newState(store.ModelInfo{ID: "m"})
+	state.ModelReplicas["node-a"] = 1
+
+	node, err := fw.SelectNode(state, []store.NodeInfo{testNode("node-a", 0, 0), testNode("node-b", 0, 0)})
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "node-b" {
+		t.Errorf("SelectNode() = %s, want node-b", node.ID)
+	}
+}
+
+func TestFramework_Parse(t *testing.T) {
+	fw, err := frameworkscheduler.Parse("memory, storage", "spread:3,tops")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := strings.Join(fw.Filters(), ","); got != "memory,storage" {
+		t.Errorf("Filters() = %s", got)
+	}
+	if got := strings.Join(fw.Scorers(), ","); got != "spread:3,tops:1" {
+		t.Errorf("Scorers() = %s", got)
+	}
+
+	for _, tc := range []struct{ filters, scorers string }{
+		{"gpu", ""},
+		{"", "unknown:1"},
+		{"", "spread:-1"},
+		{"", "spread:x"},
+	} {
+		if _, err := frameworkscheduler.Parse(tc.filters, tc.scorers); err == nil {
+			t.Errorf("Parse(%q, %q) error = nil, want error", tc.filters, tc.scorers)
+		}
+	}
+}
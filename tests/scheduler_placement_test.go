@@ -0,0 +1,331 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	deploypb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/deploy"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/preprocess"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+	"google.golang.org/grpc"
+)
+
+// fakeDeployAgent records DeployModel and UndeployModel calls and answers
+// deployments with a fixed outcome.
+type fakeDeployAgent struct {
+	deploypb.UnimplementedDeployAPIServer
+
+	mu         sync.Mutex
+	requests   []*deploypb.DeployModelRequest
+	undeployed []string
+	reject     bool
+}
+
+func (f *fakeDeployAgent) DeployModel(ctx context.Context, req *deploypb.DeployModelRequest) (*deploypb.DeployModelResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	if f.reject {
+		return &deploypb.DeployModelResponse{Success: false, Message: "rejected"}, nil
+	}
+	return &deploypb.DeployModelResponse{Success: true, ReplicaId: req.ReplicaId}, nil
+}
+
+func (f *fakeDeployAgent) UndeployModel(ctx context.Context, req *deploypb.UndeployModelRequest) (*deploypb.UndeployModelResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.undeployed = append(f.undeployed, req.ReplicaId)
+	return &deploypb.UndeployModelResponse{Success: true}, nil
+}
+
+func (f *fakeDeployAgent) undeploys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.undeployed...)
+}
+
+func (f *fakeDeployAgent) calls() []*deploypb.DeployModelRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*deploypb.DeployModelRequest(nil), f.requests...)
+}
+
+// startFakeDeployAgent serves a fakeDeployAgent on a loopback TCP port and
+// returns it together with the port it listens on.
+func startFakeDeployAgent(t *testing.T, reject bool) (*fakeDeployAgent, int) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	agent := &fakeDeployAgent{reject: reject}
+	srv := grpc.NewServer()
+	deploypb.RegisterDeployAPIServer(srv, agent)
+
+	go func() {
+		if err := srv.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			t.Logf("grpc server error: %v", err)
+		}
+	}()
+	t.Cleanup(srv.Stop)
+
+	return agent, lis.Addr().(*net.TCPAddr).Port
+}
+
+// requireOnlineNode registers an online node reachable at 127.0.0.1:port.
+func requireOnlineNode(t *testing.T, s *store.Store, nodeID string, port int) {
+	t.Helper()
+	if err := registrycontroller.RegisterNode(s, nodeID, store.NodeInfo{
+		Name:   nodeID,
+		IP:     "127.0.0.1",
+		Port:   port,
+		Status: constants.StatusOnline,
+	}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+}
+
+func TestScheduler_PlacesMissingReplicas(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent1, port1 := startFakeDeployAgent(t, false)
+	agent2, port2 := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port1)
+	requireOnlineNode(t, s, "node-2", port2)
+	requireRegisterModel(t, s, "model-1", "ModelA", 2)
+
+	sched := placementscheduler.New(s)
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	replicas, err := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if err != nil {
+		t.Fatalf("ListReplicasByModelID() error = %v", err)
+	}
+	if len(replicas) != 2 {
+		t.Fatalf("expected 2 replicas, got %d", len(replicas))
+	}
+
+	// Replicas are spread across both nodes and each node knows its replica.
+	nodesUsed := make(map[string]bool)
+	for _, r := range replicas {
+		if r.Status != constants.ModelReplicaStatusPending {
+			t.Errorf("replica %s status = %s, want pending", r.ID, r.Status)
+		}
+		nodesUsed[r.NodeID] = true
+
+		node, found, err := registrycontroller.GetNodeByID(s, r.NodeID)
+		if err != nil || !found {
+			t.Fatalf("GetNodeByID(%q) error = %v, found = %v", r.NodeID, err, found)
+		}
+		if len(node.AssignedModels) != 1 || node.AssignedModels[0] != r.ID {
+			t.Errorf("node %s AssignedModels = %v, want [%s]", node.ID, node.AssignedModels, r.ID)
+		}
+	}
+	if len(nodesUsed) != 2 {
+		t.Errorf("expected replicas on 2 distinct nodes, got %v", nodesUsed)
+	}
+
+	for _, agent := range []*fakeDeployAgent{agent1, agent2} {
+		calls := agent.calls()
+		if len(calls) != 1 {
+			t.Fatalf("expected 1 DeployModel call per agent, got %d", len(calls))
+		}
+		if calls[0].ModelId != "model-1" || calls[0].ReplicaId == "" {
+			t.Errorf("unexpected DeployModel request: %+v", calls[0])
+		}
+	}
+
+	model, _, err := registrycontroller.GetModelByID(s, "model-1")
+	if err != nil {
+		t.Fatalf("GetModelByID() error = %v", err)
+	}
+	if len(model.ReplicaIDs) != 2 {
+		t.Errorf("model ReplicaIDs = %v, want 2 entries", model.ReplicaIDs)
+	}
+
+	// A second pass must not create additional replicas.
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("second Reconcile() error = %v", err)
+	}
+	replicas, _ = replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(replicas) != 2 {
+		t.Fatalf("expected still 2 replicas after second pass, got %d", len(replicas))
+	}
+}
+
+func TestScheduler_RollsBackRejectedDeployment(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, true)
+	requireOnlineNode(t, s, "node-1", port)
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+
+	sched := placementscheduler.New(s)
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if len(agent.calls()) != 1 {
+		t.Fatalf("expected 1 DeployModel call, got %d", len(agent.calls()))
+	}
+
+	replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(replicas) != 0 {
+		t.Fatalf("expected rejected replica to be removed, got %d replicas", len(replicas))
+	}
+
+	node, _, _ := registrycontroller.GetNodeByID(s, "node-1")
+	if len(node.AssignedModels) != 0 {
+		t.Errorf("expected node AssignedModels to be empty, got %v", node.AssignedModels)
+	}
+}
+
+func TestScheduler_SkipsOfflineNodes(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	if err := registrycontroller.RegisterNode(s, "node-1", store.NodeInfo{
+		IP:     "127.0.0.1",
+		Port:   port,
+		Status: constants.StatusOffline,
+	}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+	requireRegisterModel(t, s, "model-1", "ModelA", 1)
+
+	if err := placementscheduler.New(s).Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if len(agent.calls()) != 0 {
+		t.Fatalf("expected no DeployModel calls to an offline node, got %d", len(agent.calls()))
+	}
+}
+
+func TestScheduler_ScaleDownPrefersCordonedNodes(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent1, port1 := startFakeDeployAgent(t, false)
+	agent2, port2 := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port1)
+	requireOnlineNode(t, s, "node-2", port2)
+	requireRegisterModel(t, s, "model-1", "ModelA", 2)
+
+	sched := placementscheduler.New(s)
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := registrycontroller.CordonNode(s, "node-2"); err != nil {
+		t.Fatalf("CordonNode() error = %v", err)
+	}
+
+	model, _, _ := registrycontroller.GetModelByID(s, "model-1")
+	model.Replicas = 1
+	if err := registrycontroller.UpdateModelInfo(s, "model-1", model); err != nil {
+		t.Fatalf("UpdateModelInfo() error = %v", err)
+	}
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	replicas, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(replicas) != 1 || replicas[0].NodeID != "node-1" {
+		t.Fatalf("replicas after scale down = %+v, want one on node-1", replicas)
+	}
+	if got := agent2.undeploys(); len(got) != 1 {
+		t.Errorf("node-2 UndeployModel calls = %v, want 1", got)
+	}
+	if got := agent1.undeploys(); len(got) != 0 {
+		t.Errorf("node-1 UndeployModel calls = %v, want none", got)
+	}
+	node, _, _ := registrycontroller.GetNodeByID(s, "node-2")
+	if len(node.AssignedModels) != 0 {
+		t.Errorf("node-2 AssignedModels = %v, want empty", node.AssignedModels)
+	}
+}
+
+func TestScheduler_UndeploysReplicasOfDeregisteredModel(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	requireRegisterModel(t, s, "model-1", "ModelA", 2)
+
+	sched := placementscheduler.New(s)
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := registrycontroller.DeRegisterModel(s, "model-1"); err != nil {
+		t.Fatalf("DeRegisterModel() error = %v", err)
+	}
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if got := agent.undeploys(); len(got) != 2 {
+		t.Errorf("UndeployModel calls = %v, want 2", got)
+	}
+	replicas, _ := replicascheduler.ListReplicas(s)
+	if len(replicas) != 0 {
+		t.Errorf("expected replicas to be deleted, got %d", len(replicas))
+	}
+	node, _, _ := registrycontroller.GetNodeByID(s, "node-1")
+	if len(node.AssignedModels) != 0 {
+		t.Errorf("node AssignedModels = %v, want empty", node.AssignedModels)
+	}
+}
+
+func TestScheduler_ShipsModelSettingsInDeployRequest(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	prep := &preprocess.Spec{
+		Features: []preprocess.Feature{{Type: preprocess.OneHot, Categories: []float64{1, 2}}},
+		Target:   &preprocess.Target{Type: preprocess.MinMax, Min: 0, Max: 100},
+	}
+	if err := registrycontroller.RegisterModel(s, "model-1", store.ModelInfo{
+		Name:          "ModelA",
+		Namespace:     "default",
+		Replicas:      1,
+		Batching:      &store.Batching{MaxBatchSize: 8, MaxBatchWaitMs: 10},
+		Preprocessing: prep,
+	}); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+
+	if err := placementscheduler.New(s).Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	calls := agent.calls()
+	if len(calls) != 1 {
+		t.Fatalf("deploy calls = %d, want 1", len(calls))
+	}
+	if calls[0].MaxBatchSize != 8 || calls[0].MaxBatchWaitMs != 10 {
+		t.Errorf("batching = %d rows / %d ms, want 8 / 10", calls[0].MaxBatchSize, calls[0].MaxBatchWaitMs)
+	}
+	got, err := preprocess.Parse(calls[0].Preprocessing)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, prep) {
+		t.Errorf("shipped preprocessing = %+v, want %+v", got, prep)
+	}
+}
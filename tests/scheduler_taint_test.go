@@ -0,0 +1,271 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kennethnrk/edgernetes-ai/internal/client"
+	"github.com/kennethnrk/edgernetes-ai/internal/common/constants"
+	modelpb "github.com/kennethnrk/edgernetes-ai/internal/common/pb/model"
+	grpcregistry "github.com/kennethnrk/edgernetes-ai/internal/control-plane/api/grpc/registry"
+	registrycontroller "github.com/kennethnrk/edgernetes-ai/internal/control-plane/controller/registry"
+	frameworkscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/framework"
+	placementscheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/placement"
+	replicascheduler "github.com/kennethnrk/edgernetes-ai/internal/control-plane/scheduler/replica"
+	"github.com/kennethnrk/edgernetes-ai/internal/control-plane/store"
+)
+
+func taintedNode(id string, taints ...store.Taint) store.NodeInfo {
+	node := testNode(id, 4096, 10000)
+	node.Taints = taints
+	return node
+}
+
+func TestToleration_Tolerates(t *testing.T) {
+	battery := store.Taint{Key: "power", Value: "battery", Effect: store.TaintEffectNoSchedule}
+
+	for _, tc := range []struct {
+		name string
+		tol  store.Toleration
+		want bool
+	}{
+		{"equal", store.Toleration{Key: "power", Value: "battery", Effect: store.TaintEffectNoSchedule}, true},
+		{"default operator is equal", store.Toleration{Key: "power", Value: "mains"}, false},
+		{"exists any value", store.Toleration{Key: "power", Operator: store.TolerationOpExists}, true},
+		{"exists other key", store.Toleration{Key: "reserved", Operator: store.TolerationOpExists}, false},
+		{"exists all keys", store.Toleration{Operator: store.TolerationOpExists}, true},
+		{"other effect", store.Toleration{Key: "power", Value: "battery", Effect: store.TaintEffectNoExecute}, false},
+	} {
+		if got := tc.tol.Tolerates(battery); got != tc.want {
+			t.Errorf("%s: Tolerates() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	for _, tol := range []store.Toleration{
+		{Key: "power", Operator: "In"},
+		{Key: "power", Operator: store.TolerationOpExists, Value: "battery"},
+		{Value: "battery"},
+		{Key: "power", Value: "battery", Effect: "Never"},
+	} {
+		if err := tol.Validate(); err == nil {
+			t.Errorf("Validate(%+v) error = nil, want error", tol)
+		}
+	}
+}
+
+func TestFramework_TaintToleration(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	reserved := store.Taint{Key: "reserved", Value: "safety", Effect: store.TaintEffectNoSchedule}
+	nodes := []store.NodeInfo{taintedNode("a-reserved", reserved)}
+
+	_, err := fw.SelectNode(newState(store.ModelInfo{ID: "m"}), nodes)
+	if err == nil || !strings.Contains(err.Error(), "untolerated taint reserved=safety:NoSchedule") {
+		t.Fatalf("SelectNode() error = %v, want untolerated taint", err)
+	}
+
+	state := newState(store.ModelInfo{ID: "m", Tolerations: []store.Toleration{
+		{Key: "reserved", Value: "safety", Effect: store.TaintEffectNoSchedule},
+	}})
+	if _, err := fw.SelectNode(state, nodes); err != nil {
+		t.Errorf("SelectNode() with toleration error = %v", err)
+	}
+}
+
+func TestFramework_PreferNoScheduleIsAvoided(t *testing.T) {
+	fw := frameworkscheduler.Default()
+	nodes := []store.NodeInfo{
+		taintedNode("a-battery", store.Taint{Key: "power", Value: "battery", Effect: store.TaintEffectPreferNoSchedule}),
+		taintedNode("b-mains"),
+	}
+
+	node, err := fw.SelectNode(newState(store.ModelInfo{ID: "m"}), nodes)
+	if err != nil {
+		t.Fatalf("SelectNode() error = %v", err)
+	}
+	if node.ID != "b-mains" {
+		t.Errorf("SelectNode() = %s, want b-mains", node.ID)
+	}
+
+	// The battery node stays schedulable when it is the only option.
+	if _, err := fw.SelectNode(newState(store.ModelInfo{ID: "m"}), nodes[:1]); err != nil {
+		t.Errorf("SelectNode() error = %v, want PreferNoSchedule node accepted", err)
+	}
+}
+
+func TestSetNodeTaints(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	if err := registrycontroller.RegisterNode(s, "node-1", store.NodeInfo{}); err != nil {
+		t.Fatalf("RegisterNode() error = %v", err)
+	}
+	add := []store.Taint{
+		{Key: "power", Value: "battery", Effect: store.TaintEffectNoSchedule},
+		{Key: "power", Value: "battery", Effect: store.TaintEffectPreferNoSchedule},
+		{Key: "reserved", Effect: store.TaintEffectNoExecute},
+	}
+	if err := registrycontroller.SetNodeTaints(s, "node-1", add, nil); err != nil {
+		t.Fatalf("SetNodeTaints() error = %v", err)
+	}
+
+	// Re-adding a key/effect pair replaces the value; removing a key without an effect drops all its taints.
+	if err := registrycontroller.SetNodeTaints(s, "node-1",
+		[]store.Taint{{Key: "reserved", Value: "camera", Effect: store.TaintEffectNoExecute}},
+		[]store.Taint{{Key: "power"}},
+	); err != nil {
+		t.Fatalf("SetNodeTaints() error = %v", err)
+	}
+
+	node, _, err := registrycontroller.GetNodeByID(s, "node-1")
+	if err != nil {
+		t.Fatalf("GetNodeByID() error = %v", err)
+	}
+	if len(node.Taints) != 1 || node.Taints[0].String() != "reserved=camera:NoExecute" {
+		t.Errorf("Taints = %v, want [reserved=camera:NoExecute]", node.Taints)
+	}
+}
+
+func TestScheduler_EvictsOnNoExecuteTaint(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	_, port1 := startFakeDeployAgent(t, false)
+	_, port2 := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port1)
+	requireOnlineNode(t, s, "node-2", port2)
+	requireRegisterModel(t, s, "model-1", "detector", 1)
+	if err := registrycontroller.RegisterModel(s, "model-2", store.ModelInfo{
+		ID:          "model-2",
+		Name:        "safety",
+		Namespace:   "default",
+		Replicas:    1,
+		Tolerations: []store.Toleration{{Key: "reserved", Operator: store.TolerationOpExists}},
+	}); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+
+	sched := placementscheduler.New(s)
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	// Taint whichever node got the detector; the safety model tolerates the taint.
+	detector, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(detector) != 1 {
+		t.Fatalf("expected 1 detector replica, got %d", len(detector))
+	}
+	tainted := detector[0].NodeID
+	safetyBefore, _ := replicascheduler.ListReplicasByModelID(s, "model-2")
+	if err := registrycontroller.SetNodeTaints(s, tainted,
+		[]store.Taint{{Key: "reserved", Value: "safety", Effect: store.TaintEffectNoExecute}}, nil); err != nil {
+		t.Fatalf("SetNodeTaints() error = %v", err)
+	}
+
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	detector, _ = replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(detector) != 1 {
+		t.Fatalf("expected evicted replica to be replaced and pruned, got %d replicas", len(detector))
+	}
+	if detector[0].NodeID == tainted || detector[0].Status != constants.ModelReplicaStatusPending {
+		t.Errorf("detector replica on %s (%s), want pending on the untainted node", detector[0].NodeID, detector[0].Status)
+	}
+
+	node, _, _ := registrycontroller.GetNodeByID(s, tainted)
+	for _, id := range node.AssignedModels {
+		if id == detector[0].ID {
+			t.Errorf("tainted node still lists the detector replica")
+		}
+	}
+
+	safetyAfter, _ := replicascheduler.ListReplicasByModelID(s, "model-2")
+	if len(safetyAfter) != 1 || safetyAfter[0].ID != safetyBefore[0].ID || safetyAfter[0].NodeID != safetyBefore[0].NodeID {
+		t.Errorf("tolerating replica moved: before %+v, after %+v", safetyBefore, safetyAfter)
+	}
+}
+
+func TestScheduler_ReplicasUpdateKeepsTolerations(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	agent, port := startFakeDeployAgent(t, false)
+	requireOnlineNode(t, s, "node-1", port)
+	if err := registrycontroller.SetNodeTaints(s, "node-1",
+		[]store.Taint{{Key: "reserved", Value: "safety", Effect: store.TaintEffectNoExecute}}, nil); err != nil {
+		t.Fatalf("SetNodeTaints() error = %v", err)
+	}
+	if err := registrycontroller.RegisterModel(s, "model-1", store.ModelInfo{
+		ID:          "model-1",
+		Name:        "safety",
+		Namespace:   "default",
+		Replicas:    1,
+		Tolerations: []store.Toleration{{Key: "reserved", Operator: store.TolerationOpExists}},
+	}); err != nil {
+		t.Fatalf("RegisterModel() error = %v", err)
+	}
+
+	sched := placementscheduler.New(s)
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	before, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(before) != 1 || before[0].NodeID != "node-1" {
+		t.Fatalf("before update: replicas = %+v, want 1 on node-1", before)
+	}
+
+	// What `edgectl model update --replicas 2` sends: no scheduling fields.
+	srv := grpcregistry.NewModelRegistryServer(s)
+	if _, err := srv.UpdateModel(context.Background(), &modelpb.UpdateModelRequest{Id: "model-1", Replicas: 2}); err != nil {
+		t.Fatalf("UpdateModel() error = %v", err)
+	}
+	if err := sched.Reconcile(); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	after, _ := replicascheduler.ListReplicasByModelID(s, "model-1")
+	if len(after) != 2 {
+		t.Fatalf("after update: %d replicas, want 2", len(after))
+	}
+	for _, r := range after {
+		if r.NodeID != "node-1" {
+			t.Errorf("replica %s on %q, want node-1", r.ID, r.NodeID)
+		}
+	}
+	if got := len(agent.undeploys()); got != 0 {
+		t.Errorf("undeployed %d replicas from the tainted node, want 0", got)
+	}
+}
+
+func TestParseTaintArgs(t *testing.T) {
+	add, remove, err := client.ParseTaintArgs([]string{"power=battery:NoSchedule", "reserved:NoExecute", "old:PreferNoSchedule-", "legacy-"})
+	if err != nil {
+		t.Fatalf("ParseTaintArgs() error = %v", err)
+	}
+	if got := client.FormatTaints(add); got != "power=battery:NoSchedule,reserved:NoExecute" {
+		t.Errorf("add = %s", got)
+	}
+	if len(remove) != 2 || remove[0].Key != "old" || remove[0].Effect != "PreferNoSchedule" || remove[1].Key != "legacy" || remove[1].Effect != "" {
+		t.Errorf("remove = %v", remove)
+	}
+
+	for _, arg := range []string{"power=battery", ":NoSchedule"} {
+		if _, _, err := client.ParseTaintArgs([]string{arg}); err == nil {
+			t.Errorf("ParseTaintArgs(%q) error = nil, want error", arg)
+		}
+	}
+
+	tol, err := client.ParseToleration("power:NoSchedule")
+	if err != nil {
+		t.Fatalf("ParseToleration() error = %v", err)
+	}
+	if tol.Operator != "Exists" || tol.Key != "power" || tol.Effect != "NoSchedule" {
+		t.Errorf("ParseToleration() = %+v", tol)
+	}
+	tol, _ = client.ParseToleration("power=battery")
+	if tol.Operator != "Equal" || tol.Value != "battery" || tol.Effect != "" {
+		t.Errorf("ParseToleration() = %+v", tol)
+	}
+}